@@ -29,6 +29,18 @@ type UploadIndexerRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	KnowledgeName string                 `protobuf:"bytes,1,opt,name=knowledge_name,json=knowledgeName,proto3" json:"knowledge_name,omitempty"`
 	Uri           string                 `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`
+	// 文件在压缩包内的目录
+	Path string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	// 原始文件名，为空时使用uri中的文件名
+	FileName string `protobuf:"bytes,4,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	// 网页的规范地址，抓取网页时使用
+	SourceUrl string `protobuf:"bytes,5,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`
+	// 网页正文所在元素的CSS选择器，为空时自动识别正文
+	Selector string `protobuf:"bytes,6,opt,name=selector,proto3" json:"selector,omitempty"`
+	// JSON、JSONL文件的字段映射
+	FieldMapping *FieldMapping `protobuf:"bytes,7,opt,name=field_mapping,json=fieldMapping,proto3" json:"field_mapping,omitempty"`
+	// 分块策略：recursive按分隔符分割为固定大小的块，semantic按相邻句子的语义相似度分割，为空时使用配置的默认策略
+	ChunkStrategy string `protobuf:"bytes,8,opt,name=chunk_strategy,json=chunkStrategy,proto3" json:"chunk_strategy,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -77,16 +89,130 @@ func (x *UploadIndexerRequest) GetUri() string {
 	return ""
 }
 
+func (x *UploadIndexerRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *UploadIndexerRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *UploadIndexerRequest) GetSourceUrl() string {
+	if x != nil {
+		return x.SourceUrl
+	}
+	return ""
+}
+
+func (x *UploadIndexerRequest) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *UploadIndexerRequest) GetFieldMapping() *FieldMapping {
+	if x != nil {
+		return x.FieldMapping
+	}
+	return nil
+}
+
+func (x *UploadIndexerRequest) GetChunkStrategy() string {
+	if x != nil {
+		return x.ChunkStrategy
+	}
+	return ""
+}
+
+// JSON、JSONL文件的字段映射，每条记录作为一个文档
+type FieldMapping struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 文档内容的模板，使用{字段名}引用字段，嵌套字段使用.分隔，例如"问题: {question}\n答案: {answer}"，为空时渲染所有字段
+	ContentTemplate string `protobuf:"bytes,1,opt,name=content_template,json=contentTemplate,proto3" json:"content_template,omitempty"`
+	// 作为元数据的字段
+	MetadataFields []string `protobuf:"bytes,2,rep,name=metadata_fields,json=metadataFields,proto3" json:"metadata_fields,omitempty"`
+	// 记录唯一标识所在的字段，重新上传时替换知识库中相同标识的记录
+	IdField       string `protobuf:"bytes,3,opt,name=id_field,json=idField,proto3" json:"id_field,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldMapping) Reset() {
+	*x = FieldMapping{}
+	mi := &file_indexer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldMapping) ProtoMessage() {}
+
+func (x *FieldMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_indexer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldMapping.ProtoReflect.Descriptor instead.
+func (*FieldMapping) Descriptor() ([]byte, []int) {
+	return file_indexer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FieldMapping) GetContentTemplate() string {
+	if x != nil {
+		return x.ContentTemplate
+	}
+	return ""
+}
+
+func (x *FieldMapping) GetMetadataFields() []string {
+	if x != nil {
+		return x.MetadataFields
+	}
+	return nil
+}
+
+func (x *FieldMapping) GetIdField() string {
+	if x != nil {
+		return x.IdField
+	}
+	return ""
+}
+
 type UploadIndexerReply struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DocIds        []string               `protobuf:"bytes,1,rep,name=doc_ids,json=docIds,proto3" json:"doc_ids,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	DocIds []string               `protobuf:"bytes,1,rep,name=doc_ids,json=docIds,proto3" json:"doc_ids,omitempty"`
+	// 知识库文档id
+	DocumentId int64 `protobuf:"varint,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	// 相同内容的文件已经上传过，本次上传未做任何处理
+	Existed bool `protobuf:"varint,3,opt,name=existed,proto3" json:"existed,omitempty"`
+	// 文档版本
+	Version int32 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	// 每个文件的处理结果，上传多个文件或压缩包时返回
+	Files         []*UploadIndexerFile `protobuf:"bytes,5,rep,name=files,proto3" json:"files,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UploadIndexerReply) Reset() {
 	*x = UploadIndexerReply{}
-	mi := &file_indexer_proto_msgTypes[1]
+	mi := &file_indexer_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -98,7 +224,7 @@ func (x *UploadIndexerReply) String() string {
 func (*UploadIndexerReply) ProtoMessage() {}
 
 func (x *UploadIndexerReply) ProtoReflect() protoreflect.Message {
-	mi := &file_indexer_proto_msgTypes[1]
+	mi := &file_indexer_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -111,7 +237,7 @@ func (x *UploadIndexerReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadIndexerReply.ProtoReflect.Descriptor instead.
 func (*UploadIndexerReply) Descriptor() ([]byte, []int) {
-	return file_indexer_proto_rawDescGZIP(), []int{1}
+	return file_indexer_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *UploadIndexerReply) GetDocIds() []string {
@@ -121,18 +247,423 @@ func (x *UploadIndexerReply) GetDocIds() []string {
 	return nil
 }
 
+func (x *UploadIndexerReply) GetDocumentId() int64 {
+	if x != nil {
+		return x.DocumentId
+	}
+	return 0
+}
+
+func (x *UploadIndexerReply) GetExisted() bool {
+	if x != nil {
+		return x.Existed
+	}
+	return false
+}
+
+func (x *UploadIndexerReply) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UploadIndexerReply) GetFiles() []*UploadIndexerFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type UploadIndexerFile struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 文件名
+	FileName string `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	// 文件在压缩包内的目录
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// 知识库文档id
+	DocumentId int64 `protobuf:"varint,3,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	// 文档块id
+	DocIds []string `protobuf:"bytes,4,rep,name=doc_ids,json=docIds,proto3" json:"doc_ids,omitempty"`
+	// 相同内容的文件已经上传过
+	Existed bool `protobuf:"varint,5,opt,name=existed,proto3" json:"existed,omitempty"`
+	// 文档版本
+	Version int32 `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	// 处理失败的原因，为空表示成功
+	Error         string `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadIndexerFile) Reset() {
+	*x = UploadIndexerFile{}
+	mi := &file_indexer_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadIndexerFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadIndexerFile) ProtoMessage() {}
+
+func (x *UploadIndexerFile) ProtoReflect() protoreflect.Message {
+	mi := &file_indexer_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadIndexerFile.ProtoReflect.Descriptor instead.
+func (*UploadIndexerFile) Descriptor() ([]byte, []int) {
+	return file_indexer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UploadIndexerFile) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *UploadIndexerFile) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *UploadIndexerFile) GetDocumentId() int64 {
+	if x != nil {
+		return x.DocumentId
+	}
+	return 0
+}
+
+func (x *UploadIndexerFile) GetDocIds() []string {
+	if x != nil {
+		return x.DocIds
+	}
+	return nil
+}
+
+func (x *UploadIndexerFile) GetExisted() bool {
+	if x != nil {
+		return x.Existed
+	}
+	return false
+}
+
+func (x *UploadIndexerFile) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UploadIndexerFile) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type IngestUrlsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KnowledgeName string                 `protobuf:"bytes,1,opt,name=knowledge_name,json=knowledgeName,proto3" json:"knowledge_name,omitempty"`
+	// 要抓取的网页地址
+	Urls []string `protobuf:"bytes,2,rep,name=urls,proto3" json:"urls,omitempty"`
+	// sitemap.xml的地址，其中的网页会作为起始地址
+	Sitemap string `protobuf:"bytes,3,opt,name=sitemap,proto3" json:"sitemap,omitempty"`
+	// 抓取选项
+	Options *CrawlOptions `protobuf:"bytes,4,opt,name=options,proto3" json:"options,omitempty"`
+	// 定时重新抓取的周期，支持cron表达式（如"0 3 * * *"）或间隔（如"@every 24h"），为空表示不重新抓取
+	RefreshSchedule string `protobuf:"bytes,5,opt,name=refresh_schedule,json=refreshSchedule,proto3" json:"refresh_schedule,omitempty"`
+	// 网页正文所在元素的CSS选择器，为空时自动识别正文，重新抓取时同样使用
+	Selector string `protobuf:"bytes,6,opt,name=selector,proto3" json:"selector,omitempty"`
+	// 分块策略，重新抓取时同样使用
+	ChunkStrategy string `protobuf:"bytes,7,opt,name=chunk_strategy,json=chunkStrategy,proto3" json:"chunk_strategy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestUrlsRequest) Reset() {
+	*x = IngestUrlsRequest{}
+	mi := &file_indexer_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestUrlsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestUrlsRequest) ProtoMessage() {}
+
+func (x *IngestUrlsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_indexer_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestUrlsRequest.ProtoReflect.Descriptor instead.
+func (*IngestUrlsRequest) Descriptor() ([]byte, []int) {
+	return file_indexer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *IngestUrlsRequest) GetKnowledgeName() string {
+	if x != nil {
+		return x.KnowledgeName
+	}
+	return ""
+}
+
+func (x *IngestUrlsRequest) GetUrls() []string {
+	if x != nil {
+		return x.Urls
+	}
+	return nil
+}
+
+func (x *IngestUrlsRequest) GetSitemap() string {
+	if x != nil {
+		return x.Sitemap
+	}
+	return ""
+}
+
+func (x *IngestUrlsRequest) GetOptions() *CrawlOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *IngestUrlsRequest) GetRefreshSchedule() string {
+	if x != nil {
+		return x.RefreshSchedule
+	}
+	return ""
+}
+
+func (x *IngestUrlsRequest) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *IngestUrlsRequest) GetChunkStrategy() string {
+	if x != nil {
+		return x.ChunkStrategy
+	}
+	return ""
+}
+
+type CrawlOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 只抓取与起始地址相同域名的网页
+	SameDomain bool `protobuf:"varint,1,opt,name=same_domain,json=sameDomain,proto3" json:"same_domain,omitempty"`
+	// 最大抓取深度，0表示只抓取起始地址
+	MaxDepth int32 `protobuf:"varint,2,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`
+	// 最多抓取的网页数量，0表示使用默认值100
+	MaxPages int32 `protobuf:"varint,3,opt,name=max_pages,json=maxPages,proto3" json:"max_pages,omitempty"`
+	// 网页地址需要匹配其中一个正则表达式，为空表示不限制
+	IncludePatterns []string `protobuf:"bytes,4,rep,name=include_patterns,json=includePatterns,proto3" json:"include_patterns,omitempty"`
+	// 网页地址匹配其中一个正则表达式时跳过
+	ExcludePatterns []string `protobuf:"bytes,5,rep,name=exclude_patterns,json=excludePatterns,proto3" json:"exclude_patterns,omitempty"`
+	// 遵守robots.txt
+	RespectRobots bool `protobuf:"varint,6,opt,name=respect_robots,json=respectRobots,proto3" json:"respect_robots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CrawlOptions) Reset() {
+	*x = CrawlOptions{}
+	mi := &file_indexer_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrawlOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrawlOptions) ProtoMessage() {}
+
+func (x *CrawlOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_indexer_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrawlOptions.ProtoReflect.Descriptor instead.
+func (*CrawlOptions) Descriptor() ([]byte, []int) {
+	return file_indexer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CrawlOptions) GetSameDomain() bool {
+	if x != nil {
+		return x.SameDomain
+	}
+	return false
+}
+
+func (x *CrawlOptions) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+func (x *CrawlOptions) GetMaxPages() int32 {
+	if x != nil {
+		return x.MaxPages
+	}
+	return 0
+}
+
+func (x *CrawlOptions) GetIncludePatterns() []string {
+	if x != nil {
+		return x.IncludePatterns
+	}
+	return nil
+}
+
+func (x *CrawlOptions) GetExcludePatterns() []string {
+	if x != nil {
+		return x.ExcludePatterns
+	}
+	return nil
+}
+
+func (x *CrawlOptions) GetRespectRobots() bool {
+	if x != nil {
+		return x.RespectRobots
+	}
+	return false
+}
+
+type IngestUrlsReply struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 每个网页的处理结果，file_name为网页的规范地址
+	Files         []*UploadIndexerFile `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestUrlsReply) Reset() {
+	*x = IngestUrlsReply{}
+	mi := &file_indexer_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestUrlsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestUrlsReply) ProtoMessage() {}
+
+func (x *IngestUrlsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_indexer_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestUrlsReply.ProtoReflect.Descriptor instead.
+func (*IngestUrlsReply) Descriptor() ([]byte, []int) {
+	return file_indexer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *IngestUrlsReply) GetFiles() []*UploadIndexerFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
 var File_indexer_proto protoreflect.FileDescriptor
 
 const file_indexer_proto_rawDesc = "" +
 	"\n" +
-	"\rindexer.proto\x12\x03gen\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x17validate/validate.proto\x1a\fcommon.proto\"O\n" +
+	"\rindexer.proto\x12\x03gen\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x17validate/validate.proto\x1a\fcommon.proto\"\xb8\x02\n" +
 	"\x14UploadIndexerRequest\x12%\n" +
 	"\x0eknowledge_name\x18\x01 \x01(\tR\rknowledgeName\x12\x10\n" +
-	"\x03uri\x18\x02 \x01(\tR\x03uri\"-\n" +
+	"\x03uri\x18\x02 \x01(\tR\x03uri\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x12\x1b\n" +
+	"\tfile_name\x18\x04 \x01(\tR\bfileName\x12\x1d\n" +
+	"\n" +
+	"source_url\x18\x05 \x01(\tR\tsourceUrl\x12\x1a\n" +
+	"\bselector\x18\x06 \x01(\tR\bselector\x126\n" +
+	"\rfield_mapping\x18\a \x01(\v2\x11.gen.FieldMappingR\ffieldMapping\x12C\n" +
+	"\x0echunk_strategy\x18\b \x01(\tB\x1c\xfaB\x19r\x17R\x00R\trecursiveR\bsemanticR\rchunkStrategy\"}\n" +
+	"\fFieldMapping\x12)\n" +
+	"\x10content_template\x18\x01 \x01(\tR\x0fcontentTemplate\x12'\n" +
+	"\x0fmetadata_fields\x18\x02 \x03(\tR\x0emetadataFields\x12\x19\n" +
+	"\bid_field\x18\x03 \x01(\tR\aidField\"\xb0\x01\n" +
 	"\x12UploadIndexerReply\x12\x17\n" +
-	"\adoc_ids\x18\x01 \x03(\tR\x06docIds2s\n" +
+	"\adoc_ids\x18\x01 \x03(\tR\x06docIds\x12\x1f\n" +
+	"\vdocument_id\x18\x02 \x01(\x03R\n" +
+	"documentId\x12\x18\n" +
+	"\aexisted\x18\x03 \x01(\bR\aexisted\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x05R\aversion\x12,\n" +
+	"\x05files\x18\x05 \x03(\v2\x16.gen.UploadIndexerFileR\x05files\"\xc8\x01\n" +
+	"\x11UploadIndexerFile\x12\x1b\n" +
+	"\tfile_name\x18\x01 \x01(\tR\bfileName\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x1f\n" +
+	"\vdocument_id\x18\x03 \x01(\x03R\n" +
+	"documentId\x12\x17\n" +
+	"\adoc_ids\x18\x04 \x03(\tR\x06docIds\x12\x18\n" +
+	"\aexisted\x18\x05 \x01(\bR\aexisted\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x05R\aversion\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"\xaa\x02\n" +
+	"\x11IngestUrlsRequest\x12.\n" +
+	"\x0eknowledge_name\x18\x01 \x01(\tB\a\xfaB\x04r\x02\x10\x01R\rknowledgeName\x12\x12\n" +
+	"\x04urls\x18\x02 \x03(\tR\x04urls\x12\x18\n" +
+	"\asitemap\x18\x03 \x01(\tR\asitemap\x12+\n" +
+	"\aoptions\x18\x04 \x01(\v2\x11.gen.CrawlOptionsR\aoptions\x12)\n" +
+	"\x10refresh_schedule\x18\x05 \x01(\tR\x0frefreshSchedule\x12\x1a\n" +
+	"\bselector\x18\x06 \x01(\tR\bselector\x12C\n" +
+	"\x0echunk_strategy\x18\a \x01(\tB\x1c\xfaB\x19r\x17R\x00R\trecursiveR\bsemanticR\rchunkStrategy\"\xe6\x01\n" +
+	"\fCrawlOptions\x12\x1f\n" +
+	"\vsame_domain\x18\x01 \x01(\bR\n" +
+	"sameDomain\x12\x1b\n" +
+	"\tmax_depth\x18\x02 \x01(\x05R\bmaxDepth\x12\x1b\n" +
+	"\tmax_pages\x18\x03 \x01(\x05R\bmaxPages\x12)\n" +
+	"\x10include_patterns\x18\x04 \x03(\tR\x0fincludePatterns\x12)\n" +
+	"\x10exclude_patterns\x18\x05 \x03(\tR\x0fexcludePatterns\x12%\n" +
+	"\x0erespect_robots\x18\x06 \x01(\bR\rrespectRobots\"?\n" +
+	"\x0fIngestUrlsReply\x12,\n" +
+	"\x05files\x18\x01 \x03(\v2\x16.gen.UploadIndexerFileR\x05files2\xcf\x01\n" +
 	"\x0eIndexerService\x12a\n" +
-	"\rUploadIndexer\x12\x19.gen.UploadIndexerRequest\x1a\x17.gen.UploadIndexerReply\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/api/v1/indexer(\x01BQ\n" +
+	"\rUploadIndexer\x12\x19.gen.UploadIndexerRequest\x1a\x17.gen.UploadIndexerReply\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/api/v1/indexer(\x01\x12Z\n" +
+	"\n" +
+	"IngestUrls\x12\x16.gen.IngestUrlsRequest\x1a\x14.gen.IngestUrlsReply\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/api/v1/indexer/urlBQ\n" +
 	"\acom.genB\fIndexerProtoP\x01Z\fragx/api/gen\xa2\x02\x03GXX\xaa\x02\x03Gen\xca\x02\x03Gen\xe2\x02\x0fGen\\GPBMetadata\xea\x02\x03Genb\x06proto3"
 
 var (
@@ -147,19 +678,30 @@ func file_indexer_proto_rawDescGZIP() []byte {
 	return file_indexer_proto_rawDescData
 }
 
-var file_indexer_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_indexer_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_indexer_proto_goTypes = []any{
 	(*UploadIndexerRequest)(nil), // 0: gen.UploadIndexerRequest
-	(*UploadIndexerReply)(nil),   // 1: gen.UploadIndexerReply
+	(*FieldMapping)(nil),         // 1: gen.FieldMapping
+	(*UploadIndexerReply)(nil),   // 2: gen.UploadIndexerReply
+	(*UploadIndexerFile)(nil),    // 3: gen.UploadIndexerFile
+	(*IngestUrlsRequest)(nil),    // 4: gen.IngestUrlsRequest
+	(*CrawlOptions)(nil),         // 5: gen.CrawlOptions
+	(*IngestUrlsReply)(nil),      // 6: gen.IngestUrlsReply
 }
 var file_indexer_proto_depIdxs = []int32{
-	0, // 0: gen.IndexerService.UploadIndexer:input_type -> gen.UploadIndexerRequest
-	1, // 1: gen.IndexerService.UploadIndexer:output_type -> gen.UploadIndexerReply
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1, // 0: gen.UploadIndexerRequest.field_mapping:type_name -> gen.FieldMapping
+	3, // 1: gen.UploadIndexerReply.files:type_name -> gen.UploadIndexerFile
+	5, // 2: gen.IngestUrlsRequest.options:type_name -> gen.CrawlOptions
+	3, // 3: gen.IngestUrlsReply.files:type_name -> gen.UploadIndexerFile
+	0, // 4: gen.IndexerService.UploadIndexer:input_type -> gen.UploadIndexerRequest
+	4, // 5: gen.IndexerService.IngestUrls:input_type -> gen.IngestUrlsRequest
+	2, // 6: gen.IndexerService.UploadIndexer:output_type -> gen.UploadIndexerReply
+	6, // 7: gen.IndexerService.IngestUrls:output_type -> gen.IngestUrlsReply
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_indexer_proto_init() }
@@ -174,7 +716,7 @@ func file_indexer_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_indexer_proto_rawDesc), len(file_indexer_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
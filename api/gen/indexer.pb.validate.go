@@ -61,6 +61,54 @@ func (m *UploadIndexerRequest) validate(all bool) error {
 
 	// no validation rules for Uri
 
+	// no validation rules for Path
+
+	// no validation rules for FileName
+
+	// no validation rules for SourceUrl
+
+	// no validation rules for Selector
+
+	if all {
+		switch v := interface{}(m.GetFieldMapping()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UploadIndexerRequestValidationError{
+					field:  "FieldMapping",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UploadIndexerRequestValidationError{
+					field:  "FieldMapping",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFieldMapping()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UploadIndexerRequestValidationError{
+				field:  "FieldMapping",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if _, ok := _UploadIndexerRequest_ChunkStrategy_InLookup[m.GetChunkStrategy()]; !ok {
+		err := UploadIndexerRequestValidationError{
+			field:  "ChunkStrategy",
+			reason: "value must be in list [ recursive semantic]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
 	if len(errors) > 0 {
 		return UploadIndexerRequestMultiError(errors)
 	}
@@ -141,6 +189,115 @@ var _ interface {
 	ErrorName() string
 } = UploadIndexerRequestValidationError{}
 
+var _UploadIndexerRequest_ChunkStrategy_InLookup = map[string]struct{}{
+	"":          {},
+	"recursive": {},
+	"semantic":  {},
+}
+
+// Validate checks the field values on FieldMapping with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *FieldMapping) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on FieldMapping with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in FieldMappingMultiError, or
+// nil if none found.
+func (m *FieldMapping) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *FieldMapping) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ContentTemplate
+
+	// no validation rules for IdField
+
+	if len(errors) > 0 {
+		return FieldMappingMultiError(errors)
+	}
+
+	return nil
+}
+
+// FieldMappingMultiError is an error wrapping multiple validation errors
+// returned by FieldMapping.ValidateAll() if the designated constraints aren't met.
+type FieldMappingMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m FieldMappingMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m FieldMappingMultiError) AllErrors() []error { return m }
+
+// FieldMappingValidationError is the validation error returned by
+// FieldMapping.Validate if the designated constraints aren't met.
+type FieldMappingValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FieldMappingValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FieldMappingValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FieldMappingValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FieldMappingValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FieldMappingValidationError) ErrorName() string { return "FieldMappingValidationError" }
+
+// Error satisfies the builtin error interface
+func (e FieldMappingValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFieldMapping.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FieldMappingValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FieldMappingValidationError{}
+
 // Validate checks the field values on UploadIndexerReply with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
@@ -163,6 +320,46 @@ func (m *UploadIndexerReply) validate(all bool) error {
 
 	var errors []error
 
+	// no validation rules for DocumentId
+
+	// no validation rules for Existed
+
+	// no validation rules for Version
+
+	for idx, item := range m.GetFiles() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, UploadIndexerReplyValidationError{
+						field:  fmt.Sprintf("Files[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, UploadIndexerReplyValidationError{
+						field:  fmt.Sprintf("Files[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return UploadIndexerReplyValidationError{
+					field:  fmt.Sprintf("Files[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if len(errors) > 0 {
 		return UploadIndexerReplyMultiError(errors)
 	}
@@ -242,3 +439,525 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = UploadIndexerReplyValidationError{}
+
+// Validate checks the field values on UploadIndexerFile with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UploadIndexerFile) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UploadIndexerFile with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UploadIndexerFileMultiError, or nil if none found.
+func (m *UploadIndexerFile) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UploadIndexerFile) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for FileName
+
+	// no validation rules for Path
+
+	// no validation rules for DocumentId
+
+	// no validation rules for Existed
+
+	// no validation rules for Version
+
+	// no validation rules for Error
+
+	if len(errors) > 0 {
+		return UploadIndexerFileMultiError(errors)
+	}
+
+	return nil
+}
+
+// UploadIndexerFileMultiError is an error wrapping multiple validation errors
+// returned by UploadIndexerFile.ValidateAll() if the designated constraints
+// aren't met.
+type UploadIndexerFileMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UploadIndexerFileMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UploadIndexerFileMultiError) AllErrors() []error { return m }
+
+// UploadIndexerFileValidationError is the validation error returned by
+// UploadIndexerFile.Validate if the designated constraints aren't met.
+type UploadIndexerFileValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UploadIndexerFileValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UploadIndexerFileValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UploadIndexerFileValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UploadIndexerFileValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UploadIndexerFileValidationError) ErrorName() string {
+	return "UploadIndexerFileValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UploadIndexerFileValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUploadIndexerFile.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UploadIndexerFileValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UploadIndexerFileValidationError{}
+
+// Validate checks the field values on IngestUrlsRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *IngestUrlsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on IngestUrlsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// IngestUrlsRequestMultiError, or nil if none found.
+func (m *IngestUrlsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *IngestUrlsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetKnowledgeName()) < 1 {
+		err := IngestUrlsRequestValidationError{
+			field:  "KnowledgeName",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Sitemap
+
+	if all {
+		switch v := interface{}(m.GetOptions()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, IngestUrlsRequestValidationError{
+					field:  "Options",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, IngestUrlsRequestValidationError{
+					field:  "Options",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetOptions()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return IngestUrlsRequestValidationError{
+				field:  "Options",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for RefreshSchedule
+
+	// no validation rules for Selector
+
+	if _, ok := _IngestUrlsRequest_ChunkStrategy_InLookup[m.GetChunkStrategy()]; !ok {
+		err := IngestUrlsRequestValidationError{
+			field:  "ChunkStrategy",
+			reason: "value must be in list [ recursive semantic]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return IngestUrlsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// IngestUrlsRequestMultiError is an error wrapping multiple validation errors
+// returned by IngestUrlsRequest.ValidateAll() if the designated constraints
+// aren't met.
+type IngestUrlsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m IngestUrlsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m IngestUrlsRequestMultiError) AllErrors() []error { return m }
+
+// IngestUrlsRequestValidationError is the validation error returned by
+// IngestUrlsRequest.Validate if the designated constraints aren't met.
+type IngestUrlsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e IngestUrlsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e IngestUrlsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e IngestUrlsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e IngestUrlsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e IngestUrlsRequestValidationError) ErrorName() string {
+	return "IngestUrlsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e IngestUrlsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sIngestUrlsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = IngestUrlsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = IngestUrlsRequestValidationError{}
+
+var _IngestUrlsRequest_ChunkStrategy_InLookup = map[string]struct{}{
+	"":          {},
+	"recursive": {},
+	"semantic":  {},
+}
+
+// Validate checks the field values on CrawlOptions with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *CrawlOptions) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CrawlOptions with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in CrawlOptionsMultiError, or
+// nil if none found.
+func (m *CrawlOptions) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CrawlOptions) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SameDomain
+
+	// no validation rules for MaxDepth
+
+	// no validation rules for MaxPages
+
+	// no validation rules for RespectRobots
+
+	if len(errors) > 0 {
+		return CrawlOptionsMultiError(errors)
+	}
+
+	return nil
+}
+
+// CrawlOptionsMultiError is an error wrapping multiple validation errors
+// returned by CrawlOptions.ValidateAll() if the designated constraints aren't met.
+type CrawlOptionsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CrawlOptionsMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CrawlOptionsMultiError) AllErrors() []error { return m }
+
+// CrawlOptionsValidationError is the validation error returned by
+// CrawlOptions.Validate if the designated constraints aren't met.
+type CrawlOptionsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CrawlOptionsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CrawlOptionsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CrawlOptionsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CrawlOptionsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CrawlOptionsValidationError) ErrorName() string { return "CrawlOptionsValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CrawlOptionsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCrawlOptions.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CrawlOptionsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CrawlOptionsValidationError{}
+
+// Validate checks the field values on IngestUrlsReply with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *IngestUrlsReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on IngestUrlsReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// IngestUrlsReplyMultiError, or nil if none found.
+func (m *IngestUrlsReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *IngestUrlsReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetFiles() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, IngestUrlsReplyValidationError{
+						field:  fmt.Sprintf("Files[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, IngestUrlsReplyValidationError{
+						field:  fmt.Sprintf("Files[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return IngestUrlsReplyValidationError{
+					field:  fmt.Sprintf("Files[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return IngestUrlsReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// IngestUrlsReplyMultiError is an error wrapping multiple validation errors
+// returned by IngestUrlsReply.ValidateAll() if the designated constraints
+// aren't met.
+type IngestUrlsReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m IngestUrlsReplyMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m IngestUrlsReplyMultiError) AllErrors() []error { return m }
+
+// IngestUrlsReplyValidationError is the validation error returned by
+// IngestUrlsReply.Validate if the designated constraints aren't met.
+type IngestUrlsReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e IngestUrlsReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e IngestUrlsReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e IngestUrlsReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e IngestUrlsReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e IngestUrlsReplyValidationError) ErrorName() string {
+	return "IngestUrlsReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e IngestUrlsReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sIngestUrlsReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = IngestUrlsReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = IngestUrlsReplyValidationError{}
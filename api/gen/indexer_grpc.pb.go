@@ -20,6 +20,7 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	IndexerService_UploadIndexer_FullMethodName = "/gen.IndexerService/UploadIndexer"
+	IndexerService_IngestUrls_FullMethodName    = "/gen.IndexerService/IngestUrls"
 )
 
 // IndexerServiceClient is the client API for IndexerService service.
@@ -28,6 +29,8 @@ const (
 type IndexerServiceClient interface {
 	// 上传文件索引，定义成stream方式，这样就不会生成http.pb文件了，需要自己实现http请求
 	UploadIndexer(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadIndexerRequest, UploadIndexerReply], error)
+	// 抓取网页并建立索引，每个网页作为一个单独的文档
+	IngestUrls(ctx context.Context, in *IngestUrlsRequest, opts ...grpc.CallOption) (*IngestUrlsReply, error)
 }
 
 type indexerServiceClient struct {
@@ -51,12 +54,24 @@ func (c *indexerServiceClient) UploadIndexer(ctx context.Context, opts ...grpc.C
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type IndexerService_UploadIndexerClient = grpc.ClientStreamingClient[UploadIndexerRequest, UploadIndexerReply]
 
+func (c *indexerServiceClient) IngestUrls(ctx context.Context, in *IngestUrlsRequest, opts ...grpc.CallOption) (*IngestUrlsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IngestUrlsReply)
+	err := c.cc.Invoke(ctx, IndexerService_IngestUrls_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // IndexerServiceServer is the server API for IndexerService service.
 // All implementations must embed UnimplementedIndexerServiceServer
 // for forward compatibility.
 type IndexerServiceServer interface {
 	// 上传文件索引，定义成stream方式，这样就不会生成http.pb文件了，需要自己实现http请求
 	UploadIndexer(grpc.ClientStreamingServer[UploadIndexerRequest, UploadIndexerReply]) error
+	// 抓取网页并建立索引，每个网页作为一个单独的文档
+	IngestUrls(context.Context, *IngestUrlsRequest) (*IngestUrlsReply, error)
 	mustEmbedUnimplementedIndexerServiceServer()
 }
 
@@ -70,6 +85,9 @@ type UnimplementedIndexerServiceServer struct{}
 func (UnimplementedIndexerServiceServer) UploadIndexer(grpc.ClientStreamingServer[UploadIndexerRequest, UploadIndexerReply]) error {
 	return status.Errorf(codes.Unimplemented, "method UploadIndexer not implemented")
 }
+func (UnimplementedIndexerServiceServer) IngestUrls(context.Context, *IngestUrlsRequest) (*IngestUrlsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IngestUrls not implemented")
+}
 func (UnimplementedIndexerServiceServer) mustEmbedUnimplementedIndexerServiceServer() {}
 func (UnimplementedIndexerServiceServer) testEmbeddedByValue()                        {}
 
@@ -98,13 +116,36 @@ func _IndexerService_UploadIndexer_Handler(srv interface{}, stream grpc.ServerSt
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type IndexerService_UploadIndexerServer = grpc.ClientStreamingServer[UploadIndexerRequest, UploadIndexerReply]
 
+func _IndexerService_IngestUrls_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestUrlsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexerServiceServer).IngestUrls(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexerService_IngestUrls_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexerServiceServer).IngestUrls(ctx, req.(*IngestUrlsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // IndexerService_ServiceDesc is the grpc.ServiceDesc for IndexerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var IndexerService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "gen.IndexerService",
 	HandlerType: (*IndexerServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "IngestUrls",
+			Handler:    _IndexerService_IngestUrls_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "UploadIndexer",
@@ -32,7 +32,11 @@ type CreateKnowledgeBaseRequest struct {
 	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
 	Category    string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
 	// 知识库状态
-	Status        int32 `protobuf:"varint,5,opt,name=status,proto3" json:"status,omitempty"`
+	Status int32 `protobuf:"varint,5,opt,name=status,proto3" json:"status,omitempty"`
+	// 使用对话模型为文档块生成摘要、关键词和上下文标题
+	Enrich bool `protobuf:"varint,6,opt,name=enrich,proto3" json:"enrich,omitempty"`
+	// 把上下文标题和关键词加在文档块内容前面一起向量化，需要同时开启enrich
+	EnrichEmbed   bool `protobuf:"varint,7,opt,name=enrich_embed,json=enrichEmbed,proto3" json:"enrich_embed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -102,6 +106,20 @@ func (x *CreateKnowledgeBaseRequest) GetStatus() int32 {
 	return 0
 }
 
+func (x *CreateKnowledgeBaseRequest) GetEnrich() bool {
+	if x != nil {
+		return x.Enrich
+	}
+	return false
+}
+
+func (x *CreateKnowledgeBaseRequest) GetEnrichEmbed() bool {
+	if x != nil {
+		return x.EnrichEmbed
+	}
+	return false
+}
+
 type ListKnowledgeBaseRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -222,7 +240,11 @@ type KnowledgeBase struct {
 	// 知识库创建时间
 	CreateTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=createTime,proto3" json:"createTime,omitempty"`
 	// 知识库更新时间
-	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updateTime,proto3" json:"updateTime,omitempty"`
+	UpdateTime *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updateTime,proto3" json:"updateTime,omitempty"`
+	// 使用对话模型为文档块生成摘要、关键词和上下文标题
+	Enrich bool `protobuf:"varint,8,opt,name=enrich,proto3" json:"enrich,omitempty"`
+	// 把上下文标题和关键词加在文档块内容前面一起向量化
+	EnrichEmbed   bool `protobuf:"varint,9,opt,name=enrich_embed,json=enrichEmbed,proto3" json:"enrich_embed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -306,23 +328,39 @@ func (x *KnowledgeBase) GetUpdateTime() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *KnowledgeBase) GetEnrich() bool {
+	if x != nil {
+		return x.Enrich
+	}
+	return false
+}
+
+func (x *KnowledgeBase) GetEnrichEmbed() bool {
+	if x != nil {
+		return x.EnrichEmbed
+	}
+	return false
+}
+
 var File_knowledge_base_proto protoreflect.FileDescriptor
 
 const file_knowledge_base_proto_rawDesc = "" +
 	"\n" +
-	"\x14knowledge_base.proto\x12\x03gen\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x17validate/validate.proto\x1a\fcommon.proto\"\x96\x01\n" +
+	"\x14knowledge_base.proto\x12\x03gen\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x17validate/validate.proto\x1a\fcommon.proto\"\xd1\x01\n" +
 	"\x1aCreateKnowledgeBaseRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
 	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x16\n" +
-	"\x06status\x18\x05 \x01(\x05R\x06status\"b\n" +
+	"\x06status\x18\x05 \x01(\x05R\x06status\x12\x16\n" +
+	"\x06enrich\x18\x06 \x01(\bR\x06enrich\x12!\n" +
+	"\fenrich_embed\x18\a \x01(\bR\venrichEmbed\"b\n" +
 	"\x18ListKnowledgeBaseRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\x05R\x06status\x12\x1a\n" +
 	"\bcategory\x18\x03 \x01(\tR\bcategory\"@\n" +
 	"\x16ListKnowledgeBaseReply\x12&\n" +
-	"\x04list\x18\x01 \x03(\v2\x12.gen.KnowledgeBaseR\x04list\"\x81\x02\n" +
+	"\x04list\x18\x01 \x03(\v2\x12.gen.KnowledgeBaseR\x04list\"\xbc\x02\n" +
 	"\rKnowledgeBase\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -334,7 +372,9 @@ const file_knowledge_base_proto_rawDesc = "" +
 	"createTime\x12:\n" +
 	"\n" +
 	"updateTime\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"updateTime2\xd5\x03\n" +
+	"updateTime\x12\x16\n" +
+	"\x06enrich\x18\b \x01(\bR\x06enrich\x12!\n" +
+	"\fenrich_embed\x18\t \x01(\bR\venrichEmbed2\xd5\x03\n" +
 	"\x14KnowledgeBaseService\x12[\n" +
 	"\x13CreateKnowledgeBase\x12\x1f.gen.CreateKnowledgeBaseRequest\x1a\f.gen.IDReply\"\x15\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
 	"/api/v1/kb\x12`\n" +
@@ -67,6 +67,10 @@ func (m *CreateKnowledgeBaseRequest) validate(all bool) error {
 
 	// no validation rules for Status
 
+	// no validation rules for Enrich
+
+	// no validation rules for EnrichEmbed
+
 	if len(errors) > 0 {
 		return CreateKnowledgeBaseRequestMultiError(errors)
 	}
@@ -481,6 +485,10 @@ func (m *KnowledgeBase) validate(all bool) error {
 		}
 	}
 
+	// no validation rules for Enrich
+
+	// no validation rules for EnrichEmbed
+
 	if len(errors) > 0 {
 		return KnowledgeBaseMultiError(errors)
 	}
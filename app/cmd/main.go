@@ -1,9 +1,14 @@
 package main
 
 import (
+	"expvar"
 	"flag"
 	"os"
 	"ragx/app/pkg/ai"
+	"ragx/app/pkg/blob"
+	"ragx/app/pkg/docparser"
+	"ragx/app/pkg/embedder"
+	"ragx/app/pkg/redact"
 
 	"github.com/google/wire"
 
@@ -14,7 +19,11 @@ import (
 	"github.com/go-kratos/kratos/v2/encoding"
 	"github.com/sirupsen/logrus"
 
+	"ragx/app/internal/biz"
+	"ragx/app/internal/biz/entity"
 	"ragx/app/internal/conf"
+	"ragx/app/internal/consts"
+	"ragx/app/internal/server"
 
 	"github.com/go-kratos/kratos/v2"
 	"github.com/go-kratos/kratos/v2/config"
@@ -85,7 +94,7 @@ func init() {
 	flag.StringVar(&flagconf, "conf", "./configs", "config path, eg: -conf configs")
 }
 
-func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server) *kratos.App {
+func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server, scheduler *server.SchedulerServer) *kratos.App {
 	return kratos.New(
 		kratos.ID(id),
 		kratos.Name(Name),
@@ -95,17 +104,107 @@ func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server) *kratos.App {
 		kratos.Server(
 			//gs,
 			hs,
+			scheduler,
 		),
 	)
 }
 
-func newAIClient(c *conf.Bootstrap) *ai.Client {
-	return ai.NewClient(os.Getenv("OPENAI_API_KEY"),
+func newAIClient(c *conf.Bootstrap, d biz.Data, store blob.BlobStore, cache embedder.Cache) *ai.Client {
+	client := ai.NewClient(os.Getenv("OPENAI_API_KEY"),
+		ai.WithBlobStore(store),
+		ai.WithSpreadsheetConfig(&docparser.SpreadsheetConfig{
+			HeaderRow:  int(c.Parser.GetSpreadsheet().GetHeaderRow()),
+			RowsPerDoc: int(c.Parser.GetSpreadsheet().GetRowsPerDoc()),
+		}),
+		ai.WithPDFConfig(&docparser.PDFConfig{
+			StripHeaderFooter: c.Parser.GetPdf().GetStripHeaderFooter(),
+		}),
+		ai.WithChunkConfig(&ai.ChunkConfig{
+			Tokenizer:            c.Chunk.GetTokenizer(),
+			Encoding:             c.Chunk.GetEncoding(),
+			ChunkSize:            int(c.Chunk.GetChunkSize()),
+			OverlapSize:          int(c.Chunk.GetOverlapSize()),
+			MergeSize:            int(c.Chunk.GetMergeSize()),
+			Strategy:             c.Chunk.GetStrategy(),
+			BreakpointPercentile: c.Chunk.GetBreakpointPercentile(),
+			MinChunkSize:         int(c.Chunk.GetMinChunkSize()),
+			ParentChild:          c.Chunk.GetParentChild(),
+			ChildChunkSize:       int(c.Chunk.GetChildChunkSize()),
+		}),
+		ai.WithQuestionsPerChunk(int(c.Chunk.GetQuestionsPerChunk())),
+		ai.WithEnrichConcurrency(int(c.Chunk.GetEnrichConcurrency())),
+		ai.WithRedactConfig(redactConfig(c.Redact)),
 		ai.WithOnlyChatModel(false),
+		ai.WithVectorStore(c.Data.GetVectorStore().GetDriver()),
+		ai.WithPGVectorConfig(&ai.PGVectorConfig{
+			DB:               d.DB(),
+			Table:            entity.TableNameKnowledgeChunk,
+			ActiveStatus:     consts.StatusActive,
+			M:                int(c.Data.GetVectorStore().GetPgvector().GetM()),
+			EfConstruction:   int(c.Data.GetVectorStore().GetPgvector().GetEfConstruction()),
+			EfSearch:         int(c.Data.GetVectorStore().GetPgvector().GetEfSearch()),
+			TextSearchConfig: c.Data.GetVectorStore().GetPgvector().GetTextSearchConfig(),
+		}),
+		ai.WithHybridSearch(c.Data.GetVectorStore().GetHybrid()),
 		ai.WithESAddress(c.Data.Elasticsearch.Address),
 		ai.WithIndexName(c.Data.Elasticsearch.IndexName),
 		ai.WithEmbeddingApiKey(os.Getenv("ARK_EMBEDDING_API_KEY")),
+		ai.WithEmbedderConfig(embeddingConfig(c.Embedding)),
+		ai.WithEmbedBatchConfig(&embedder.BatchConfig{
+			Cache:       cache,
+			BatchSize:   int(c.Embedding.GetBatchSize()),
+			Concurrency: int(c.Embedding.GetConcurrency()),
+			MaxRetries:  int(c.Embedding.GetMaxRetries()),
+		}),
 	)
+	// 累计的嵌入统计，通过/debug/vars查看
+	expvar.Publish("embedding", expvar.Func(func() any {
+		return client.EmbedStats().Snapshot()
+	}))
+	return client
+}
+
+// 嵌入器配置，没有配置时返回nil，使用方舟的默认模型
+func embeddingConfig(c *conf.Embedding) *embedder.Config {
+	if c == nil {
+		return nil
+	}
+	provider := c.GetProvider()
+	if provider == "" {
+		provider = embedder.ProviderArk
+	}
+	apiKey := c.GetApiKey()
+	if apiKey == "" {
+		apiKey = os.Getenv("EMBEDDING_API_KEY")
+	}
+	if apiKey == "" && provider == embedder.ProviderArk {
+		apiKey = os.Getenv("ARK_EMBEDDING_API_KEY")
+	}
+	return &embedder.Config{
+		Provider:   provider,
+		Model:      c.GetModel(),
+		BaseURL:    c.GetBaseUrl(),
+		APIKey:     apiKey,
+		Dimensions: int(c.GetDimensions()),
+		Timeout:    c.GetTimeout().AsDuration(),
+	}
+}
+
+// 脱敏配置，没有启用时返回nil
+func redactConfig(c *conf.Redact) *redact.Config {
+	if !c.GetEnabled() {
+		return nil
+	}
+	rules := make([]redact.Rule, 0, len(c.GetRules()))
+	for _, r := range c.GetRules() {
+		rules = append(rules, redact.Rule{Kind: r.GetKind(), Pattern: r.GetPattern()})
+	}
+	return &redact.Config{
+		Mode:      c.GetMode(),
+		Detectors: c.GetDetectors(),
+		Rules:     rules,
+		Salt:      c.GetSalt(),
+	}
 }
 
 var ProviderSet = wire.NewSet(newAIClient)
@@ -26,21 +26,35 @@ import (
 // wireApp init kratos application.
 func wireApp(confServer *conf.Server, confData *conf.Data, bootstrap *conf.Bootstrap, logger log.Logger) (*kratos.App, func(), error) {
 	grpcServer := server.NewGRPCServer(confServer, logger)
-	client := newAIClient(bootstrap)
-	chatUsecase := biz.NewChatUsecase(client, logger)
-	streamService := service.NewStreamService(chatUsecase, logger)
 	bizData, cleanup, err := data.NewData(confData, logger)
 	if err != nil {
 		return nil, nil, err
 	}
+	blobStore, err := data.NewBlobStore(confData)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	cache, err := data.NewEmbeddingCache(bootstrap, bizData)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	client := newAIClient(bootstrap, bizData, blobStore, cache)
+	knowledgeChunkRepo := repo.NewKnowledgeChunkRepo(bizData, logger)
+	chatUsecase := biz.NewChatUsecase(client, knowledgeChunkRepo, logger)
+	streamService := service.NewStreamService(chatUsecase, logger)
 	knowledgeBaseRepo := repo.NewKnowledgeBaseRepo(bizData, logger)
 	knowledgeBaseUsecase := biz.NewKnowledgeBaseUsecase(knowledgeBaseRepo, logger)
 	knowledgeBaseService := service.NewKnowledgeBaseService(knowledgeBaseUsecase)
 	knowledgeDocumentRepo := repo.NewKnowledgeDocumentRepo(bizData, logger)
-	knowledgeDocumentUsecase := biz.NewKnowledgeDocumentUsecase(knowledgeDocumentRepo, logger, client)
-	indexerService := service.NewIndexerServiceService(knowledgeDocumentUsecase)
-	httpServer := server.NewHTTPServer(confServer, logger, streamService, knowledgeBaseService, indexerService)
-	app := newApp(logger, grpcServer, httpServer)
+	knowledgeDocumentUsecase := biz.NewKnowledgeDocumentUsecase(knowledgeDocumentRepo, knowledgeChunkRepo, knowledgeBaseRepo, logger, client, blobStore)
+	knowledgeDocumentService := service.NewKnowledgeDocumentService(knowledgeDocumentUsecase)
+	indexerService := service.NewIndexerServiceService(knowledgeDocumentUsecase, bootstrap, blobStore)
+	httpServer := server.NewHTTPServer(confServer, logger, streamService, knowledgeBaseService, knowledgeDocumentService, indexerService)
+	knowledgeRefreshUsecase := biz.NewKnowledgeRefreshUsecase(knowledgeDocumentRepo, knowledgeDocumentUsecase, bizData, logger)
+	schedulerServer := server.NewSchedulerServer(knowledgeRefreshUsecase, logger)
+	app := newApp(logger, grpcServer, httpServer, schedulerServer)
 	return app, func() {
 		cleanup()
 	}, nil
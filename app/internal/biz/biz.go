@@ -19,4 +19,5 @@ var ProviderSet = wire.NewSet(
 	NewChatUsecase,
 	NewKnowledgeBaseUsecase,
 	NewKnowledgeDocumentUsecase,
+	NewKnowledgeRefreshUsecase,
 )
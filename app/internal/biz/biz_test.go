@@ -0,0 +1,174 @@
+package biz_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ragx/app/internal/biz"
+	"ragx/app/internal/biz/entity"
+	"ragx/app/internal/biz/query"
+	"ragx/app/internal/data/repo"
+	"ragx/app/pkg/ai"
+	"ragx/app/pkg/cache/redis"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// 使用sqlite的测试数据
+type testData struct {
+	db  *gorm.DB
+	rdb *redis.Client
+}
+
+func (d *testData) DB() *gorm.DB       { return d.db }
+func (d *testData) Rdb() *redis.Client { return d.rdb }
+
+// 测试用的仓储，每个测试使用独立的sqlite数据库
+type testRepos struct {
+	data  *testData
+	kb    biz.KnowledgeBaseRepo
+	doc   biz.KnowledgeDocumentRepo
+	chunk biz.KnowledgeChunkRepo
+}
+
+func newTestRepos(t *testing.T) *testRepos {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&entity.KnowledgeBase{}, &entity.KnowledgeDocument{}, &entity.KnowledgeChunk{}); err != nil {
+		t.Fatal(err)
+	}
+	// biz中的查询条件使用query包的全局变量，测试不能并行
+	query.SetDefault(db)
+	d := &testData{db: db}
+	return &testRepos{
+		data:  d,
+		kb:    repo.NewKnowledgeBaseRepo(d, log.DefaultLogger),
+		doc:   repo.NewKnowledgeDocumentRepo(d, log.DefaultLogger),
+		chunk: repo.NewKnowledgeChunkRepo(d, log.DefaultLogger),
+	}
+}
+
+// 读取本地文件的加载器，每个文件是一个文档
+type fileLoader struct{}
+
+func (fileLoader) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) ([]*schema.Document, error) {
+	b, err := os.ReadFile(src.URI)
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.Document{{Content: string(b), MetaData: map[string]any{}}}, nil
+}
+
+// 按空行分割文档的转换器
+type paragraphSplitter struct{}
+
+func (paragraphSplitter) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	var docs []*schema.Document
+	for _, doc := range src {
+		for _, p := range strings.Split(doc.Content, "\n\n") {
+			docs = append(docs, &schema.Document{Content: p, MetaData: doc.MetaData})
+		}
+	}
+	return docs, nil
+}
+
+// 记录调用的向量存储，storeErr不为空时写入失败，检索时返回results的副本
+type fakeStore struct {
+	results  []*schema.Document
+	stored   []string
+	reused   []string
+	deleted  []string
+	storeErr error
+	// 写入时调用，检查文档块是否已经保存
+	onStore func(docs []*schema.Document)
+}
+
+func (s *fakeStore) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) ([]string, error) {
+	if s.onStore != nil {
+		s.onStore(docs)
+	}
+	if s.storeErr != nil {
+		return nil, s.storeErr
+	}
+	for _, doc := range docs {
+		s.stored = append(s.stored, doc.ID)
+	}
+	return s.stored, nil
+}
+
+func (s *fakeStore) Search(ctx context.Context, query string, opts *ai.SearchOptions) ([]*schema.Document, error) {
+	res := make([]*schema.Document, 0, len(s.results))
+	for _, doc := range s.results {
+		meta := make(map[string]any, len(doc.MetaData))
+		for k, v := range doc.MetaData {
+			meta[k] = v
+		}
+		res = append(res, (&schema.Document{ID: doc.ID, Content: doc.Content, MetaData: meta}).WithScore(doc.Score()))
+	}
+	return res, nil
+}
+
+func (s *fakeStore) Delete(ctx context.Context, ids []string) error {
+	s.deleted = append(s.deleted, ids...)
+	return nil
+}
+
+func (s *fakeStore) Reuse(ctx context.Context, ids []string) error {
+	s.reused = append(s.reused, ids...)
+	return nil
+}
+
+// 返回固定回答的对话模型，记录收到的消息
+type fakeChatModel struct {
+	answer string
+	inputs [][]*schema.Message
+}
+
+func (m *fakeChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	m.inputs = append(m.inputs, input)
+	return schema.AssistantMessage(m.answer, nil), nil
+}
+
+func (m *fakeChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	msg, err := m.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{msg}), nil
+}
+
+func (m *fakeChatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	return m, nil
+}
+
+// 读取本地文件、按空行分割，使用向量存储的ai客户端
+func newTestAIClient(store ai.VectorStore) *ai.Client {
+	return &ai.Client{Loader: fileLoader{}, Transformer: paragraphSplitter{}, VectorStore: store, Indexer: store}
+}
+
+func newTestDocumentUsecase(r *testRepos, store ai.VectorStore) *biz.KnowledgeDocumentUsecase {
+	return biz.NewKnowledgeDocumentUsecase(r.doc, r.chunk, r.kb, log.DefaultLogger, newTestAIClient(store), nil)
+}
+
+// 在临时目录中写入上传的文件，返回文件路径
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
@@ -2,28 +2,116 @@ package biz
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	pb "ragx/api/gen"
+	"ragx/app/internal/biz/query"
 	"ragx/app/internal/consts"
 	"ragx/app/pkg/ai"
+	"ragx/app/pkg/docparser"
+	"strings"
 
 	"github.com/cloudwego/eino/schema"
 	"github.com/go-kratos/kratos/v2/log"
 )
 
 type ChatUsecase struct {
-	aiClient *ai.Client
-	log      *log.Helper
+	aiClient  *ai.Client
+	chunkRepo KnowledgeChunkRepo
+	log       *log.Helper
 }
 
-func NewChatUsecase(aiClient *ai.Client, logger log.Logger) *ChatUsecase {
+func NewChatUsecase(aiClient *ai.Client, chunkRepo KnowledgeChunkRepo, logger log.Logger) *ChatUsecase {
 	return &ChatUsecase{
-		aiClient: aiClient,
-		log:      log.NewHelper(logger),
+		aiClient:  aiClient,
+		chunkRepo: chunkRepo,
+		log:       log.NewHelper(logger),
 	}
 }
 
+const (
+	// 默认检索的文档块数量
+	defaultTopK = 5
+	// 默认的相似度阈值
+	defaultScore = 0.2
+)
+
+// ChatStreamReply 流式回答和回答引用的文档块
+type ChatStreamReply struct {
+	Stream     *schema.StreamReader[*schema.Message]
+	References []*pb.Document
+}
+
+// 从知识库中检索与问题相关的文档块
+func (c *ChatUsecase) retrieve(ctx context.Context, req *pb.ChatRequest) ([]*schema.Document, error) {
+	topK, score := int(req.TopK), req.Score
+	if topK <= 0 {
+		topK = defaultTopK
+	}
+	if score <= 0 {
+		score = defaultScore
+	}
+	docs, err := c.aiClient.Retrieve(ctx, req.KnowledgeName, req.Question, topK, score)
+	if err != nil {
+		c.log.Errorf("ChatUsecase.retrieve err: %+v", err)
+		return nil, err
+	}
+	return docs, nil
+}
+
+// 将命中的子块替换为所属的父块作为提示词的上下文，多个子块属于同一个父块时只保留一个
+// 父块的得分取子块的最高分，没有父块或父块已经失效的文档块保持不变
+func (c *ChatUsecase) expandParents(ctx context.Context, docs []*schema.Document) ([]*schema.Document, error) {
+	parentIDs := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		if id, ok := doc.MetaData[ai.MetaParentID].(string); ok && id != "" {
+			parentIDs = append(parentIDs, id)
+		}
+	}
+	if len(parentIDs) == 0 {
+		return docs, nil
+	}
+	qc := query.KnowledgeChunk
+	chunks, err := c.chunkRepo.ListAll(ctx, qc.ChunkID.In(parentIDs...), qc.IsParent.Is(true), qc.Status.Eq(consts.StatusActive))
+	if err != nil {
+		c.log.Errorf("ChatUsecase.expandParents ListAll err: %+v", err)
+		return nil, err
+	}
+	parents := make(map[string]*schema.Document, len(chunks))
+	for _, chunk := range chunks {
+		if _, ok := parents[chunk.ChunkID]; ok {
+			continue
+		}
+		meta := map[string]any{}
+		if chunk.Ext != "" {
+			_ = json.Unmarshal([]byte(chunk.Ext), &meta)
+		}
+		meta[ai.KnowledgeName] = chunk.KnowledgeBaseName
+		parents[chunk.ChunkID] = &schema.Document{ID: chunk.ChunkID, Content: chunk.Content, MetaData: meta}
+	}
+
+	res := make([]*schema.Document, 0, len(docs))
+	added := make(map[string]bool, len(docs))
+	for _, doc := range docs {
+		id, _ := doc.MetaData[ai.MetaParentID].(string)
+		parent, ok := parents[id]
+		if !ok {
+			res = append(res, doc)
+			continue
+		}
+		if parent.Score() < doc.Score() {
+			parent.WithScore(doc.Score())
+		}
+		if !added[id] {
+			added[id] = true
+			res = append(res, parent)
+		}
+	}
+	return res, nil
+}
+
 // 将检索到的上下文和问题转换为消息列表
-func (c *ChatUsecase) docsMessages(ctx context.Context, req *pb.ChatRequest) ([]*schema.Message, error) {
+func (c *ChatUsecase) docsMessages(ctx context.Context, req *pb.ChatRequest, docs []*schema.Document) ([]*schema.Message, error) {
 	// todo 从历史获取
 	//chatHistory, err := x.eh.GetHistory(convID, 100)
 	//if err != nil {
@@ -40,8 +128,8 @@ func (c *ChatUsecase) docsMessages(ctx context.Context, req *pb.ChatRequest) ([]
 	// 使用模板生成消息
 	tmpl := consts.PromptTemplate()
 	messages, err := tmpl.Format(ctx, map[string]any{
-		"role": "你是一个专业的AI助手，能够根据提供的参考信息准确回答用户问题。",
-		//"docs":    req.Docs,
+		"role":     "你是一个专业的AI助手，能够根据提供的参考信息准确回答用户问题。",
+		"docs":     formatDocs(docs),
 		"question": req.Question,
 		// 对话历史（这个例子里模拟两轮对话历史）
 		//"chat_history": []*schema.Message{
@@ -58,12 +146,38 @@ func (c *ChatUsecase) docsMessages(ctx context.Context, req *pb.ChatRequest) ([]
 }
 
 func (c *ChatUsecase) Chat(ctx context.Context, req *pb.ChatRequest) (*pb.ChatReply, error) {
-	return &pb.ChatReply{}, nil
+	docs, err := c.retrieve(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	// 提示词使用父块，引用仍然是命中的子块
+	contextDocs, err := c.expandParents(ctx, docs)
+	if err != nil {
+		return nil, err
+	}
+	messages, err := c.docsMessages(ctx, req, contextDocs)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := c.aiClient.ChatModel.Generate(ctx, messages)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ChatReply{Answer: msg.Content, References: references(docs)}, nil
 }
 
-func (c *ChatUsecase) ChatStream(ctx context.Context, req *pb.ChatRequest) (*schema.StreamReader[*schema.Message], error) {
+func (c *ChatUsecase) ChatStream(ctx context.Context, req *pb.ChatRequest) (*ChatStreamReply, error) {
+	docs, err := c.retrieve(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	// 提示词使用父块，引用仍然是命中的子块
+	contextDocs, err := c.expandParents(ctx, docs)
+	if err != nil {
+		return nil, err
+	}
 	// 转换为消息列表
-	messages, err := c.docsMessages(ctx, req)
+	messages, err := c.docsMessages(ctx, req, contextDocs)
 	if err != nil {
 		return nil, err
 	}
@@ -72,5 +186,50 @@ func (c *ChatUsecase) ChatStream(ctx context.Context, req *pb.ChatRequest) (*sch
 	if err != nil {
 		return nil, err
 	}
-	return sr, err
+	return &ChatStreamReply{Stream: sr, References: references(docs)}, nil
+}
+
+// 将文档块格式化为提示词中的参考内容，带上文件名、页码等来源信息，便于模型引用
+func formatDocs(docs []*schema.Document) string {
+	var b strings.Builder
+	for i, doc := range docs {
+		fmt.Fprintf(&b, "[%d]", i+1)
+		if src := docSource(doc); src != "" {
+			b.WriteString(" 来源: " + src)
+		}
+		b.WriteString("\n" + doc.Content + "\n\n")
+	}
+	return b.String()
+}
+
+// 文档块的来源，如"手册.pdf 第3页"
+func docSource(doc *schema.Document) string {
+	var parts []string
+	if name, ok := doc.MetaData[ai.MetaFileName].(string); ok && name != "" {
+		parts = append(parts, name)
+	}
+	if page, ok := doc.MetaData[docparser.MetaPage]; ok {
+		parts = append(parts, fmt.Sprintf("第%v页", page))
+	}
+	if slide, ok := doc.MetaData[docparser.MetaSlideNumber]; ok {
+		parts = append(parts, fmt.Sprintf("第%v张幻灯片", slide))
+	}
+	return strings.Join(parts, " ")
+}
+
+// 将检索到的文档块转换为回答的引用，元数据中的扩展数据、向量不返回
+func references(docs []*schema.Document) []*pb.Document {
+	res := make([]*pb.Document, 0, len(docs))
+	for _, doc := range docs {
+		meta := make(map[string]string, len(doc.MetaData))
+		for k, v := range doc.MetaData {
+			switch v.(type) {
+			case string, float64, int, int64, bool:
+				meta[k] = fmt.Sprint(v)
+			}
+		}
+		delete(meta, ai.FieldExtra)
+		res = append(res, &pb.Document{Id: doc.ID, Content: doc.Content, Metadata: meta})
+	}
+	return res
 }
@@ -0,0 +1,201 @@
+package biz_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	pb "ragx/api/gen"
+	"ragx/app/internal/biz"
+	"ragx/app/internal/biz/entity"
+	"ragx/app/internal/consts"
+	"ragx/app/pkg/ai"
+	"ragx/app/pkg/docparser"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// 模拟es索引文档时保存的扩展数据，只保存ExtKeys中的元数据
+func withExt(t *testing.T, doc *schema.Document) *schema.Document {
+	t.Helper()
+	ext := make(map[string]any)
+	for _, k := range ai.ExtKeys {
+		if v, ok := doc.MetaData[k]; ok {
+			ext[k] = v
+		}
+	}
+	b, err := json.Marshal(ext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &schema.Document{ID: doc.ID, Content: doc.Content, MetaData: map[string]any{ai.FieldExtra: string(b), ai.KnowledgeName: "kb"}}
+}
+
+func TestChatPDFPageReferences(t *testing.T) {
+	m := &fakeChatModel{answer: "回答"}
+	// PDF解析器每页输出一个文档，页码是int，经过es保存后从扩展数据中解析出来
+	store := &fakeStore{results: []*schema.Document{
+		withExt(t, &schema.Document{ID: "page1", Content: "第一章 简介", MetaData: map[string]any{ai.MetaFileName: "manual.pdf", "_extension": ".pdf", docparser.MetaPage: 1}}),
+		withExt(t, &schema.Document{ID: "page3", Content: "第三章 安装", MetaData: map[string]any{ai.MetaFileName: "manual.pdf", "_extension": ".pdf", docparser.MetaPage: 3}}),
+	}}
+	client := newTestAIClient(store)
+	client.ChatModel = m
+	uc := biz.NewChatUsecase(client, newTestRepos(t).chunk, log.DefaultLogger)
+	ctx := context.Background()
+
+	req := &pb.ChatRequest{KnowledgeName: "kb", Question: "如何安装"}
+	reply, err := uc.Chat(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream, err := uc.ChatStream(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Stream.Close()
+	for i, refs := range [][]*pb.Document{reply.References, stream.References} {
+		if len(refs) != 2 {
+			t.Fatalf("references %d = %v", i, refs)
+		}
+		// 页码返回到引用的元数据中，扩展数据本身不返回
+		for j, want := range []string{"1", "3"} {
+			meta := refs[j].Metadata
+			if meta[docparser.MetaPage] != want || meta[ai.MetaFileName] != "manual.pdf" {
+				t.Errorf("reference %d metadata = %v", j, meta)
+			}
+			if _, ok := meta[ai.FieldExtra]; ok {
+				t.Errorf("reference %d has ext: %v", j, meta)
+			}
+		}
+		// 提示词中带上页码，便于模型引用
+		prompt := ""
+		for _, msg := range m.inputs[i] {
+			prompt += msg.Content
+		}
+		if !strings.Contains(prompt, "来源: manual.pdf 第3页") {
+			t.Errorf("prompt %d = %q", i, prompt)
+		}
+	}
+}
+
+// 检索到的子块，元数据中记录所属的父块
+func childDoc(id, parentID string, score float64) *schema.Document {
+	meta := map[string]any{ai.MetaFileName: "manual.md"}
+	if parentID != "" {
+		meta[ai.MetaParentID] = parentID
+	}
+	return (&schema.Document{ID: id, Content: "子块" + id, MetaData: meta}).WithScore(score)
+}
+
+// 向量存储中的子块，父块id保存在扩展数据中
+func storedChildDoc(t *testing.T, id, parentID string, score float64) *schema.Document {
+	t.Helper()
+	doc := childDoc(id, parentID, score)
+	ext, err := ai.ExtJSON(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return (&schema.Document{ID: id, Content: doc.Content, MetaData: map[string]any{ai.FieldExtra: ext}}).WithScore(score)
+}
+
+// 保存父块p1(生效)和p2(已替代)
+func createParents(t *testing.T, r *testRepos) {
+	t.Helper()
+	_, err := r.chunk.BatchCreate(context.Background(), []*entity.KnowledgeChunk{
+		{KnowledgeDocID: 1, KnowledgeBaseName: "kb", ChunkID: "p1", Content: "父块p1", Ext: `{"_file_name":"manual.md","h1":"安装"}`,
+			IsParent: true, Status: consts.StatusActive},
+		{KnowledgeDocID: 1, KnowledgeBaseName: "kb", ChunkID: "p2", Content: "父块p2", IsParent: true, Status: consts.StatusSuperseded},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func docIDs(docs []*schema.Document) string {
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.ID
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestExpandParents(t *testing.T) {
+	r := newTestRepos(t)
+	createParents(t, r)
+	uc := biz.NewChatUsecase(newTestAIClient(&fakeStore{}), r.chunk, log.DefaultLogger)
+	ctx := context.Background()
+
+	docs := []*schema.Document{
+		childDoc("c1", "p1", 1.3),
+		childDoc("c2", "p2", 1.5),
+		childDoc("c3", "p1", 1.6),
+		childDoc("c4", "", 1.2),
+		childDoc("c5", "p9", 1.1),
+	}
+	res, err := biz.ExpandParents(uc, ctx, docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 同一个父块的两个子块合并为一个父块，已替代或不存在的父块保留子块
+	if docIDs(res) != "p1,c2,c4,c5" {
+		t.Fatalf("expandParents() = %s", docIDs(res))
+	}
+	p1 := res[0]
+	if p1.Score() != 1.6 || p1.Content != "父块p1" || p1.MetaData["h1"] != "安装" || p1.MetaData[ai.KnowledgeName] != "kb" {
+		t.Errorf("parent = %+v, score = %v", p1, p1.Score())
+	}
+	// 没有父块时不查询数据库，原样返回
+	plain := []*schema.Document{childDoc("c6", "", 1)}
+	if res, err = biz.ExpandParents(uc, ctx, plain); err != nil || docIDs(res) != "c6" {
+		t.Errorf("expandParents() = %s, %v", docIDs(res), err)
+	}
+}
+
+func TestChatParentContext(t *testing.T) {
+	r := newTestRepos(t)
+	createParents(t, r)
+	store := &fakeStore{results: []*schema.Document{
+		storedChildDoc(t, "c1", "p1", 1.3),
+		storedChildDoc(t, "c2", "p2", 1.5),
+		storedChildDoc(t, "c3", "p1", 1.6),
+	}}
+	m := &fakeChatModel{answer: "回答"}
+	client := newTestAIClient(store)
+	client.ChatModel = m
+	uc := biz.NewChatUsecase(client, r.chunk, log.DefaultLogger)
+	ctx := context.Background()
+	req := &pb.ChatRequest{KnowledgeName: "kb", Question: "如何安装"}
+
+	reply, err := uc.Chat(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream, err := uc.ChatStream(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Stream.Close()
+	for i, refs := range [][]*pb.Document{reply.References, stream.References} {
+		// 引用仍然是命中的子块
+		ids := make([]string, len(refs))
+		for j, ref := range refs {
+			ids[j] = ref.Id
+		}
+		if strings.Join(ids, ",") != "c1,c2,c3" || refs[0].Content != "子块c1" || refs[0].Metadata[ai.MetaParentID] != "p1" {
+			t.Errorf("references %d = %v", i, refs)
+		}
+		// 提示词中使用父块，两个子块的父块只出现一次
+		prompt := ""
+		for _, msg := range m.inputs[i] {
+			prompt += msg.Content
+		}
+		if strings.Count(prompt, "父块p1") != 1 || strings.Contains(prompt, "子块c1") || !strings.Contains(prompt, "子块c2") {
+			t.Errorf("prompt %d = %q", i, prompt)
+		}
+	}
+	if reply.Answer != "回答" {
+		t.Errorf("answer = %q", reply.Answer)
+	}
+}
@@ -19,6 +19,8 @@ type KnowledgeBase struct {
 	Status      int32     `gorm:"column:status;not null" json:"status"`
 	CreateTime  time.Time `gorm:"column:create_time;not null" json:"create_time"`
 	UpdateTime  time.Time `gorm:"column:update_time;not null" json:"update_time"`
+	Enrich      bool      `gorm:"column:enrich;not null" json:"enrich"`
+	EnrichEmbed bool      `gorm:"column:enrich_embed;not null" json:"enrich_embed"`
 }
 
 // TableName KnowledgeBase's table name
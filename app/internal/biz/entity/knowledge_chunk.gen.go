@@ -12,14 +12,19 @@ const TableNameKnowledgeChunk = "knowledge_chunk"
 
 // KnowledgeChunk mapped from table <knowledge_chunk>
 type KnowledgeChunk struct {
-	ID             int64     `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
-	KnowledgeDocID int64     `gorm:"column:knowledge_doc_id;not null" json:"knowledge_doc_id"`
-	ChunkID        string    `gorm:"column:chunk_id;not null" json:"chunk_id"`
-	Content        string    `gorm:"column:content;not null" json:"content"`
-	Ext            string    `gorm:"column:ext;not null" json:"ext"`
-	Status         int32     `gorm:"column:status;not null" json:"status"`
-	CreatedAt      time.Time `gorm:"column:created_at;not null" json:"created_at"`
-	UpdatedAt      time.Time `gorm:"column:updated_at;not null" json:"updated_at"`
+	ID                int64     `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
+	KnowledgeDocID    int64     `gorm:"column:knowledge_doc_id;not null" json:"knowledge_doc_id"`
+	ChunkID           string    `gorm:"column:chunk_id;not null" json:"chunk_id"`
+	Content           string    `gorm:"column:content;not null" json:"content"`
+	Ext               string    `gorm:"column:ext;not null" json:"ext"`
+	Status            int32     `gorm:"column:status;not null" json:"status"`
+	CreatedAt         time.Time `gorm:"column:created_at;not null" json:"created_at"`
+	UpdatedAt         time.Time `gorm:"column:updated_at;not null" json:"updated_at"`
+	KnowledgeBaseName string    `gorm:"column:knowledge_base_name;not null" json:"knowledge_base_name"`
+	ContentHash       string    `gorm:"column:content_hash;not null" json:"content_hash"`
+	RecordID          string    `gorm:"column:record_id;not null" json:"record_id"`
+	ParentID          string    `gorm:"column:parent_id;not null" json:"parent_id"`
+	IsParent          bool      `gorm:"column:is_parent;not null" json:"is_parent"`
 }
 
 // TableName KnowledgeChunk's table name
@@ -12,12 +12,27 @@ const TableNameKnowledgeDocument = "knowledge_document"
 
 // KnowledgeDocument mapped from table <knowledge_document>
 type KnowledgeDocument struct {
-	ID                int64     `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
-	KnowledgeBaseName string    `gorm:"column:knowledge_base_name;not null" json:"knowledge_base_name"`
-	FileName          string    `gorm:"column:file_name;not null" json:"file_name"`
-	Status            int32     `gorm:"column:status;not null" json:"status"`
-	CreatedAt         time.Time `gorm:"column:created_at;not null" json:"created_at"`
-	UpdatedAt         time.Time `gorm:"column:updated_at;not null" json:"updated_at"`
+	ID                int64      `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
+	KnowledgeBaseName string     `gorm:"column:knowledge_base_name;not null" json:"knowledge_base_name"`
+	FileName          string     `gorm:"column:file_name;not null" json:"file_name"`
+	Status            int32      `gorm:"column:status;not null" json:"status"`
+	CreatedAt         time.Time  `gorm:"column:created_at;not null" json:"created_at"`
+	UpdatedAt         time.Time  `gorm:"column:updated_at;not null" json:"updated_at"`
+	FileHash          string     `gorm:"column:file_hash;not null" json:"file_hash"`
+	Version           int32      `gorm:"column:version;not null;default:1" json:"version"`
+	Path              string     `gorm:"column:path;not null" json:"path"`
+	URI               string     `gorm:"column:uri;not null" json:"uri"`
+	SourceURL         string     `gorm:"column:source_url;not null" json:"source_url"`
+	RefreshSchedule   string     `gorm:"column:refresh_schedule;not null" json:"refresh_schedule"`
+	ETag              string     `gorm:"column:etag;not null" json:"etag"`
+	LastModified      string     `gorm:"column:last_modified;not null" json:"last_modified"`
+	NextRefreshAt     *time.Time `gorm:"column:next_refresh_at" json:"next_refresh_at"`
+	RespectRobots     bool       `gorm:"column:respect_robots;not null" json:"respect_robots"`
+	Selector          string     `gorm:"column:selector;not null" json:"selector"`
+	FieldMapping      string     `gorm:"column:field_mapping;not null" json:"field_mapping"`
+	ChunkStrategy     string     `gorm:"column:chunk_strategy;not null" json:"chunk_strategy"`
+	EnrichTokens      int64      `gorm:"column:enrich_tokens;not null" json:"enrich_tokens"`
+	PiiReport         string     `gorm:"column:pii_report;not null" json:"pii_report"`
 }
 
 // TableName KnowledgeDocument's table name
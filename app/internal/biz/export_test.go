@@ -0,0 +1,14 @@
+package biz
+
+// 导出内部方法给biz_test包的测试使用，biz_test需要引用data/repo，不能放在biz包内
+var (
+	StoreChunks = (*KnowledgeDocumentUsecase).storeChunks
+	NextVersion = (*KnowledgeDocumentUsecase).nextVersion
+	Supersede   = (*KnowledgeDocumentUsecase).supersede
+	SetRefresh  = (*KnowledgeDocumentUsecase).setRefresh
+
+	NextRefreshAt = nextRefreshAt
+	SaveParents   = (*KnowledgeDocumentUsecase).saveParents
+
+	ExpandParents = (*ChatUsecase).expandParents
+)
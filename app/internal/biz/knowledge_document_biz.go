@@ -1,13 +1,31 @@
 package biz
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
 	pb "ragx/api/gen"
 	"ragx/app/internal/biz/entity"
 	"ragx/app/internal/biz/query"
+	"ragx/app/internal/consts"
 	"ragx/app/pkg/ai"
+	"ragx/app/pkg/blob"
+	"ragx/app/pkg/crawler"
+	"ragx/app/pkg/docparser"
+	"ragx/app/pkg/embedder"
+	"ragx/app/pkg/redact"
+	"ragx/app/pkg/utils"
 
 	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"gorm.io/gen"
@@ -42,58 +60,752 @@ type KnowledgeDocumentRepo interface {
 }
 
 type KnowledgeDocumentUsecase struct {
-	repo     KnowledgeDocumentRepo
-	log      *log.Helper
-	aiClient *ai.Client
+	repo      KnowledgeDocumentRepo
+	chunkRepo KnowledgeChunkRepo
+	kbRepo    KnowledgeBaseRepo
+	log       *log.Helper
+	aiClient  *ai.Client
+	blobStore blob.BlobStore
 }
 
-func NewKnowledgeDocumentUsecase(repo KnowledgeDocumentRepo, logger log.Logger, aiClient *ai.Client) *KnowledgeDocumentUsecase {
-	return &KnowledgeDocumentUsecase{repo: repo, log: log.NewHelper(logger), aiClient: aiClient}
+func NewKnowledgeDocumentUsecase(repo KnowledgeDocumentRepo, chunkRepo KnowledgeChunkRepo, kbRepo KnowledgeBaseRepo, logger log.Logger, aiClient *ai.Client, blobStore blob.BlobStore) *KnowledgeDocumentUsecase {
+	return &KnowledgeDocumentUsecase{repo: repo, chunkRepo: chunkRepo, kbRepo: kbRepo, log: log.NewHelper(logger), aiClient: aiClient, blobStore: blobStore}
 }
 
 func (uc *KnowledgeDocumentUsecase) Create(ctx context.Context, req *pb.UploadIndexerRequest) (*pb.UploadIndexerReply, error) {
-	//var obj = entity.KnowledgeDocument{
-	//	KnowledgeBaseName: req.KnowledgeName,
-	//	FileName:          req.Uri,
-	//	Status:            consts.StatusPending,
-	//}
-	//e, err := uc.repo.Create(ctx, &obj)
-	//if err != nil {
-	//	uc.log.Errorf("KnowledgeDocumentUsecase.Create err: %+v", gerror.Wrap(err, ""))
-	//	return nil, err
-	//}
-	// 先调用加载器，加载文件内容
-	docs, err := uc.aiClient.Loader.Load(ctx, document.Source{URI: req.Uri})
-	if err != nil {
-		uc.log.Errorf("KnowledgeDocumentUsecase.Create Load err: %+v", gerror.Wrap(err, ""))
-		return nil, err
-	}
-	// 调用转换器，对文档进行分隔、过滤、合并
-	//docs, err = uc.aiClient.Transformer.Transform(ctx, docs)
-	//if err != nil {
-	//	uc.log.Errorf("KnowledgeDocumentUsecase.Create Transform err: %+v", gerror.Wrap(err, ""))
-	//	return nil, err
-	//}
-	//// 合并文档
-	//docs, err = ai.DocAddIDAndMerge(ctx, docs)
-	//if err != nil {
-	//	uc.log.Errorf("KnowledgeDocumentUsecase.Create Transform err: %+v", gerror.Wrap(err, ""))
-	//	return nil, err
-	//}
-	// 调用索引器，将文档索引到向量数据库
-	// 设置知识库的名称
-	ctx = context.WithValue(ctx, ai.KnowledgeName, req.KnowledgeName)
-	ids, err := uc.aiClient.Indexer.Store(ctx, docs)
+	mapping, err := fieldMapping(req.FieldMapping)
 	if err != nil {
-		uc.log.Errorf("KnowledgeDocumentUsecase.Create Index err: %+v", gerror.Wrap(err, ""))
 		return nil, err
 	}
+	// 计算文件的sha256，同一个知识库内相同的文件只处理一次
+	fileHash, err := uc.fileHash(ctx, req.Uri)
+	if err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.Create fileHash err: %+v", err)
+		return nil, err
+	}
+	// 网页按地址区分，内容相同的不同网页是不同的文档，各自保存自己的同步状态
+	// 正文选择器、字段映射或分块策略不同时得到的文档块不同，作为新的版本重新处理
+	q := query.KnowledgeDocument
+	obj, err := uc.repo.GetByConditions(ctx, q.KnowledgeBaseName.Eq(req.KnowledgeName), q.FileHash.Eq(fileHash),
+		q.SourceURL.Eq(req.SourceUrl), q.Selector.Eq(req.Selector), q.FieldMapping.Eq(mapping), q.ChunkStrategy.Eq(req.ChunkStrategy),
+		q.Status.Neq(consts.StatusSuperseded))
+	if err != nil && !entity.IsNotFound(err) {
+		uc.log.Errorf("KnowledgeDocumentUsecase.Create GetByConditions err: %+v", err)
+		return nil, err
+	}
+	if obj != nil && obj.Status != consts.StatusFailed {
+		// 已经上传过相同的文件，直接返回已有的文档
+		ids, err := uc.chunkIDs(ctx, obj.ID)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.UploadIndexerReply{DocIds: ids, DocumentId: obj.ID, Existed: true, Version: obj.Version}, nil
+	}
+	if obj == nil {
+		// 同名文件重新上传时生成新的版本
+		fileName := req.FileName
+		if fileName == "" {
+			fileName = filepath.Base(req.Uri)
+		}
+		version, err := uc.nextVersion(ctx, req.KnowledgeName, req.Path, fileName)
+		if err != nil {
+			return nil, err
+		}
+		obj, err = uc.repo.Create(ctx, &entity.KnowledgeDocument{
+			KnowledgeBaseName: req.KnowledgeName,
+			FileName:          fileName,
+			Path:              req.Path,
+			URI:               req.Uri,
+			SourceURL:         req.SourceUrl,
+			Selector:          req.Selector,
+			FieldMapping:      mapping,
+			ChunkStrategy:     req.ChunkStrategy,
+			FileHash:          fileHash,
+			Version:           version,
+			Status:            consts.StatusIndexing,
+		})
+		if err != nil {
+			uc.log.Errorf("KnowledgeDocumentUsecase.Create err: %+v", err)
+			return nil, err
+		}
+	} else {
+		// 上次处理失败的文档，清理掉残留的文档块后重新处理
+		if _, err = uc.chunkRepo.DeleteByConditions(ctx, query.KnowledgeChunk.KnowledgeDocID.Eq(obj.ID)); err != nil {
+			uc.log.Errorf("KnowledgeDocumentUsecase.Create DeleteByConditions err: %+v", err)
+			return nil, err
+		}
+		// 使用本次上传的文件重新处理
+		obj.URI = req.Uri
+		if _, err = uc.repo.Update(ctx, obj, q.URI); err != nil {
+			uc.log.Errorf("KnowledgeDocumentUsecase.Create Update err: %+v", err)
+			return nil, err
+		}
+		if err = uc.updateStatus(ctx, obj, consts.StatusIndexing); err != nil {
+			return nil, err
+		}
+	}
 
+	ids, err := uc.index(ctx, obj, req.Uri)
+	if err != nil {
+		_ = uc.updateStatus(ctx, obj, consts.StatusFailed)
+		return nil, err
+	}
+	if err = uc.updateStatus(ctx, obj, consts.StatusActive); err != nil {
+		return nil, err
+	}
+	// 新版本生效后，替换掉旧版本
+	if err = uc.supersede(ctx, obj); err != nil {
+		return nil, err
+	}
+	if err = uc.upsertRecords(ctx, obj); err != nil {
+		return nil, err
+	}
 	return &pb.UploadIndexerReply{
-		DocIds: ids,
+		DocIds:     ids,
+		DocumentId: obj.ID,
+		Version:    obj.Version,
 	}, nil
 }
 
+// 抓取网页并建立索引，网页内容保存到blob存储，每个网页作为一个单独的文档，以规范地址作为文件名
+func (uc *KnowledgeDocumentUsecase) IngestURLs(ctx context.Context, req *pb.IngestUrlsRequest) (*pb.IngestUrlsReply, error) {
+	opts := req.GetOptions()
+	c, err := crawler.New(crawler.Options{
+		SameDomain:    opts.GetSameDomain(),
+		MaxDepth:      int(opts.GetMaxDepth()),
+		MaxPages:      int(opts.GetMaxPages()),
+		Include:       opts.GetIncludePatterns(),
+		Exclude:       opts.GetExcludePatterns(),
+		RespectRobots: opts.GetRespectRobots(),
+	})
+	if err != nil {
+		return nil, pb.ErrorIngestUrlInvalid("%s", err.Error())
+	}
+	if req.RefreshSchedule != "" {
+		if _, err = nextRefreshAt(req.RefreshSchedule, time.Now()); err != nil {
+			return nil, pb.ErrorIngestUrlInvalid("刷新周期不合法: %s", err.Error())
+		}
+	}
+	seeds := req.Urls
+	if req.Sitemap != "" {
+		urls, err := c.Sitemap(ctx, req.Sitemap)
+		if err != nil {
+			uc.log.Errorf("KnowledgeDocumentUsecase.IngestURLs Sitemap err: %+v", err)
+			return nil, pb.ErrorIngestUrlInvalid("读取sitemap失败: %s", err.Error())
+		}
+		seeds = append(seeds, urls...)
+	}
+	if len(seeds) == 0 {
+		return nil, pb.ErrorIngestUrlInvalid("请提供网页地址或sitemap地址")
+	}
+	res, err := c.Crawl(ctx, seeds)
+	if err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.IngestURLs Crawl err: %+v", err)
+		return nil, err
+	}
+
+	var reply pb.IngestUrlsReply
+	for _, page := range res.Pages {
+		file := &pb.UploadIndexerFile{FileName: page.CanonicalURL}
+		r, err := uc.ingestPage(ctx, req.KnowledgeName, page, req.RefreshSchedule, req.Selector, req.ChunkStrategy, opts.GetRespectRobots())
+		if err != nil {
+			file.Error = err.Error()
+		} else {
+			file.DocumentId, file.DocIds, file.Existed, file.Version = r.DocumentId, r.DocIds, r.Existed, r.Version
+		}
+		reply.Files = append(reply.Files, file)
+	}
+	for _, f := range res.Failed {
+		reply.Files = append(reply.Files, &pb.UploadIndexerFile{FileName: f.URL, Error: f.Err.Error()})
+	}
+	return &reply, nil
+}
+
+// 保存网页内容并建立索引，记录网页的ETag、Last-Modified、刷新周期和是否遵守robots.txt，用于定时重新抓取
+// selector是正文所在元素的CSS选择器，为空时自动识别正文，strategy是分块策略
+func (uc *KnowledgeDocumentUsecase) ingestPage(ctx context.Context, knowledgeName string, page *crawler.Page, schedule, selector, strategy string, respectRobots bool) (*pb.UploadIndexerReply, error) {
+	// 扩展名决定使用哪个解析器
+	ext := ".html"
+	if page.ContentType == "text/plain" {
+		ext = ".txt"
+	}
+	key := path.Join(knowledgeName, "web", utils.UniqueID()+ext)
+	if err := uc.blobStore.Put(ctx, key, bytes.NewReader(page.Body), int64(len(page.Body))); err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.ingestPage Put err: %+v", err)
+		return nil, err
+	}
+	reply, err := uc.Create(ctx, &pb.UploadIndexerRequest{
+		KnowledgeName: knowledgeName,
+		Uri:           blob.URI(key),
+		FileName:      page.CanonicalURL,
+		SourceUrl:     page.CanonicalURL,
+		Selector:      selector,
+		ChunkStrategy: strategy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reply.Existed {
+		// 内容没有变化，保存的网页内容不再需要
+		if err := uc.blobStore.Delete(ctx, key); err != nil {
+			uc.log.Errorf("KnowledgeDocumentUsecase.ingestPage Delete err: %+v", err)
+		}
+	}
+	if err = uc.setRefresh(ctx, reply.DocumentId, schedule, respectRobots, page.ETag, page.LastModified); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// 更新文档的刷新周期、抓取选项和网页的缓存标识，并计算下次刷新的时间
+func (uc *KnowledgeDocumentUsecase) setRefresh(ctx context.Context, docID int64, schedule string, respectRobots bool, etag, lastModified string) error {
+	obj := &entity.KnowledgeDocument{ID: docID, RefreshSchedule: schedule, RespectRobots: respectRobots, ETag: etag, LastModified: lastModified}
+	if schedule != "" {
+		next, err := nextRefreshAt(schedule, time.Now())
+		if err != nil {
+			return pb.ErrorIngestUrlInvalid("刷新周期不合法: %s", err.Error())
+		}
+		obj.NextRefreshAt = &next
+	}
+	q := query.KnowledgeDocument
+	if _, err := uc.repo.Update(ctx, obj, q.RefreshSchedule, q.RespectRobots, q.ETag, q.LastModified, q.NextRefreshAt); err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.setRefresh err: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// 校验字段映射，转换为JSON保存在文档中，没有指定映射时返回空字符串
+func fieldMapping(m *pb.FieldMapping) (string, error) {
+	if m == nil || (m.ContentTemplate == "" && len(m.MetadataFields) == 0 && m.IdField == "") {
+		return "", nil
+	}
+	fields := append([]string{m.IdField}, m.MetadataFields...)
+	for _, f := range fields {
+		if strings.ContainsAny(f, "{}") {
+			return "", pb.ErrorFieldMappingInvalid("字段名不合法: %q", f)
+		}
+	}
+	b, err := json.Marshal(&docparser.JSONMapping{
+		ContentTemplate: m.ContentTemplate,
+		MetadataFields:  m.MetadataFields,
+		IDField:         m.IdField,
+	})
+	if err != nil {
+		return "", gerror.Wrap(err, "")
+	}
+	return string(b), nil
+}
+
+// 计算文件内容的sha256，支持blob存储中的文件和本地文件
+func (uc *KnowledgeDocumentUsecase) fileHash(ctx context.Context, uri string) (string, error) {
+	key, ok := blob.KeyFromURI(uri)
+	if !ok {
+		return utils.FileSHA256(uri)
+	}
+	rc, err := uc.blobStore.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	return utils.ReaderSHA256(rc)
+}
+
+// 获取同一目录下同名文件的下一个版本号
+func (uc *KnowledgeDocumentUsecase) nextVersion(ctx context.Context, knowledgeName, path, fileName string) (int32, error) {
+	q := query.KnowledgeDocument
+	list, err := uc.repo.ListWithoutCount(ctx, &entity.PageAndOrder{
+		PageData: entity.PageData{Page: 1, PageSize: 1},
+		Order:    q.Version.Desc(),
+	}, q.KnowledgeBaseName.Eq(knowledgeName), q.Path.Eq(path), q.FileName.Eq(fileName))
+	if err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.nextVersion err: %+v", err)
+		return 0, err
+	}
+	if len(list) == 0 {
+		return 1, nil
+	}
+	return list[0].Version + 1, nil
+}
+
+// 将同一目录下同名文件的旧版本及其文档块标记为已替代，并从索引中删除不再被引用的文档块
+func (uc *KnowledgeDocumentUsecase) supersede(ctx context.Context, obj *entity.KnowledgeDocument) error {
+	q, qc := query.KnowledgeDocument, query.KnowledgeChunk
+	prevs, err := uc.repo.ListAll(ctx, q.KnowledgeBaseName.Eq(obj.KnowledgeBaseName), q.Path.Eq(obj.Path), q.FileName.Eq(obj.FileName),
+		q.Status.Eq(consts.StatusActive), q.ID.Neq(obj.ID))
+	if err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.supersede ListAll err: %+v", err)
+		return err
+	}
+	if len(prevs) == 0 {
+		return nil
+	}
+	prevIDs := make([]int64, 0, len(prevs))
+	for _, p := range prevs {
+		prevIDs = append(prevIDs, p.ID)
+	}
+	prevChunks, err := uc.chunkRepo.ListAll(ctx, qc.KnowledgeDocID.In(prevIDs...))
+	if err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.supersede ListAll chunk err: %+v", err)
+		return err
+	}
+	err = uc.repo.Query().Transaction(func(tx *query.Query) error {
+		if _, err := tx.KnowledgeDocument.WithContext(ctx).Where(q.ID.In(prevIDs...)).Update(q.Status, consts.StatusSuperseded); err != nil {
+			return gerror.Wrap(err, "")
+		}
+		if _, err := tx.KnowledgeChunk.WithContext(ctx).Where(qc.KnowledgeDocID.In(prevIDs...)).Update(qc.Status, consts.StatusSuperseded); err != nil {
+			return gerror.Wrap(err, "")
+		}
+		return nil
+	})
+	if err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.supersede Transaction err: %+v", err)
+		return err
+	}
+
+	return uc.removeUnused(ctx, prevChunks)
+}
+
+// 用JSON文件中的记录替换知识库中其他文档里唯一标识相同的记录，记录全部被替换的文档标记为已替代
+func (uc *KnowledgeDocumentUsecase) upsertRecords(ctx context.Context, obj *entity.KnowledgeDocument) error {
+	if obj.FieldMapping == "" {
+		return nil
+	}
+	q, qc := query.KnowledgeDocument, query.KnowledgeChunk
+	chunks, err := uc.chunkRepo.ListAll(ctx, qc.KnowledgeDocID.Eq(obj.ID), qc.RecordID.Neq(""))
+	if err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.upsertRecords ListAll err: %+v", err)
+		return err
+	}
+	recordIDs := make([]string, 0, len(chunks))
+	for _, c := range chunks {
+		recordIDs = append(recordIDs, c.RecordID)
+	}
+	if len(recordIDs) == 0 {
+		return nil
+	}
+	prevChunks, err := uc.chunkRepo.ListAll(ctx, qc.KnowledgeBaseName.Eq(obj.KnowledgeBaseName), qc.RecordID.In(recordIDs...),
+		qc.Status.Eq(consts.StatusActive), qc.KnowledgeDocID.Neq(obj.ID))
+	if err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.upsertRecords ListAll prev err: %+v", err)
+		return err
+	}
+	if len(prevChunks) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(prevChunks))
+	docIDs := make([]int64, 0, len(prevChunks))
+	seen := make(map[int64]bool)
+	for _, c := range prevChunks {
+		ids = append(ids, c.ID)
+		if !seen[c.KnowledgeDocID] {
+			seen[c.KnowledgeDocID] = true
+			docIDs = append(docIDs, c.KnowledgeDocID)
+		}
+	}
+	err = uc.repo.Query().Transaction(func(tx *query.Query) error {
+		if _, err := tx.KnowledgeChunk.WithContext(ctx).Where(qc.ID.In(ids...)).Update(qc.Status, consts.StatusSuperseded); err != nil {
+			return gerror.Wrap(err, "")
+		}
+		// 还有生效记录的文档保持不变
+		remain, err := tx.KnowledgeChunk.WithContext(ctx).Where(qc.KnowledgeDocID.In(docIDs...), qc.Status.Eq(consts.StatusActive)).Find()
+		if err != nil {
+			return gerror.Wrap(err, "")
+		}
+		active := make(map[int64]bool, len(remain))
+		for _, c := range remain {
+			active[c.KnowledgeDocID] = true
+		}
+		emptyDocIDs := make([]int64, 0, len(docIDs))
+		for _, id := range docIDs {
+			if !active[id] {
+				emptyDocIDs = append(emptyDocIDs, id)
+			}
+		}
+		if len(emptyDocIDs) == 0 {
+			return nil
+		}
+		if _, err := tx.KnowledgeDocument.WithContext(ctx).Where(q.ID.In(emptyDocIDs...)).Update(q.Status, consts.StatusSuperseded); err != nil {
+			return gerror.Wrap(err, "")
+		}
+		return nil
+	})
+	if err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.upsertRecords Transaction err: %+v", err)
+		return err
+	}
+	return uc.removeUnused(ctx, prevChunks)
+}
+
+// 被替代的文档块如果没有被其他生效的文档引用，从索引中删除
+func (uc *KnowledgeDocumentUsecase) removeUnused(ctx context.Context, prevChunks []*entity.KnowledgeChunk) error {
+	qc := query.KnowledgeChunk
+	chunkIDs := make([]string, 0, len(prevChunks))
+	for _, c := range prevChunks {
+		chunkIDs = append(chunkIDs, c.ChunkID)
+	}
+	if len(chunkIDs) == 0 {
+		return nil
+	}
+	used, err := uc.chunkRepo.ListAll(ctx, qc.ChunkID.In(chunkIDs...), qc.Status.Eq(consts.StatusActive))
+	if err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.removeUnused ListAll err: %+v", err)
+		return err
+	}
+	usedIDs := make(map[string]bool, len(used))
+	for _, c := range used {
+		usedIDs[c.ChunkID] = true
+	}
+	removed := make([]string, 0, len(chunkIDs))
+	for _, id := range chunkIDs {
+		if !usedIDs[id] {
+			usedIDs[id] = true
+			removed = append(removed, id)
+		}
+	}
+	if err = uc.aiClient.DeleteDocuments(ctx, removed); err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.removeUnused DeleteDocuments err: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// 加载、分割文档，并将文档块写入索引，返回文档块id
+// 启用父子块检索时，文档块作为父块只保存在数据库，分割出的子块写入索引
+func (uc *KnowledgeDocumentUsecase) index(ctx context.Context, obj *entity.KnowledgeDocument, uri string) ([]string, error) {
+	// 先调用加载器，加载文件内容，网页的正文选择器、JSON文件的字段映射传给解析器
+	var parserOpts []parser.Option
+	if obj.Selector != "" {
+		parserOpts = append(parserOpts, docparser.WithSelector(obj.Selector))
+	}
+	if obj.FieldMapping != "" {
+		var m docparser.JSONMapping
+		if err := json.Unmarshal([]byte(obj.FieldMapping), &m); err != nil {
+			return nil, gerror.Wrap(err, "")
+		}
+		parserOpts = append(parserOpts, docparser.WithJSONMapping(&m))
+	}
+	var opts []document.LoaderOption
+	if len(parserOpts) > 0 {
+		opts = append(opts, document.WithParserOptions(parserOpts...))
+	}
+	docs, err := uc.aiClient.Loader.Load(ctx, document.Source{URI: uri}, opts...)
+	if err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.index Load err: %+v", gerror.Wrap(err, ""))
+		return nil, err
+	}
+	// 保存的文件名与原始文件名不同，使用原始文件名，并记录文件在压缩包内的目录、网页地址，分割后的文档块会继承元数据
+	for _, doc := range docs {
+		if doc.MetaData == nil {
+			doc.MetaData = make(map[string]any)
+		}
+		doc.MetaData[ai.MetaFileName] = obj.FileName
+		if obj.Path != "" {
+			doc.MetaData[ai.MetaPath] = obj.Path
+		}
+		if obj.SourceURL != "" {
+			doc.MetaData[ai.MetaURL] = obj.SourceURL
+		}
+	}
+	// 分割前先脱敏，文档块和索引中都不会出现敏感信息
+	if docs, err = uc.redact(ctx, obj, docs); err != nil {
+		return nil, err
+	}
+	// 调用转换器，对文档进行分隔、过滤、合并，文档指定了分块策略时使用指定的策略
+	docs, err = uc.aiClient.Transformer.Transform(ctx, docs, ai.WithChunkStrategy(obj.ChunkStrategy))
+	if err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.index Transform err: %+v", gerror.Wrap(err, ""))
+		return nil, err
+	}
+	// 合并文档
+	docs, err = uc.aiClient.DocAddIDAndMerge(ctx, docs)
+	if err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.index DocAddIDAndMerge err: %+v", gerror.Wrap(err, ""))
+		return nil, err
+	}
+	if !uc.aiClient.ParentChild() {
+		hashes := make([]string, len(docs))
+		for i, doc := range docs {
+			hashes[i] = ai.ContentHash(doc.Content)
+		}
+		return uc.storeChunks(ctx, obj, docs, hashes)
+	}
+
+	// 父子块检索：文档块作为父块保存在数据库，分割出的子块写入索引
+	parentIDs, err := uc.saveParents(ctx, obj, docs)
+	if err != nil {
+		return nil, err
+	}
+	children, err := uc.aiClient.SplitChildren(ctx, docs)
+	if err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.index SplitChildren err: %+v", err)
+		return nil, err
+	}
+	// 子块的哈希包含父块id，只有父块也相同时才复用已有的子块，保证子块总能找到自己的父块
+	hashes := make([]string, len(children))
+	for i, doc := range children {
+		hashes[i] = ai.ContentHash(doc.MetaData[ai.MetaParentID].(string) + " " + doc.Content)
+	}
+	ids, err := uc.storeChunks(ctx, obj, children, hashes)
+	if err != nil {
+		return nil, err
+	}
+	return append(parentIDs, ids...), nil
+}
+
+// 保存父块，内容相同的父块复用已有的id，返回父块id
+// 父块只保存在数据库，不写入索引，docs的id会被替换为实际使用的父块id
+func (uc *KnowledgeDocumentUsecase) saveParents(ctx context.Context, obj *entity.KnowledgeDocument, docs []*schema.Document) ([]string, error) {
+	hashes := make([]string, len(docs))
+	for i, doc := range docs {
+		hashes[i] = ai.ContentHash(doc.Content)
+	}
+	qc := query.KnowledgeChunk
+	existChunks, err := uc.chunkRepo.ListAll(ctx, qc.KnowledgeBaseName.Eq(obj.KnowledgeBaseName), qc.ContentHash.In(hashes...),
+		qc.IsParent.Is(true), qc.Status.Eq(consts.StatusActive))
+	if err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.saveParents ListAll err: %+v", err)
+		return nil, err
+	}
+	existed := make(map[string]string, len(existChunks)+len(docs))
+	for _, c := range existChunks {
+		if _, ok := existed[c.ContentHash]; !ok {
+			existed[c.ContentHash] = c.ChunkID
+		}
+	}
+
+	chunks := make([]*entity.KnowledgeChunk, 0, len(docs))
+	ids := make([]string, 0, len(docs))
+	added := make(map[string]bool, len(docs))
+	for i, doc := range docs {
+		if id, ok := existed[hashes[i]]; ok {
+			doc.ID = id
+		} else {
+			existed[hashes[i]] = doc.ID
+		}
+		if added[hashes[i]] {
+			continue
+		}
+		added[hashes[i]] = true
+		ext, err := ai.ExtJSON(doc)
+		if err != nil {
+			return nil, err
+		}
+		chunk := &entity.KnowledgeChunk{
+			KnowledgeDocID:    obj.ID,
+			KnowledgeBaseName: obj.KnowledgeBaseName,
+			ChunkID:           doc.ID,
+			Content:           doc.Content,
+			Ext:               ext,
+			ContentHash:       hashes[i],
+			Status:            consts.StatusActive,
+			IsParent:          true,
+		}
+		chunk.RecordID, _ = doc.MetaData[docparser.MetaRecordID].(string)
+		chunks = append(chunks, chunk)
+		ids = append(ids, chunk.ChunkID)
+	}
+	if len(chunks) > 0 {
+		if _, err = uc.chunkRepo.BatchCreate(ctx, chunks); err != nil {
+			uc.log.Errorf("KnowledgeDocumentUsecase.saveParents BatchCreate err: %+v", err)
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+// 将文档块写入索引并记录文档与文档块的关系，返回文档块id，hashes是每个文档块用于去重的哈希
+// 同一个知识库内哈希相同的文档块只写入一次索引，多个文档通过knowledge_chunk引用同一个索引文档
+func (uc *KnowledgeDocumentUsecase) storeChunks(ctx context.Context, obj *entity.KnowledgeDocument, docs []*schema.Document, hashes []string) ([]string, error) {
+	// 查询知识库中已经存在的文档块
+	qc := query.KnowledgeChunk
+	// 旧版本的文档块可能已经从索引中删除，只复用生效中的文档块，父块不在索引中
+	existChunks, err := uc.chunkRepo.ListAll(ctx, qc.KnowledgeBaseName.Eq(obj.KnowledgeBaseName), qc.ContentHash.In(hashes...),
+		qc.IsParent.Is(false), qc.Status.Eq(consts.StatusActive))
+	if err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.storeChunks ListAll err: %+v", err)
+		return nil, err
+	}
+	existed := make(map[string]*entity.KnowledgeChunk, len(existChunks))
+	for _, c := range existChunks {
+		if _, ok := existed[c.ContentHash]; !ok {
+			existed[c.ContentHash] = c
+		}
+	}
+
+	// 只有新的文档块才需要写入索引，同一批次内重复的文档块也只写入一次
+	newDocs := make([]*schema.Document, 0, len(docs))
+	seen := make(map[string]bool, len(docs))
+	for i, doc := range docs {
+		if existed[hashes[i]] != nil || seen[hashes[i]] {
+			continue
+		}
+		seen[hashes[i]] = true
+		newDocs = append(newDocs, doc)
+	}
+	// 设置知识库的名称
+	ctx = context.WithValue(ctx, ai.KnowledgeName, obj.KnowledgeBaseName)
+	if len(newDocs) > 0 {
+		if err = uc.enrich(ctx, obj, newDocs, docs); err != nil {
+			return nil, err
+		}
+		// 生成问题失败不影响写入索引，只是这些文档块没有问题向量
+		if err = uc.aiClient.GenerateQuestions(ctx, newDocs); err != nil {
+			uc.log.Errorf("KnowledgeDocumentUsecase.storeChunks GenerateQuestions err: %+v", err)
+		}
+	}
+
+	// 记录文档与文档块的关系，pgvector把向量保存在文档块表上，需要先保存文档块再写入索引
+	chunks := make([]*entity.KnowledgeChunk, 0, len(docs))
+	ids := make([]string, 0, len(docs))
+	var reused []string
+	added := make(map[string]bool, len(docs))
+	for i, doc := range docs {
+		if added[hashes[i]] {
+			continue
+		}
+		added[hashes[i]] = true
+		chunk := &entity.KnowledgeChunk{
+			KnowledgeDocID:    obj.ID,
+			KnowledgeBaseName: obj.KnowledgeBaseName,
+			ContentHash:       hashes[i],
+			Status:            consts.StatusActive,
+		}
+		if e, ok := existed[hashes[i]]; ok {
+			chunk.ChunkID, chunk.Content, chunk.Ext = e.ChunkID, e.Content, e.Ext
+			reused = append(reused, e.ChunkID)
+		} else {
+			ext, err := ai.ExtJSON(doc)
+			if err != nil {
+				return nil, err
+			}
+			chunk.ChunkID, chunk.Content, chunk.Ext = doc.ID, doc.Content, ext
+		}
+		chunk.RecordID, _ = doc.MetaData[docparser.MetaRecordID].(string)
+		chunk.ParentID, _ = doc.MetaData[ai.MetaParentID].(string)
+		chunks = append(chunks, chunk)
+		ids = append(ids, chunk.ChunkID)
+	}
+	if len(chunks) == 0 {
+		return ids, nil
+	}
+	if _, err = uc.chunkRepo.BatchCreate(ctx, chunks); err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.storeChunks BatchCreate err: %+v", err)
+		return nil, err
+	}
+	// 没有写入索引的文档块不能被其它文档复用，失败时删除刚保存的文档块
+	rollback := func() {
+		rowIDs := make([]int64, 0, len(chunks))
+		for _, c := range chunks {
+			rowIDs = append(rowIDs, c.ID)
+		}
+		if _, err := uc.chunkRepo.DeleteByConditions(ctx, qc.ID.In(rowIDs...)); err != nil {
+			uc.log.Errorf("KnowledgeDocumentUsecase.storeChunks DeleteByConditions err: %+v", err)
+		}
+	}
+	// pgvector的向量保存在行上，复用的文档块需要把向量复制到新保存的行
+	if err = uc.aiClient.VectorStore.Reuse(ctx, reused); err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.storeChunks Reuse err: %+v", err)
+		rollback()
+		return nil, err
+	}
+	if len(newDocs) > 0 {
+		// 调用索引器，将文档索引到向量数据库
+		stats := &embedder.Stats{}
+		if _, err = uc.aiClient.Indexer.Store(embedder.WithStats(ctx, stats), newDocs); err != nil {
+			uc.log.Errorf("KnowledgeDocumentUsecase.storeChunks Store err: %+v", gerror.Wrap(err, ""))
+			rollback()
+			return nil, err
+		}
+		// 本次写入的嵌入统计，包括缓存命中率和实际向量化的token数，累计值通过/debug/vars查看
+		uc.log.Infof("KnowledgeDocumentUsecase.storeChunks embedding stats: %s", stats)
+	}
+	return ids, nil
+}
+
+// 处理文档中的敏感信息，并把检测报告保存到文档上，脱敏配置为拒绝时文档包含敏感信息返回错误
+func (uc *KnowledgeDocumentUsecase) redact(ctx context.Context, obj *entity.KnowledgeDocument, docs []*schema.Document) ([]*schema.Document, error) {
+	if uc.aiClient.Redactor == nil {
+		return docs, nil
+	}
+	report := make(redact.Report)
+	// 写入扩展数据的元数据都会保存到索引和数据库，包括JSON记录映射出的字段，全部需要处理
+	res, rerr := uc.aiClient.Redactor.Transform(ctx, docs, redact.WithReport(report), redact.WithMetaKeys(ai.ExtKeys...))
+	obj.PiiReport = ""
+	if report.Total() > 0 {
+		b, err := json.Marshal(report)
+		if err != nil {
+			return nil, gerror.Wrap(err, "")
+		}
+		obj.PiiReport = string(b)
+	}
+	if _, err := uc.repo.Update(ctx, obj, query.KnowledgeDocument.PiiReport); err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.redact Update err: %+v", err)
+		return nil, err
+	}
+	if rerr != nil {
+		if errors.Is(rerr, redact.ErrRejected) {
+			return nil, pb.ErrorDocumentPiiRejected("文档包含敏感信息: %s", obj.PiiReport)
+		}
+		uc.log.Errorf("KnowledgeDocumentUsecase.redact Transform err: %+v", rerr)
+		return nil, rerr
+	}
+	return res, nil
+}
+
+// 知识库开启了增强时，使用对话模型为新的文档块生成摘要、关键词和上下文标题，并记录消耗的token数
+// 摘要使用文档的全部文档块生成，单个文档块增强失败不影响写入索引
+func (uc *KnowledgeDocumentUsecase) enrich(ctx context.Context, obj *entity.KnowledgeDocument, newDocs, docs []*schema.Document) error {
+	kb, err := uc.kbRepo.GetByConditions(ctx, query.KnowledgeBase.Name.Eq(obj.KnowledgeBaseName))
+	if err != nil {
+		if entity.IsNotFound(err) {
+			return nil
+		}
+		uc.log.Errorf("KnowledgeDocumentUsecase.enrich GetByConditions err: %+v", err)
+		return err
+	}
+	if !kb.Enrich {
+		return nil
+	}
+	usage := &ai.EnrichUsage{}
+	_, err = uc.aiClient.Enricher.Transform(ctx, newDocs, ai.WithEnrichSource(docs), ai.WithEnrichEmbed(kb.EnrichEmbed), ai.WithEnrichUsage(usage))
+	if err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.enrich Transform err: %+v", err)
+	}
+	uc.log.Infof("KnowledgeDocumentUsecase.enrich document %d: %d chunks, %d calls, %d prompt tokens, %d completion tokens",
+		obj.ID, len(newDocs), usage.Calls, usage.PromptTokens, usage.CompletionTokens)
+	obj.EnrichTokens = int64(usage.TotalTokens())
+	if _, err = uc.repo.Update(ctx, obj, query.KnowledgeDocument.EnrichTokens); err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.enrich Update err: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// 获取文档下所有文档块的id
+func (uc *KnowledgeDocumentUsecase) chunkIDs(ctx context.Context, docID int64) ([]string, error) {
+	chunks, err := uc.chunkRepo.ListAll(ctx, query.KnowledgeChunk.KnowledgeDocID.Eq(docID))
+	if err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.chunkIDs err: %+v", err)
+		return nil, err
+	}
+	ids := make([]string, 0, len(chunks))
+	for _, c := range chunks {
+		ids = append(ids, c.ChunkID)
+	}
+	return ids, nil
+}
+
+// 更新文档状态
+func (uc *KnowledgeDocumentUsecase) updateStatus(ctx context.Context, obj *entity.KnowledgeDocument, status int32) error {
+	obj.Status = status
+	if _, err := uc.repo.Update(ctx, obj, query.KnowledgeDocument.Status); err != nil {
+		uc.log.Errorf("KnowledgeDocumentUsecase.updateStatus err: %+v", err)
+		return err
+	}
+	return nil
+}
+
 func (uc *KnowledgeDocumentUsecase) Update(ctx context.Context, obj *entity.KnowledgeDocument) (*entity.KnowledgeDocument, error) {
 	_, err := uc.repo.Save(ctx, obj)
 	if err != nil {
@@ -112,6 +824,31 @@ func (uc *KnowledgeDocumentUsecase) Delete(ctx context.Context, id int64) error
 	return nil
 }
 
+// 下载文档的源文件，调用方负责关闭
+func (uc *KnowledgeDocumentUsecase) Download(ctx context.Context, id int64) (*entity.KnowledgeDocument, io.ReadCloser, error) {
+	obj, err := uc.repo.Get(ctx, id)
+	if err != nil {
+		if entity.IsNotFound(err) {
+			return nil, nil, pb.ErrorDocumentNotFound("文档%d不存在", id)
+		}
+		uc.log.Errorf("KnowledgeDocumentUsecase.Download Get err: %+v", err)
+		return nil, nil, err
+	}
+	key, ok := blob.KeyFromURI(obj.URI)
+	if !ok {
+		return nil, nil, pb.ErrorDocumentNotFound("文档%d的源文件不存在", id)
+	}
+	rc, err := uc.blobStore.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return nil, nil, pb.ErrorDocumentNotFound("文档%d的源文件不存在", id)
+		}
+		uc.log.Errorf("KnowledgeDocumentUsecase.Download blob Get err: %+v", err)
+		return nil, nil, err
+	}
+	return obj, rc, nil
+}
+
 func (uc *KnowledgeDocumentUsecase) Get(ctx context.Context, id int64) (*entity.KnowledgeDocument, error) {
 	e, err := uc.repo.Get(ctx, id)
 	if err != nil {
@@ -121,13 +858,70 @@ func (uc *KnowledgeDocumentUsecase) Get(ctx context.Context, id int64) (*entity.
 	return e, nil
 }
 
-func (uc *KnowledgeDocumentUsecase) List(ctx context.Context, page *entity.PageAndOrder) ([]*entity.KnowledgeDocument, int64, error) {
-	arr, count, err := uc.repo.List(ctx, page)
+// 文档列表，latest为true时每个文件只返回一个版本：有生效的版本时返回生效的版本，否则返回版本号最大的版本
+// 新版本正在索引或索引失败时，仍然返回正在使用的旧版本
+func (uc *KnowledgeDocumentUsecase) List(ctx context.Context, req *pb.ListKnowledgeDocumentRequest) (*pb.ListKnowledgeDocumentReply, error) {
+	q := query.KnowledgeDocument
+	cond := make([]gen.Condition, 0)
+	if req.KnowledgeName != "" {
+		cond = append(cond, q.KnowledgeBaseName.Eq(req.KnowledgeName))
+	}
+	if req.FileName != "" {
+		cond = append(cond, q.FileName.Like("%"+req.FileName+"%"))
+	}
+	if req.Latest {
+		cond = append(cond, q.Status.Neq(consts.StatusSuperseded))
+	}
+	arr, err := uc.repo.ListAll(ctx, cond...)
 	if err != nil {
 		uc.log.Errorf("%+v", err)
-		return nil, 0, err
+		return nil, err
+	}
+	if req.Latest {
+		arr = latestVersions(arr)
+	}
+	var res pb.ListKnowledgeDocumentReply
+	utils.Copy(&res.List, arr)
+	return &res, nil
+}
+
+// 按知识库、目录和文件名分组，每个文件保留生效的版本，没有生效的版本时保留版本号最大的版本，结果保持原来的顺序
+func latestVersions(arr []*entity.KnowledgeDocument) []*entity.KnowledgeDocument {
+	type fileKey struct{ knowledgeName, path, fileName string }
+	picked := make(map[fileKey]*entity.KnowledgeDocument, len(arr))
+	for _, e := range arr {
+		k := fileKey{e.KnowledgeBaseName, e.Path, e.FileName}
+		if p, ok := picked[k]; !ok || preferVersion(e, p) {
+			picked[k] = e
+		}
+	}
+	res := make([]*entity.KnowledgeDocument, 0, len(picked))
+	for _, e := range arr {
+		if picked[fileKey{e.KnowledgeBaseName, e.Path, e.FileName}] == e {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// 同一个文件的两个版本中是否优先使用a，生效的版本优先，其次是版本号大的
+func preferVersion(a, b *entity.KnowledgeDocument) bool {
+	if aActive, bActive := a.Status == consts.StatusActive, b.Status == consts.StatusActive; aActive != bActive {
+		return aActive
+	}
+	return a.Version > b.Version
+}
+
+// 文档某个版本的文档块列表
+func (uc *KnowledgeDocumentUsecase) ListChunk(ctx context.Context, req *pb.ListKnowledgeChunkRequest) (*pb.ListKnowledgeChunkReply, error) {
+	arr, err := uc.chunkRepo.ListAll(ctx, query.KnowledgeChunk.KnowledgeDocID.Eq(req.DocumentId))
+	if err != nil {
+		uc.log.Errorf("%+v", err)
+		return nil, err
 	}
-	return arr, count, nil
+	var res pb.ListKnowledgeChunkReply
+	utils.Copy(&res.List, arr)
+	return &res, nil
 }
 
 func (uc *KnowledgeDocumentUsecase) ListAll(ctx context.Context) ([]*entity.KnowledgeDocument, error) {
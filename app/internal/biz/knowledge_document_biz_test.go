@@ -0,0 +1,523 @@
+package biz_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	pb "ragx/api/gen"
+	"ragx/app/internal/biz"
+	"ragx/app/internal/biz/entity"
+	"ragx/app/internal/biz/query"
+	"ragx/app/internal/consts"
+	"ragx/app/pkg/ai"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+func TestCreateDuplicateFile(t *testing.T) {
+	r := newTestRepos(t)
+	store := &fakeStore{}
+	uc := newTestDocumentUsecase(r, store)
+	ctx := context.Background()
+
+	uri := writeFile(t, "handbook.txt", "第一章\n\n第二章")
+	first, err := uc.Create(ctx, &pb.UploadIndexerRequest{KnowledgeName: "kb", Uri: uri})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Existed || len(first.DocIds) != 2 || len(store.stored) != 2 {
+		t.Fatalf("first = %+v, stored = %v", first, store.stored)
+	}
+	doc, err := r.doc.Get(ctx, first.DocumentId)
+	if err != nil || doc.Status != consts.StatusActive || doc.FileHash == "" || doc.FileName != "handbook.txt" {
+		t.Fatalf("doc = %+v, err = %v", doc, err)
+	}
+
+	// 相同的文件换个名字再上传，返回已有的文档，不再写入索引
+	store.stored = nil
+	again, err := uc.Create(ctx, &pb.UploadIndexerRequest{KnowledgeName: "kb", Uri: writeFile(t, "copy.txt", "第一章\n\n第二章")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again.Existed || again.DocumentId != first.DocumentId || fmt.Sprint(again.DocIds) != fmt.Sprint(first.DocIds) || len(store.stored) != 0 {
+		t.Errorf("again = %+v, stored = %v", again, store.stored)
+	}
+	if n, err := r.doc.Count(ctx); err != nil || n != 1 {
+		t.Errorf("%d documents, err = %v", n, err)
+	}
+
+	// 其它知识库中是新的文档
+	other, err := uc.Create(ctx, &pb.UploadIndexerRequest{KnowledgeName: "kb2", Uri: uri})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.Existed || other.DocumentId == first.DocumentId || len(store.stored) != 2 {
+		t.Errorf("other = %+v, stored = %v", other, store.stored)
+	}
+}
+
+func TestCreateRetryFailed(t *testing.T) {
+	r := newTestRepos(t)
+	store := &fakeStore{storeErr: errors.New("es unavailable")}
+	uc := newTestDocumentUsecase(r, store)
+	ctx := context.Background()
+
+	req := &pb.UploadIndexerRequest{KnowledgeName: "kb", Uri: writeFile(t, "a.txt", "内容")}
+	if _, err := uc.Create(ctx, req); err == nil {
+		t.Fatal("expected error")
+	}
+	// 处理失败的文档重新上传时重新处理，不会当作已经上传过
+	store.storeErr = nil
+	reply, err := uc.Create(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Existed || len(reply.DocIds) != 1 || len(store.stored) != 1 {
+		t.Fatalf("reply = %+v, stored = %v", reply, store.stored)
+	}
+	if n, err := r.doc.Count(ctx); err != nil || n != 1 {
+		t.Errorf("%d documents, err = %v", n, err)
+	}
+	doc, err := r.doc.Get(ctx, reply.DocumentId)
+	if err != nil || doc.Status != consts.StatusActive {
+		t.Errorf("doc = %+v, err = %v", doc, err)
+	}
+}
+
+func TestCreateSameContentURLs(t *testing.T) {
+	r := newTestRepos(t)
+	store := &fakeStore{}
+	uc := newTestDocumentUsecase(r, store)
+	ctx := context.Background()
+
+	uri := writeFile(t, "page.html", "页面内容")
+	page := func(u string) *pb.UploadIndexerRequest {
+		return &pb.UploadIndexerRequest{KnowledgeName: "kb", Uri: uri, FileName: u, SourceUrl: u}
+	}
+	a, err := uc.Create(ctx, page("https://example.com/a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 内容相同的另一个网页是新的文档，文档块引用已有的索引文档
+	b, err := uc.Create(ctx, page("https://example.com/b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Existed || b.DocumentId == a.DocumentId || fmt.Sprint(b.DocIds) != fmt.Sprint(a.DocIds) || len(store.stored) != 1 {
+		t.Fatalf("a = %+v, b = %+v, stored = %v", a, b, store.stored)
+	}
+	// 同一个网页再次抓取到相同的内容时返回已有的文档
+	again, err := uc.Create(ctx, page("https://example.com/b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again.Existed || again.DocumentId != b.DocumentId {
+		t.Errorf("again = %+v", again)
+	}
+	// 上传的文件与网页内容相同时也是新的文档
+	file, err := uc.Create(ctx, &pb.UploadIndexerRequest{KnowledgeName: "kb", Uri: uri})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.Existed || file.DocumentId == a.DocumentId || file.DocumentId == b.DocumentId {
+		t.Errorf("file = %+v", file)
+	}
+	docs, err := r.doc.ListAll(ctx)
+	if err != nil || len(docs) != 3 {
+		t.Fatalf("%d documents, err = %v", len(docs), err)
+	}
+	for _, d := range docs {
+		if d.Status != consts.StatusActive {
+			t.Errorf("doc %s status = %d", d.FileName, d.Status)
+		}
+	}
+}
+
+func TestCreateSelectorChanged(t *testing.T) {
+	r := newTestRepos(t)
+	store := &fakeStore{}
+	uc := newTestDocumentUsecase(r, store)
+	ctx := context.Background()
+
+	uri := writeFile(t, "page.html", "<article>正文</article>")
+	first, err := uc.Create(ctx, &pb.UploadIndexerRequest{KnowledgeName: "kb", Uri: uri})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 相同的文件换一个正文选择器上传，生成新的版本并替换掉旧版本
+	second, err := uc.Create(ctx, &pb.UploadIndexerRequest{KnowledgeName: "kb", Uri: uri, Selector: "article"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Existed || second.DocumentId == first.DocumentId || second.Version != 2 {
+		t.Fatalf("second = %+v", second)
+	}
+	old, err := r.doc.Get(ctx, first.DocumentId)
+	if err != nil || old.Status != consts.StatusSuperseded {
+		t.Errorf("old = %+v, err = %v", old, err)
+	}
+	doc, err := r.doc.Get(ctx, second.DocumentId)
+	if err != nil || doc.Selector != "article" || doc.Status != consts.StatusActive {
+		t.Errorf("doc = %+v, err = %v", doc, err)
+	}
+	// 选择器相同时返回已有的文档
+	again, err := uc.Create(ctx, &pb.UploadIndexerRequest{KnowledgeName: "kb", Uri: uri, Selector: "article"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again.Existed || again.DocumentId != second.DocumentId {
+		t.Errorf("again = %+v", again)
+	}
+}
+
+func TestCreateFieldMappingChanged(t *testing.T) {
+	r := newTestRepos(t)
+	store := &fakeStore{}
+	uc := newTestDocumentUsecase(r, store)
+	ctx := context.Background()
+
+	uri := writeFile(t, "faq.jsonl", `{"id": 1, "question": "如何退款", "answer": "联系客服"}`)
+	req := func(tmpl string) *pb.UploadIndexerRequest {
+		return &pb.UploadIndexerRequest{KnowledgeName: "kb", Uri: uri, FieldMapping: &pb.FieldMapping{ContentTemplate: tmpl, IdField: "id"}}
+	}
+	first, err := uc.Create(ctx, req("{question}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 相同的文件使用新的字段映射上传，生成新的版本并替换掉旧版本
+	second, err := uc.Create(ctx, req("{question}\n{answer}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Existed || second.DocumentId == first.DocumentId || second.Version != 2 {
+		t.Fatalf("second = %+v", second)
+	}
+	old, err := r.doc.Get(ctx, first.DocumentId)
+	if err != nil || old.Status != consts.StatusSuperseded {
+		t.Errorf("old = %+v, err = %v", old, err)
+	}
+	// 字段映射相同时返回已有的文档
+	again, err := uc.Create(ctx, req("{question}\n{answer}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again.Existed || again.DocumentId != second.DocumentId {
+		t.Errorf("again = %+v", again)
+	}
+}
+
+func TestCreateChunkStrategyChanged(t *testing.T) {
+	r := newTestRepos(t)
+	store := &fakeStore{}
+	uc := newTestDocumentUsecase(r, store)
+	ctx := context.Background()
+
+	uri := writeFile(t, "a.txt", "第一段\n\n第二段")
+	first, err := uc.Create(ctx, &pb.UploadIndexerRequest{KnowledgeName: "kb", Uri: uri})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 相同的文件换一个分块策略上传，生成新的版本并替换掉旧版本
+	second, err := uc.Create(ctx, &pb.UploadIndexerRequest{KnowledgeName: "kb", Uri: uri, ChunkStrategy: "semantic"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Existed || second.DocumentId == first.DocumentId || second.Version != 2 {
+		t.Fatalf("second = %+v", second)
+	}
+	old, err := r.doc.Get(ctx, first.DocumentId)
+	if err != nil || old.Status != consts.StatusSuperseded {
+		t.Errorf("old = %+v, err = %v", old, err)
+	}
+	doc, err := r.doc.Get(ctx, second.DocumentId)
+	if err != nil || doc.ChunkStrategy != "semantic" || doc.Status != consts.StatusActive {
+		t.Errorf("doc = %+v, err = %v", doc, err)
+	}
+	// 分块策略相同时返回已有的文档
+	again, err := uc.Create(ctx, &pb.UploadIndexerRequest{KnowledgeName: "kb", Uri: uri, ChunkStrategy: "semantic"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again.Existed || again.DocumentId != second.DocumentId {
+		t.Errorf("again = %+v", again)
+	}
+}
+
+func TestCreateSharedChunks(t *testing.T) {
+	r := newTestRepos(t)
+	store := &fakeStore{}
+	uc := newTestDocumentUsecase(r, store)
+	ctx := context.Background()
+
+	// 文件内重复的段落只写入一次
+	a, err := uc.Create(ctx, &pb.UploadIndexerRequest{KnowledgeName: "kb", Uri: writeFile(t, "a.txt", "公共段落\n\nA的段落\n\n公共段落")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.DocIds) != 2 || len(store.stored) != 2 {
+		t.Fatalf("a = %+v, stored = %v", a, store.stored)
+	}
+	// 另一个文件中只有空白不同的段落引用已有的文档块，只有新的段落写入索引
+	store.stored = nil
+	b, err := uc.Create(ctx, &pb.UploadIndexerRequest{KnowledgeName: "kb", Uri: writeFile(t, "b.txt", "B的段落\n\n\t公共段落 \n")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.DocIds) != 2 || b.DocIds[1] != a.DocIds[0] || fmt.Sprint(store.stored) != fmt.Sprint(b.DocIds[:1]) {
+		t.Fatalf("a = %v, b = %v, stored = %v", a.DocIds, b.DocIds, store.stored)
+	}
+	// 两个文档各有一条引用公共文档块的记录，内容使用已有的文档块
+	qc := query.KnowledgeChunk
+	chunks, err := r.chunk.ListAll(ctx, qc.ChunkID.Eq(a.DocIds[0]))
+	if err != nil || len(chunks) != 2 {
+		t.Fatalf("%d chunk rows, err = %v", len(chunks), err)
+	}
+	if chunks[0].KnowledgeDocID != a.DocumentId || chunks[1].KnowledgeDocID != b.DocumentId ||
+		chunks[1].Content != "公共段落" || chunks[0].ContentHash != chunks[1].ContentHash {
+		t.Errorf("chunks = %+v, %+v", chunks[0], chunks[1])
+	}
+}
+
+// 保存文档的各个版本，返回保存后的文档
+func createDocs(t *testing.T, r *testRepos, docs ...*entity.KnowledgeDocument) []*entity.KnowledgeDocument {
+	t.Helper()
+	res, err := r.doc.BatchCreate(context.Background(), docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestNextVersion(t *testing.T) {
+	r := newTestRepos(t)
+	uc := newTestDocumentUsecase(r, &fakeStore{})
+	ctx := context.Background()
+	v, err := biz.NextVersion(uc, ctx, "kb", "docs", "a.md")
+	if err != nil || v != 1 {
+		t.Fatalf("first version = %d, %v", v, err)
+	}
+	createDocs(t, r,
+		&entity.KnowledgeDocument{KnowledgeBaseName: "kb", Path: "docs", FileName: "a.md", Version: 1, Status: consts.StatusSuperseded},
+		&entity.KnowledgeDocument{KnowledgeBaseName: "kb", Path: "docs", FileName: "a.md", Version: 3, Status: consts.StatusFailed},
+		&entity.KnowledgeDocument{KnowledgeBaseName: "kb", Path: "docs", FileName: "a.md", Version: 2, Status: consts.StatusActive},
+		&entity.KnowledgeDocument{KnowledgeBaseName: "kb", Path: "other", FileName: "a.md", Version: 7, Status: consts.StatusActive},
+		&entity.KnowledgeDocument{KnowledgeBaseName: "kb2", Path: "docs", FileName: "a.md", Version: 9, Status: consts.StatusActive},
+	)
+	// 所有状态的版本都计入，失败的版本号也不会被复用
+	if v, err = biz.NextVersion(uc, ctx, "kb", "docs", "a.md"); err != nil || v != 4 {
+		t.Errorf("next version = %d, %v", v, err)
+	}
+	if v, err = biz.NextVersion(uc, ctx, "kb", "", "a.md"); err != nil || v != 1 {
+		t.Errorf("next version in root = %d, %v", v, err)
+	}
+}
+
+func TestSupersede(t *testing.T) {
+	r := newTestRepos(t)
+	store := &fakeStore{}
+	uc := newTestDocumentUsecase(r, store)
+	ctx := context.Background()
+	docs := createDocs(t, r,
+		&entity.KnowledgeDocument{KnowledgeBaseName: "kb", Path: "docs", FileName: "a.md", Version: 1, Status: consts.StatusActive},
+		&entity.KnowledgeDocument{KnowledgeBaseName: "kb", Path: "docs", FileName: "a.md", Version: 2, Status: consts.StatusFailed},
+		&entity.KnowledgeDocument{KnowledgeBaseName: "kb", Path: "other", FileName: "a.md", Version: 1, Status: consts.StatusActive},
+		&entity.KnowledgeDocument{KnowledgeBaseName: "kb", Path: "docs", FileName: "a.md", Version: 3, Status: consts.StatusActive},
+	)
+	old, failed, other, cur := docs[0], docs[1], docs[2], docs[3]
+	chunk := func(doc *entity.KnowledgeDocument, id string) *entity.KnowledgeChunk {
+		return &entity.KnowledgeChunk{KnowledgeDocID: doc.ID, ChunkID: id, KnowledgeBaseName: "kb", Status: consts.StatusActive}
+	}
+	// 旧版本的a只被旧版本使用，s同时被新版本使用，o被其它目录的同名文件使用
+	if _, err := r.chunk.BatchCreate(ctx, []*entity.KnowledgeChunk{
+		chunk(old, "a"), chunk(old, "s"), chunk(old, "o"), chunk(cur, "s"), chunk(other, "o"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := biz.Supersede(uc, ctx, cur); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[int64]int32{old.ID: consts.StatusSuperseded, failed.ID: consts.StatusFailed, other.ID: consts.StatusActive, cur.ID: consts.StatusActive}
+	for id, status := range want {
+		doc, err := r.doc.Get(ctx, id)
+		if err != nil || doc.Status != status {
+			t.Errorf("doc %d status = %d, want %d, err = %v", id, doc.Status, status, err)
+		}
+	}
+	qc := query.KnowledgeChunk
+	n, err := r.chunk.Count(ctx, qc.KnowledgeDocID.Eq(old.ID), qc.Status.Eq(consts.StatusSuperseded))
+	if err != nil || n != 3 {
+		t.Errorf("%d superseded chunks of old version, err = %v", n, err)
+	}
+	// 只删除不再被生效的文档块引用的索引文档
+	if fmt.Sprint(store.deleted) != "[a]" {
+		t.Errorf("deleted = %v", store.deleted)
+	}
+
+	// 没有旧版本时不做任何操作
+	store.deleted = nil
+	if err = biz.Supersede(uc, ctx, other); err != nil || len(store.deleted) != 0 {
+		t.Errorf("err = %v, deleted = %v", err, store.deleted)
+	}
+}
+
+func TestListLatest(t *testing.T) {
+	r := newTestRepos(t)
+	uc := newTestDocumentUsecase(r, &fakeStore{})
+	ctx := context.Background()
+	createDocs(t, r,
+		// 新版本索引失败或正在索引时返回生效的旧版本
+		&entity.KnowledgeDocument{KnowledgeBaseName: "kb", FileName: "a.md", Version: 1, Status: consts.StatusSuperseded},
+		&entity.KnowledgeDocument{KnowledgeBaseName: "kb", FileName: "a.md", Version: 2, Status: consts.StatusActive},
+		&entity.KnowledgeDocument{KnowledgeBaseName: "kb", FileName: "a.md", Version: 3, Status: consts.StatusFailed},
+		&entity.KnowledgeDocument{KnowledgeBaseName: "kb", FileName: "a.md", Version: 4, Status: consts.StatusIndexing},
+		// 没有生效的版本时返回版本号最大的版本
+		&entity.KnowledgeDocument{KnowledgeBaseName: "kb", FileName: "b.md", Version: 1, Status: consts.StatusFailed},
+		&entity.KnowledgeDocument{KnowledgeBaseName: "kb", FileName: "b.md", Version: 2, Status: consts.StatusIndexing},
+		// 不同目录的同名文件是不同的文件
+		&entity.KnowledgeDocument{KnowledgeBaseName: "kb", Path: "sub", FileName: "a.md", Version: 1, Status: consts.StatusActive},
+		&entity.KnowledgeDocument{KnowledgeBaseName: "kb2", FileName: "a.md", Version: 5, Status: consts.StatusActive},
+	)
+	versions := func(list []*pb.KnowledgeDocument) string {
+		s := ""
+		for _, d := range list {
+			s += fmt.Sprintf("%s/%s/%s@%d ", d.KnowledgeBaseName, d.Path, d.FileName, d.Version)
+		}
+		return s
+	}
+
+	res, err := uc.List(ctx, &pb.ListKnowledgeDocumentRequest{KnowledgeName: "kb", Latest: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := versions(res.List), "kb//a.md@2 kb//b.md@2 kb/sub/a.md@1 "; got != want {
+		t.Errorf("latest = %q, want %q", got, want)
+	}
+	res, err = uc.List(ctx, &pb.ListKnowledgeDocumentRequest{FileName: "a", Latest: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := versions(res.List), "kb//a.md@2 kb/sub/a.md@1 kb2//a.md@5 "; got != want {
+		t.Errorf("latest = %q, want %q", got, want)
+	}
+	// 不过滤版本时返回所有版本
+	res, err = uc.List(ctx, &pb.ListKnowledgeDocumentRequest{KnowledgeName: "kb"})
+	if err != nil || len(res.List) != 7 {
+		t.Errorf("all versions = %d, %v", len(res.List), err)
+	}
+}
+
+func TestSaveParents(t *testing.T) {
+	r := newTestRepos(t)
+	uc := newTestDocumentUsecase(r, &fakeStore{})
+	ctx := context.Background()
+	qc := query.KnowledgeChunk
+	// 已有的生效父块会被复用，已替代的父块不会
+	if _, err := r.chunk.BatchCreate(ctx, []*entity.KnowledgeChunk{
+		{KnowledgeDocID: 1, KnowledgeBaseName: "kb", ChunkID: "old", ContentHash: ai.ContentHash("旧父块"), IsParent: true, Status: consts.StatusActive},
+		{KnowledgeDocID: 1, KnowledgeBaseName: "kb", ChunkID: "gone", ContentHash: ai.ContentHash("失效父块"), IsParent: true, Status: consts.StatusSuperseded},
+		{KnowledgeDocID: 1, KnowledgeBaseName: "kb2", ChunkID: "other", ContentHash: ai.ContentHash("新父块"), IsParent: true, Status: consts.StatusActive},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	doc := &entity.KnowledgeDocument{ID: 2, KnowledgeBaseName: "kb"}
+	docs := []*schema.Document{
+		{ID: "n1", Content: "新父块", MetaData: map[string]any{ai.MetaFileName: "a.md"}},
+		{ID: "n2", Content: " 新父块\n", MetaData: map[string]any{}},
+		{ID: "n3", Content: "旧父块", MetaData: map[string]any{}},
+		{ID: "n4", Content: "失效父块", MetaData: map[string]any{}},
+	}
+	ids, err := biz.SaveParents(uc, ctx, doc, docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 内容相同的父块只保存一次，文档块的id替换为实际使用的父块id，子块据此记录父块
+	if fmt.Sprint(ids) != "[n1 old n4]" {
+		t.Errorf("ids = %v", ids)
+	}
+	if docs[0].ID != "n1" || docs[1].ID != "n1" || docs[2].ID != "old" || docs[3].ID != "n4" {
+		t.Errorf("doc ids = %s,%s,%s,%s", docs[0].ID, docs[1].ID, docs[2].ID, docs[3].ID)
+	}
+	chunks, err := r.chunk.ListAll(ctx, qc.KnowledgeDocID.Eq(2))
+	if err != nil || len(chunks) != 3 {
+		t.Fatalf("%d parent chunks saved, err = %v", len(chunks), err)
+	}
+	for _, c := range chunks {
+		if !c.IsParent || c.Status != consts.StatusActive || c.KnowledgeBaseName != "kb" {
+			t.Errorf("chunk = %+v", c)
+		}
+	}
+	if chunks[0].Ext != `{"_file_name":"a.md"}` {
+		t.Errorf("ext = %s", chunks[0].Ext)
+	}
+}
+
+func TestStoreChunks(t *testing.T) {
+	r := newTestRepos(t)
+	store := &fakeStore{}
+	uc := newTestDocumentUsecase(r, store)
+	ctx := context.Background()
+	qc := query.KnowledgeChunk
+
+	// 写入索引前文档块已经保存，pgvector才能把向量写到行上
+	store.onStore = func(docs []*schema.Document) {
+		for _, doc := range docs {
+			n, err := r.chunk.Count(ctx, qc.ChunkID.Eq(doc.ID))
+			if err != nil || n == 0 {
+				t.Errorf("chunk %s not saved before Store: %v", doc.ID, err)
+			}
+		}
+	}
+	doc1 := &entity.KnowledgeDocument{ID: 1, KnowledgeBaseName: "kb"}
+	docs := []*schema.Document{
+		{ID: "a", Content: "A", MetaData: map[string]any{ai.MetaFileName: "1.md"}},
+		{ID: "b", Content: "B", MetaData: map[string]any{}},
+		{ID: "a2", Content: "A", MetaData: map[string]any{}},
+	}
+	ids, err := biz.StoreChunks(uc, ctx, doc1, docs, []string{"ha", "hb", "ha"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != "a" || ids[1] != "b" || len(store.stored) != 2 {
+		t.Fatalf("ids = %v, stored = %v", ids, store.stored)
+	}
+	chunk, err := r.chunk.GetByConditions(ctx, qc.ChunkID.Eq("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chunk.Ext != `{"_file_name":"1.md"}` || chunk.Status != consts.StatusActive {
+		t.Errorf("chunk = %+v", chunk)
+	}
+
+	// 另一个文档复用已有的文档块，不再写入索引，向量存储收到复用通知
+	store.stored, store.onStore = nil, nil
+	doc2 := &entity.KnowledgeDocument{ID: 2, KnowledgeBaseName: "kb"}
+	ids, err = biz.StoreChunks(uc, ctx, doc2, []*schema.Document{{ID: "x", Content: "A"}, {ID: "c", Content: "C"}}, []string{"ha", "hc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != "a" || ids[1] != "c" {
+		t.Fatalf("ids = %v", ids)
+	}
+	if len(store.stored) != 1 || store.stored[0] != "c" || len(store.reused) != 1 || store.reused[0] != "a" {
+		t.Errorf("stored = %v, reused = %v", store.stored, store.reused)
+	}
+
+	// 写入索引失败时删除刚保存的文档块，其它文档不会复用没有写入索引的文档块
+	store.storeErr = errors.New("es unavailable")
+	doc3 := &entity.KnowledgeDocument{ID: 3, KnowledgeBaseName: "kb"}
+	if _, err = biz.StoreChunks(uc, ctx, doc3, []*schema.Document{{ID: "d", Content: "D"}, {ID: "y", Content: "B"}}, []string{"hd", "hb"}); err == nil {
+		t.Fatal("expected error")
+	}
+	n, err := r.chunk.Count(ctx, qc.KnowledgeDocID.Eq(3))
+	if err != nil || n != 0 {
+		t.Errorf("%d chunks left after rollback, err = %v", n, err)
+	}
+	// 其它文档的文档块不受影响
+	n, err = r.chunk.Count(ctx, qc.ChunkID.Eq("b"))
+	if err != nil || n != 1 {
+		t.Errorf("chunk b count = %d, err = %v", n, err)
+	}
+}
@@ -0,0 +1,155 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"ragx/app/internal/biz/entity"
+	"ragx/app/internal/biz/query"
+	"ragx/app/internal/consts"
+	"ragx/app/pkg/cache/redis"
+	"ragx/app/pkg/crawler"
+	"ragx/app/pkg/utils"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	// 每次最多刷新的文档数量
+	refreshBatchSize = 100
+	// 刷新锁的过期时间，需要大于单个文档重新抓取、建立索引的时间
+	refreshLockTTL = 10 * time.Minute
+	// 刷新锁的key前缀，后面是文档id
+	refreshLockPrefix = "ragx:refresh:doc:"
+)
+
+// 只有持有锁的实例才能释放锁，避免锁过期后误删其他实例的锁
+const unlockScript = `if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("del", KEYS[1]) else return 0 end`
+
+// KnowledgeRefreshUsecase 定时重新抓取网页来源的文档
+// 多个实例同时运行时，通过redis锁保证同一个文档只被一个实例刷新
+type KnowledgeRefreshUsecase struct {
+	repo  KnowledgeDocumentRepo
+	docUc *KnowledgeDocumentUsecase
+	rdb   *redis.Client
+	log   *log.Helper
+}
+
+func NewKnowledgeRefreshUsecase(repo KnowledgeDocumentRepo, docUc *KnowledgeDocumentUsecase, data Data, logger log.Logger) *KnowledgeRefreshUsecase {
+	return &KnowledgeRefreshUsecase{repo: repo, docUc: docUc, rdb: data.Rdb(), log: log.NewHelper(logger)}
+}
+
+// CheckLock 检查刷新锁，没有配置redis时无法在多个实例之间加锁
+// 存在定时刷新的文档时输出警告，多实例部署时同一个文档可能被每个实例重复刷新，需要配置redis或者只运行一个实例
+func (uc *KnowledgeRefreshUsecase) CheckLock(ctx context.Context) {
+	if uc.rdb != nil {
+		return
+	}
+	q := query.KnowledgeDocument
+	n, err := uc.repo.Count(ctx, q.Status.Eq(consts.StatusActive), q.SourceURL.Neq(""), q.RefreshSchedule.Neq(""))
+	if err != nil {
+		uc.log.Errorf("KnowledgeRefreshUsecase.CheckLock Count err: %+v", err)
+		return
+	}
+	if n > 0 {
+		uc.log.Warnf("KnowledgeRefreshUsecase.CheckLock %d documents have refresh schedules but redis is not configured, "+
+			"refreshes are not locked and every instance will refresh them", n)
+	}
+}
+
+// RunDue 刷新所有到期的文档，返回刷新的文档数量
+func (uc *KnowledgeRefreshUsecase) RunDue(ctx context.Context) (int, error) {
+	q := query.KnowledgeDocument
+	docs, err := uc.repo.ListWithoutCount(ctx, &entity.PageAndOrder{
+		PageData: entity.PageData{Page: 1, PageSize: refreshBatchSize},
+		Order:    q.NextRefreshAt,
+	}, q.Status.Eq(consts.StatusActive), q.SourceURL.Neq(""), q.RefreshSchedule.Neq(""), q.NextRefreshAt.Lte(time.Now()))
+	if err != nil {
+		uc.log.Errorf("KnowledgeRefreshUsecase.RunDue ListWithoutCount err: %+v", err)
+		return 0, err
+	}
+	n := 0
+	for _, doc := range docs {
+		if ctx.Err() != nil {
+			break
+		}
+		ok, err := uc.refreshWithLock(ctx, doc.ID)
+		if err != nil {
+			// 单个文档刷新失败不影响其他文档
+			uc.log.Errorf("KnowledgeRefreshUsecase.RunDue refresh doc %d err: %+v", doc.ID, err)
+			continue
+		}
+		if ok {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// 获取锁后刷新文档，没有获取到锁时返回false
+// 没有配置redis时不加锁，只适合单实例部署，启动时由CheckLock输出警告
+func (uc *KnowledgeRefreshUsecase) refreshWithLock(ctx context.Context, id int64) (bool, error) {
+	if uc.rdb != nil {
+		key := fmt.Sprintf("%s%d", refreshLockPrefix, id)
+		token := utils.UniqueID()
+		ok, err := uc.rdb.SetNX(ctx, key, token, refreshLockTTL)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			return false, nil
+		}
+		defer func() {
+			if err := uc.rdb.RunScript(context.WithoutCancel(ctx), unlockScript, []string{key}, token).Err(); err != nil {
+				uc.log.Errorf("KnowledgeRefreshUsecase.refreshWithLock unlock err: %+v", err)
+			}
+		}()
+	}
+	// 获取锁之前其他实例可能已经刷新过，重新查询确认文档仍然需要刷新
+	q := query.KnowledgeDocument
+	doc, err := uc.repo.GetByConditions(ctx, q.ID.Eq(id), q.Status.Eq(consts.StatusActive), q.NextRefreshAt.Lte(time.Now()))
+	if err != nil {
+		if entity.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, uc.refresh(ctx, doc)
+}
+
+// 使用ETag、Last-Modified发送条件请求，网页没有变化时只更新下次刷新的时间
+// 网页内容变化时生成新的版本，只有变化的文档块需要重新向量化
+// 抓取时使用的robots.txt选项与首次抓取时相同，robots.txt不再允许抓取时保留已有的版本
+func (uc *KnowledgeRefreshUsecase) refresh(ctx context.Context, doc *entity.KnowledgeDocument) error {
+	c, err := crawler.New(crawler.Options{RespectRobots: doc.RespectRobots})
+	if err != nil {
+		return err
+	}
+	page, err := c.Fetch(ctx, doc.SourceURL, doc.ETag, doc.LastModified)
+	if err == nil {
+		// 文档以地址作为文件名，保持不变才能替换掉旧版本
+		page.CanonicalURL = doc.SourceURL
+		if _, err = uc.docUc.ingestPage(ctx, doc.KnowledgeBaseName, page, doc.RefreshSchedule, doc.Selector, doc.ChunkStrategy, doc.RespectRobots); err == nil {
+			return nil
+		}
+	} else if errors.Is(err, crawler.ErrNotModified) {
+		err = nil
+	}
+	// 网页没有变化或者刷新失败，等到下个周期再刷新
+	if e := uc.docUc.setRefresh(ctx, doc.ID, doc.RefreshSchedule, doc.RespectRobots, doc.ETag, doc.LastModified); e != nil {
+		return e
+	}
+	return err
+}
+
+// 根据刷新周期计算下次刷新的时间，支持标准的5位cron表达式和@every、@daily等描述符
+func nextRefreshAt(schedule string, now time.Time) (time.Time, error) {
+	s, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return s.Next(now), nil
+}
@@ -0,0 +1,207 @@
+package biz_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"ragx/app/internal/biz"
+	"ragx/app/internal/biz/entity"
+	"ragx/app/internal/consts"
+	"ragx/app/pkg/cache/redis"
+
+	"github.com/go-kratos/kratos/v2/log"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+func TestNextRefreshAt(t *testing.T) {
+	now := time.Date(2025, 3, 10, 8, 30, 0, 0, time.UTC)
+	tests := []struct {
+		schedule string
+		want     time.Time
+	}{
+		{"@every 1h", now.Add(time.Hour)},
+		{"@every 90m", now.Add(90 * time.Minute)},
+		{"@daily", time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2025, 3, 11, 3, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 3, 10, 8, 45, 0, 0, time.UTC)},
+		// 3月10日是周一
+		{"0 9 * * 1", time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := biz.NextRefreshAt(tt.schedule, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("nextRefreshAt(%q) = %v, %v, want %v", tt.schedule, got, err, tt.want)
+		}
+	}
+	// 只支持5位的cron表达式
+	for _, schedule := range []string{"", "daily", "0 0 3 * * *", "61 * * * *", "@every"} {
+		if _, err := biz.NextRefreshAt(schedule, now); err == nil {
+			t.Errorf("nextRefreshAt(%q) should fail", schedule)
+		}
+	}
+}
+
+// 内存中的redis，只实现刷新锁用到的SetNX和解锁脚本
+type fakeRedis struct {
+	goredis.UniversalClient
+	mu     sync.Mutex
+	values map[string]string
+	ttls   map[string]time.Duration
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{values: map[string]string{}, ttls: map[string]time.Duration{}}
+}
+
+func (f *fakeRedis) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *goredis.BoolCmd {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.values[key]; ok {
+		return goredis.NewBoolResult(false, nil)
+	}
+	f.values[key], f.ttls[key] = fmt.Sprint(value), expiration
+	return goredis.NewBoolResult(true, nil)
+}
+
+// 解锁脚本：值与持有锁时的token相同才删除
+func (f *fakeRedis) EvalSha(ctx context.Context, sha1 string, keys []string, args ...interface{}) *goredis.Cmd {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.values[keys[0]] != fmt.Sprint(args[0]) {
+		return goredis.NewCmdResult(int64(0), nil)
+	}
+	delete(f.values, keys[0])
+	return goredis.NewCmdResult(int64(1), nil)
+}
+
+func (f *fakeRedis) get(key string) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	v, ok := f.values[key]
+	return v, ok
+}
+
+// 记录日志的logger
+type recordLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *recordLogger) Log(level log.Level, keyvals ...interface{}) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, level.String()+" "+fmt.Sprint(keyvals...))
+	return nil
+}
+
+// 创建一个已经到期的定时刷新文档，网页地址是内网地址，抓取会失败
+func createDueDoc(t *testing.T, r *testRepos) *entity.KnowledgeDocument {
+	t.Helper()
+	due := time.Now().Add(-time.Minute)
+	return createDocs(t, r, &entity.KnowledgeDocument{KnowledgeBaseName: "kb", FileName: "http://127.0.0.1:1/page", Version: 1,
+		Status: consts.StatusActive, SourceURL: "http://127.0.0.1:1/page", RefreshSchedule: "@every 1h", NextRefreshAt: &due,
+		RespectRobots: true, ETag: `"v1"`})[0]
+}
+
+func TestRunDueLock(t *testing.T) {
+	r := newTestRepos(t)
+	rdb := newFakeRedis()
+	r.data.rdb = redis.NewClient(rdb)
+	refreshUc := biz.NewKnowledgeRefreshUsecase(r.doc, newTestDocumentUsecase(r, &fakeStore{}), r.data, log.DefaultLogger)
+	ctx := context.Background()
+	doc := createDueDoc(t, r)
+	key := fmt.Sprintf("ragx:refresh:doc:%d", doc.ID)
+
+	// 其他实例持有锁时跳过，文档保持到期状态
+	rdb.values[key] = "other"
+	n, err := refreshUc.RunDue(ctx)
+	if err != nil || n != 0 {
+		t.Fatalf("RunDue() = %d, %v", n, err)
+	}
+	got, err := r.doc.Get(ctx, doc.ID)
+	if err != nil || !got.NextRefreshAt.Equal(*doc.NextRefreshAt) {
+		t.Fatalf("next refresh changed while locked: %v, %v", got.NextRefreshAt, err)
+	}
+	if v, _ := rdb.get(key); v != "other" {
+		t.Errorf("lock of other instance changed: %q", v)
+	}
+
+	// 锁释放后刷新，抓取失败时推迟到下个周期，并释放自己的锁
+	delete(rdb.values, key)
+	if _, err = refreshUc.RunDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+	got, err = r.doc.Get(ctx, doc.ID)
+	if err != nil || got.NextRefreshAt == nil || !got.NextRefreshAt.After(time.Now().Add(50*time.Minute)) {
+		t.Fatalf("next refresh = %v, %v", got.NextRefreshAt, err)
+	}
+	// 抓取选项和缓存标识保持不变，下次刷新仍然遵守robots.txt
+	if !got.RespectRobots || got.ETag != doc.ETag {
+		t.Errorf("doc = %+v", got)
+	}
+	if _, ok := rdb.get(key); ok {
+		t.Error("lock not released")
+	}
+	if rdb.ttls[key] != 10*time.Minute {
+		t.Errorf("lock ttl = %v", rdb.ttls[key])
+	}
+}
+
+func TestCheckLock(t *testing.T) {
+	r := newTestRepos(t)
+	ctx := context.Background()
+	logger := &recordLogger{}
+	refreshUc := biz.NewKnowledgeRefreshUsecase(r.doc, newTestDocumentUsecase(r, &fakeStore{}), r.data, logger)
+
+	// 没有定时刷新的文档时不警告
+	refreshUc.CheckLock(ctx)
+	if len(logger.lines) != 0 {
+		t.Errorf("logs = %v", logger.lines)
+	}
+	createDueDoc(t, r)
+	refreshUc.CheckLock(ctx)
+	if len(logger.lines) != 1 || !strings.HasPrefix(logger.lines[0], "WARN") || !strings.Contains(logger.lines[0], "redis is not configured") {
+		t.Errorf("logs = %v", logger.lines)
+	}
+
+	// 配置了redis时不警告
+	logger.lines = nil
+	r.data.rdb = redis.NewClient(newFakeRedis())
+	refreshUc = biz.NewKnowledgeRefreshUsecase(r.doc, newTestDocumentUsecase(r, &fakeStore{}), r.data, logger)
+	refreshUc.CheckLock(ctx)
+	if len(logger.lines) != 0 {
+		t.Errorf("logs = %v", logger.lines)
+	}
+}
+
+func TestSetRefresh(t *testing.T) {
+	r := newTestRepos(t)
+	uc := newTestDocumentUsecase(r, &fakeStore{})
+	ctx := context.Background()
+	doc := createDocs(t, r, &entity.KnowledgeDocument{KnowledgeBaseName: "kb", FileName: "https://example.com/a", Version: 1,
+		Status: consts.StatusActive, SourceURL: "https://example.com/a"})[0]
+
+	if err := biz.SetRefresh(uc, ctx, doc.ID, "@every 1h", true, `"v1"`, "Mon, 02 Jan 2006 15:04:05 GMT"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := r.doc.Get(ctx, doc.ID)
+	if err != nil || got.RefreshSchedule != "@every 1h" || !got.RespectRobots || got.ETag != `"v1"` || got.NextRefreshAt == nil {
+		t.Fatalf("doc = %+v, err = %v", got, err)
+	}
+	// 重新抓取时不再定时刷新，也不再遵守robots.txt
+	if err = biz.SetRefresh(uc, ctx, doc.ID, "", false, "", ""); err != nil {
+		t.Fatal(err)
+	}
+	got, err = r.doc.Get(ctx, doc.ID)
+	if err != nil || got.RefreshSchedule != "" || got.RespectRobots || got.ETag != "" || got.NextRefreshAt != nil {
+		t.Errorf("doc = %+v, err = %v", got, err)
+	}
+	if err = biz.SetRefresh(uc, ctx, doc.ID, "daily", true, "", ""); err == nil {
+		t.Error("invalid schedule should fail")
+	}
+}
@@ -34,6 +34,8 @@ func newKnowledgeBase(db *gorm.DB, opts ...gen.DOOption) knowledgeBase {
 	_knowledgeBase.Status = field.NewInt32(tableName, "status")
 	_knowledgeBase.CreateTime = field.NewTime(tableName, "create_time")
 	_knowledgeBase.UpdateTime = field.NewTime(tableName, "update_time")
+	_knowledgeBase.Enrich = field.NewBool(tableName, "enrich")
+	_knowledgeBase.EnrichEmbed = field.NewBool(tableName, "enrich_embed")
 
 	_knowledgeBase.fillFieldMap()
 
@@ -51,6 +53,8 @@ type knowledgeBase struct {
 	Status      field.Int32
 	CreateTime  field.Time
 	UpdateTime  field.Time
+	Enrich      field.Bool
+	EnrichEmbed field.Bool
 
 	fieldMap map[string]field.Expr
 }
@@ -74,6 +78,8 @@ func (k *knowledgeBase) updateTableName(table string) *knowledgeBase {
 	k.Status = field.NewInt32(table, "status")
 	k.CreateTime = field.NewTime(table, "create_time")
 	k.UpdateTime = field.NewTime(table, "update_time")
+	k.Enrich = field.NewBool(table, "enrich")
+	k.EnrichEmbed = field.NewBool(table, "enrich_embed")
 
 	k.fillFieldMap()
 
@@ -90,7 +96,7 @@ func (k *knowledgeBase) GetFieldByName(fieldName string) (field.OrderExpr, bool)
 }
 
 func (k *knowledgeBase) fillFieldMap() {
-	k.fieldMap = make(map[string]field.Expr, 7)
+	k.fieldMap = make(map[string]field.Expr, 9)
 	k.fieldMap["id"] = k.ID
 	k.fieldMap["name"] = k.Name
 	k.fieldMap["description"] = k.Description
@@ -98,6 +104,8 @@ func (k *knowledgeBase) fillFieldMap() {
 	k.fieldMap["status"] = k.Status
 	k.fieldMap["create_time"] = k.CreateTime
 	k.fieldMap["update_time"] = k.UpdateTime
+	k.fieldMap["enrich"] = k.Enrich
+	k.fieldMap["enrich_embed"] = k.EnrichEmbed
 }
 
 func (k knowledgeBase) clone(db *gorm.DB) knowledgeBase {
@@ -35,6 +35,11 @@ func newKnowledgeChunk(db *gorm.DB, opts ...gen.DOOption) knowledgeChunk {
 	_knowledgeChunk.Status = field.NewInt32(tableName, "status")
 	_knowledgeChunk.CreatedAt = field.NewTime(tableName, "created_at")
 	_knowledgeChunk.UpdatedAt = field.NewTime(tableName, "updated_at")
+	_knowledgeChunk.KnowledgeBaseName = field.NewString(tableName, "knowledge_base_name")
+	_knowledgeChunk.ContentHash = field.NewString(tableName, "content_hash")
+	_knowledgeChunk.RecordID = field.NewString(tableName, "record_id")
+	_knowledgeChunk.ParentID = field.NewString(tableName, "parent_id")
+	_knowledgeChunk.IsParent = field.NewBool(tableName, "is_parent")
 
 	_knowledgeChunk.fillFieldMap()
 
@@ -44,15 +49,20 @@ func newKnowledgeChunk(db *gorm.DB, opts ...gen.DOOption) knowledgeChunk {
 type knowledgeChunk struct {
 	knowledgeChunkDo
 
-	ALL            field.Asterisk
-	ID             field.Int64
-	KnowledgeDocID field.Int64
-	ChunkID        field.String
-	Content        field.String
-	Ext            field.String
-	Status         field.Int32
-	CreatedAt      field.Time
-	UpdatedAt      field.Time
+	ALL               field.Asterisk
+	ID                field.Int64
+	KnowledgeDocID    field.Int64
+	ChunkID           field.String
+	Content           field.String
+	Ext               field.String
+	Status            field.Int32
+	CreatedAt         field.Time
+	UpdatedAt         field.Time
+	KnowledgeBaseName field.String
+	ContentHash       field.String
+	RecordID          field.String
+	ParentID          field.String
+	IsParent          field.Bool
 
 	fieldMap map[string]field.Expr
 }
@@ -77,6 +87,11 @@ func (k *knowledgeChunk) updateTableName(table string) *knowledgeChunk {
 	k.Status = field.NewInt32(table, "status")
 	k.CreatedAt = field.NewTime(table, "created_at")
 	k.UpdatedAt = field.NewTime(table, "updated_at")
+	k.KnowledgeBaseName = field.NewString(table, "knowledge_base_name")
+	k.ContentHash = field.NewString(table, "content_hash")
+	k.RecordID = field.NewString(table, "record_id")
+	k.ParentID = field.NewString(table, "parent_id")
+	k.IsParent = field.NewBool(table, "is_parent")
 
 	k.fillFieldMap()
 
@@ -93,7 +108,7 @@ func (k *knowledgeChunk) GetFieldByName(fieldName string) (field.OrderExpr, bool
 }
 
 func (k *knowledgeChunk) fillFieldMap() {
-	k.fieldMap = make(map[string]field.Expr, 8)
+	k.fieldMap = make(map[string]field.Expr, 13)
 	k.fieldMap["id"] = k.ID
 	k.fieldMap["knowledge_doc_id"] = k.KnowledgeDocID
 	k.fieldMap["chunk_id"] = k.ChunkID
@@ -102,6 +117,11 @@ func (k *knowledgeChunk) fillFieldMap() {
 	k.fieldMap["status"] = k.Status
 	k.fieldMap["created_at"] = k.CreatedAt
 	k.fieldMap["updated_at"] = k.UpdatedAt
+	k.fieldMap["knowledge_base_name"] = k.KnowledgeBaseName
+	k.fieldMap["content_hash"] = k.ContentHash
+	k.fieldMap["record_id"] = k.RecordID
+	k.fieldMap["parent_id"] = k.ParentID
+	k.fieldMap["is_parent"] = k.IsParent
 }
 
 func (k knowledgeChunk) clone(db *gorm.DB) knowledgeChunk {
@@ -33,6 +33,21 @@ func newKnowledgeDocument(db *gorm.DB, opts ...gen.DOOption) knowledgeDocument {
 	_knowledgeDocument.Status = field.NewInt32(tableName, "status")
 	_knowledgeDocument.CreatedAt = field.NewTime(tableName, "created_at")
 	_knowledgeDocument.UpdatedAt = field.NewTime(tableName, "updated_at")
+	_knowledgeDocument.FileHash = field.NewString(tableName, "file_hash")
+	_knowledgeDocument.Version = field.NewInt32(tableName, "version")
+	_knowledgeDocument.Path = field.NewString(tableName, "path")
+	_knowledgeDocument.URI = field.NewString(tableName, "uri")
+	_knowledgeDocument.SourceURL = field.NewString(tableName, "source_url")
+	_knowledgeDocument.RefreshSchedule = field.NewString(tableName, "refresh_schedule")
+	_knowledgeDocument.ETag = field.NewString(tableName, "etag")
+	_knowledgeDocument.LastModified = field.NewString(tableName, "last_modified")
+	_knowledgeDocument.NextRefreshAt = field.NewTime(tableName, "next_refresh_at")
+	_knowledgeDocument.RespectRobots = field.NewBool(tableName, "respect_robots")
+	_knowledgeDocument.Selector = field.NewString(tableName, "selector")
+	_knowledgeDocument.FieldMapping = field.NewString(tableName, "field_mapping")
+	_knowledgeDocument.ChunkStrategy = field.NewString(tableName, "chunk_strategy")
+	_knowledgeDocument.EnrichTokens = field.NewInt64(tableName, "enrich_tokens")
+	_knowledgeDocument.PiiReport = field.NewString(tableName, "pii_report")
 
 	_knowledgeDocument.fillFieldMap()
 
@@ -49,6 +64,21 @@ type knowledgeDocument struct {
 	Status            field.Int32
 	CreatedAt         field.Time
 	UpdatedAt         field.Time
+	FileHash          field.String
+	Version           field.Int32
+	Path              field.String
+	URI               field.String
+	SourceURL         field.String
+	RefreshSchedule   field.String
+	ETag              field.String
+	LastModified      field.String
+	NextRefreshAt     field.Time
+	RespectRobots     field.Bool
+	Selector          field.String
+	FieldMapping      field.String
+	ChunkStrategy     field.String
+	EnrichTokens      field.Int64
+	PiiReport         field.String
 
 	fieldMap map[string]field.Expr
 }
@@ -71,6 +101,21 @@ func (k *knowledgeDocument) updateTableName(table string) *knowledgeDocument {
 	k.Status = field.NewInt32(table, "status")
 	k.CreatedAt = field.NewTime(table, "created_at")
 	k.UpdatedAt = field.NewTime(table, "updated_at")
+	k.FileHash = field.NewString(table, "file_hash")
+	k.Version = field.NewInt32(table, "version")
+	k.Path = field.NewString(table, "path")
+	k.URI = field.NewString(table, "uri")
+	k.SourceURL = field.NewString(table, "source_url")
+	k.RefreshSchedule = field.NewString(table, "refresh_schedule")
+	k.ETag = field.NewString(table, "etag")
+	k.LastModified = field.NewString(table, "last_modified")
+	k.NextRefreshAt = field.NewTime(table, "next_refresh_at")
+	k.RespectRobots = field.NewBool(table, "respect_robots")
+	k.Selector = field.NewString(table, "selector")
+	k.FieldMapping = field.NewString(table, "field_mapping")
+	k.ChunkStrategy = field.NewString(table, "chunk_strategy")
+	k.EnrichTokens = field.NewInt64(table, "enrich_tokens")
+	k.PiiReport = field.NewString(table, "pii_report")
 
 	k.fillFieldMap()
 
@@ -87,13 +132,28 @@ func (k *knowledgeDocument) GetFieldByName(fieldName string) (field.OrderExpr, b
 }
 
 func (k *knowledgeDocument) fillFieldMap() {
-	k.fieldMap = make(map[string]field.Expr, 6)
+	k.fieldMap = make(map[string]field.Expr, 21)
 	k.fieldMap["id"] = k.ID
 	k.fieldMap["knowledge_base_name"] = k.KnowledgeBaseName
 	k.fieldMap["file_name"] = k.FileName
 	k.fieldMap["status"] = k.Status
 	k.fieldMap["created_at"] = k.CreatedAt
 	k.fieldMap["updated_at"] = k.UpdatedAt
+	k.fieldMap["file_hash"] = k.FileHash
+	k.fieldMap["version"] = k.Version
+	k.fieldMap["path"] = k.Path
+	k.fieldMap["uri"] = k.URI
+	k.fieldMap["source_url"] = k.SourceURL
+	k.fieldMap["refresh_schedule"] = k.RefreshSchedule
+	k.fieldMap["etag"] = k.ETag
+	k.fieldMap["last_modified"] = k.LastModified
+	k.fieldMap["next_refresh_at"] = k.NextRefreshAt
+	k.fieldMap["respect_robots"] = k.RespectRobots
+	k.fieldMap["selector"] = k.Selector
+	k.fieldMap["field_mapping"] = k.FieldMapping
+	k.fieldMap["chunk_strategy"] = k.ChunkStrategy
+	k.fieldMap["enrich_tokens"] = k.EnrichTokens
+	k.fieldMap["pii_report"] = k.PiiReport
 }
 
 func (k knowledgeDocument) clone(db *gorm.DB) knowledgeDocument {
@@ -27,6 +27,11 @@ type Bootstrap struct {
 	Server        *Server                `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
 	Data          *Data                  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 	App           *AppConfig             `protobuf:"bytes,3,opt,name=app,proto3" json:"app,omitempty"`
+	Upload        *Upload                `protobuf:"bytes,4,opt,name=upload,proto3" json:"upload,omitempty"`
+	Parser        *Parser                `protobuf:"bytes,5,opt,name=parser,proto3" json:"parser,omitempty"`
+	Chunk         *Chunk                 `protobuf:"bytes,6,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	Redact        *Redact                `protobuf:"bytes,7,opt,name=redact,proto3" json:"redact,omitempty"`
+	Embedding     *Embedding             `protobuf:"bytes,8,opt,name=embedding,proto3" json:"embedding,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -82,6 +87,520 @@ func (x *Bootstrap) GetApp() *AppConfig {
 	return nil
 }
 
+func (x *Bootstrap) GetUpload() *Upload {
+	if x != nil {
+		return x.Upload
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetParser() *Parser {
+	if x != nil {
+		return x.Parser
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetChunk() *Chunk {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetRedact() *Redact {
+	if x != nil {
+		return x.Redact
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetEmbedding() *Embedding {
+	if x != nil {
+		return x.Embedding
+	}
+	return nil
+}
+
+// Embedding 嵌入器配置，修改向量维度后需要使用新的索引名
+type Embedding struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 嵌入器类型：ark、openai(兼容OpenAI接口)、ollama(兼容Ollama接口)、hash(本地哈希，不需要网络，用于开发和测试)，默认ark
+	Provider string `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	// 模型名称
+	Model string `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	// 服务地址，为空时使用各类型的默认地址
+	BaseUrl string `protobuf:"bytes,3,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	// API密钥，为空时使用环境变量EMBEDDING_API_KEY，ark类型还会使用ARK_EMBEDDING_API_KEY
+	ApiKey string `protobuf:"bytes,4,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	// 向量维度，为0时启动时请求一次获取，hash类型默认256
+	Dimensions int32 `protobuf:"varint,5,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	// 请求超时，默认60秒
+	Timeout *durationpb.Duration `protobuf:"bytes,6,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// 向量缓存：memory、redis、postgres，为空时不缓存，相同的文本重新建立索引时不再请求嵌入服务
+	Cache string `protobuf:"bytes,7,opt,name=cache,proto3" json:"cache,omitempty"`
+	// redis缓存的过期时间，0表示不过期
+	CacheTtl *durationpb.Duration `protobuf:"bytes,8,opt,name=cache_ttl,json=cacheTtl,proto3" json:"cache_ttl,omitempty"`
+	// 每次请求的文本数，默认16
+	BatchSize int32 `protobuf:"varint,9,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// 同时请求的批次数，默认4
+	Concurrency int32 `protobuf:"varint,10,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	// 限流(429)或服务端错误(5xx)时的重试次数，默认3，-1表示不重试
+	MaxRetries    int32 `protobuf:"varint,11,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Embedding) Reset() {
+	*x = Embedding{}
+	mi := &file_conf_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Embedding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Embedding) ProtoMessage() {}
+
+func (x *Embedding) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Embedding.ProtoReflect.Descriptor instead.
+func (*Embedding) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Embedding) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Embedding) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *Embedding) GetBaseUrl() string {
+	if x != nil {
+		return x.BaseUrl
+	}
+	return ""
+}
+
+func (x *Embedding) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
+}
+
+func (x *Embedding) GetDimensions() int32 {
+	if x != nil {
+		return x.Dimensions
+	}
+	return 0
+}
+
+func (x *Embedding) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *Embedding) GetCache() string {
+	if x != nil {
+		return x.Cache
+	}
+	return ""
+}
+
+func (x *Embedding) GetCacheTtl() *durationpb.Duration {
+	if x != nil {
+		return x.CacheTtl
+	}
+	return nil
+}
+
+func (x *Embedding) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *Embedding) GetConcurrency() int32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+func (x *Embedding) GetMaxRetries() int32 {
+	if x != nil {
+		return x.MaxRetries
+	}
+	return 0
+}
+
+// Redact 建立索引前对文档中的敏感信息脱敏
+type Redact struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 是否启用
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// 处理方式：mask遮盖、hash替换为哈希标记、reject拒绝整个文档，默认mask
+	Mode string `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	// 启用的内置检测器：mobile、id_card、email、iban、bank_card，为空时全部启用
+	Detectors []string       `protobuf:"bytes,3,rep,name=detectors,proto3" json:"detectors,omitempty"`
+	Rules     []*Redact_Rule `protobuf:"bytes,4,rep,name=rules,proto3" json:"rules,omitempty"`
+	// 计算哈希标记的盐
+	Salt          string `protobuf:"bytes,5,opt,name=salt,proto3" json:"salt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Redact) Reset() {
+	*x = Redact{}
+	mi := &file_conf_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Redact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Redact) ProtoMessage() {}
+
+func (x *Redact) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Redact.ProtoReflect.Descriptor instead.
+func (*Redact) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Redact) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Redact) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *Redact) GetDetectors() []string {
+	if x != nil {
+		return x.Detectors
+	}
+	return nil
+}
+
+func (x *Redact) GetRules() []*Redact_Rule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *Redact) GetSalt() string {
+	if x != nil {
+		return x.Salt
+	}
+	return ""
+}
+
+// Chunk 文档分块配置，大小和重叠都以token计
+type Chunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 分词器：estimate按字符类别估算，bpe使用BPE词表精确计算，默认estimate
+	Tokenizer string `protobuf:"bytes,1,opt,name=tokenizer,proto3" json:"tokenizer,omitempty"`
+	// BPE词表的编码名称，默认cl100k_base，词表缓存在TIKTOKEN_CACHE_DIR目录
+	Encoding string `protobuf:"bytes,2,opt,name=encoding,proto3" json:"encoding,omitempty"`
+	// 文档块的大小上限，默认512
+	ChunkSize int32 `protobuf:"varint,3,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	// 相邻文档块的重叠，默认64
+	OverlapSize int32 `protobuf:"varint,4,opt,name=overlap_size,json=overlapSize,proto3" json:"overlap_size,omitempty"`
+	// markdown文档块合并后的大小上限，默认256
+	MergeSize int32 `protobuf:"varint,5,opt,name=merge_size,json=mergeSize,proto3" json:"merge_size,omitempty"`
+	// 默认的分块策略：recursive、semantic，默认recursive
+	Strategy string `protobuf:"bytes,6,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	// 语义分块时，相邻句子的相似度低于这个百分位时切分，默认5
+	BreakpointPercentile float64 `protobuf:"fixed64,7,opt,name=breakpoint_percentile,json=breakpointPercentile,proto3" json:"breakpoint_percentile,omitempty"`
+	// 语义分块时文档块的大小下限，默认64
+	MinChunkSize int32 `protobuf:"varint,8,opt,name=min_chunk_size,json=minChunkSize,proto3" json:"min_chunk_size,omitempty"`
+	// 父子块检索：文档块作为父块保存在数据库，再分割为较小的子块写入索引，检索到子块后使用父块作为上下文
+	ParentChild bool `protobuf:"varint,9,opt,name=parent_child,json=parentChild,proto3" json:"parent_child,omitempty"`
+	// 子块的大小上限，默认128
+	ChildChunkSize int32 `protobuf:"varint,10,opt,name=child_chunk_size,json=childChunkSize,proto3" json:"child_chunk_size,omitempty"`
+	// 为每个文档块生成的问题数，问题向量化后与内容向量一起检索，0表示不生成
+	QuestionsPerChunk int32 `protobuf:"varint,11,opt,name=questions_per_chunk,json=questionsPerChunk,proto3" json:"questions_per_chunk,omitempty"`
+	// 增强文档块时同时调用对话模型的数量，默认4，是否增强在知识库上设置
+	EnrichConcurrency int32 `protobuf:"varint,12,opt,name=enrich_concurrency,json=enrichConcurrency,proto3" json:"enrich_concurrency,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Chunk) Reset() {
+	*x = Chunk{}
+	mi := &file_conf_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Chunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Chunk) ProtoMessage() {}
+
+func (x *Chunk) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Chunk.ProtoReflect.Descriptor instead.
+func (*Chunk) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Chunk) GetTokenizer() string {
+	if x != nil {
+		return x.Tokenizer
+	}
+	return ""
+}
+
+func (x *Chunk) GetEncoding() string {
+	if x != nil {
+		return x.Encoding
+	}
+	return ""
+}
+
+func (x *Chunk) GetChunkSize() int32 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+func (x *Chunk) GetOverlapSize() int32 {
+	if x != nil {
+		return x.OverlapSize
+	}
+	return 0
+}
+
+func (x *Chunk) GetMergeSize() int32 {
+	if x != nil {
+		return x.MergeSize
+	}
+	return 0
+}
+
+func (x *Chunk) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *Chunk) GetBreakpointPercentile() float64 {
+	if x != nil {
+		return x.BreakpointPercentile
+	}
+	return 0
+}
+
+func (x *Chunk) GetMinChunkSize() int32 {
+	if x != nil {
+		return x.MinChunkSize
+	}
+	return 0
+}
+
+func (x *Chunk) GetParentChild() bool {
+	if x != nil {
+		return x.ParentChild
+	}
+	return false
+}
+
+func (x *Chunk) GetChildChunkSize() int32 {
+	if x != nil {
+		return x.ChildChunkSize
+	}
+	return 0
+}
+
+func (x *Chunk) GetQuestionsPerChunk() int32 {
+	if x != nil {
+		return x.QuestionsPerChunk
+	}
+	return 0
+}
+
+func (x *Chunk) GetEnrichConcurrency() int32 {
+	if x != nil {
+		return x.EnrichConcurrency
+	}
+	return 0
+}
+
+// Parser 文档解析配置
+type Parser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Spreadsheet   *Parser_Spreadsheet    `protobuf:"bytes,1,opt,name=spreadsheet,proto3" json:"spreadsheet,omitempty"`
+	Pdf           *Parser_Pdf            `protobuf:"bytes,2,opt,name=pdf,proto3" json:"pdf,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Parser) Reset() {
+	*x = Parser{}
+	mi := &file_conf_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Parser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Parser) ProtoMessage() {}
+
+func (x *Parser) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Parser.ProtoReflect.Descriptor instead.
+func (*Parser) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Parser) GetSpreadsheet() *Parser_Spreadsheet {
+	if x != nil {
+		return x.Spreadsheet
+	}
+	return nil
+}
+
+func (x *Parser) GetPdf() *Parser_Pdf {
+	if x != nil {
+		return x.Pdf
+	}
+	return nil
+}
+
+// Upload 上传文件配置
+type Upload struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 上传文件的临时目录，文件校验通过后保存到blob存储，每个知识库一个子目录
+	Dir string `protobuf:"bytes,1,opt,name=dir,proto3" json:"dir,omitempty"`
+	// 单个文件的大小上限，单位字节
+	MaxSize int64 `protobuf:"varint,2,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+	// 允许上传的文件类型，根据文件内容识别，为空时使用默认值
+	AllowedTypes  []string `protobuf:"bytes,3,rep,name=allowed_types,json=allowedTypes,proto3" json:"allowed_types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Upload) Reset() {
+	*x = Upload{}
+	mi := &file_conf_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Upload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upload) ProtoMessage() {}
+
+func (x *Upload) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upload.ProtoReflect.Descriptor instead.
+func (*Upload) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Upload) GetDir() string {
+	if x != nil {
+		return x.Dir
+	}
+	return ""
+}
+
+func (x *Upload) GetMaxSize() int64 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+func (x *Upload) GetAllowedTypes() []string {
+	if x != nil {
+		return x.AllowedTypes
+	}
+	return nil
+}
+
 // AppConfig 定义应用配置信息
 type AppConfig struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -97,7 +616,7 @@ type AppConfig struct {
 
 func (x *AppConfig) Reset() {
 	*x = AppConfig{}
-	mi := &file_conf_proto_msgTypes[1]
+	mi := &file_conf_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -109,7 +628,7 @@ func (x *AppConfig) String() string {
 func (*AppConfig) ProtoMessage() {}
 
 func (x *AppConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_proto_msgTypes[1]
+	mi := &file_conf_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -122,7 +641,7 @@ func (x *AppConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AppConfig.ProtoReflect.Descriptor instead.
 func (*AppConfig) Descriptor() ([]byte, []int) {
-	return file_conf_proto_rawDescGZIP(), []int{1}
+	return file_conf_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *AppConfig) GetEnv() string {
@@ -157,7 +676,7 @@ type Server struct {
 
 func (x *Server) Reset() {
 	*x = Server{}
-	mi := &file_conf_proto_msgTypes[2]
+	mi := &file_conf_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -169,7 +688,7 @@ func (x *Server) String() string {
 func (*Server) ProtoMessage() {}
 
 func (x *Server) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_proto_msgTypes[2]
+	mi := &file_conf_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -182,7 +701,7 @@ func (x *Server) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Server.ProtoReflect.Descriptor instead.
 func (*Server) Descriptor() ([]byte, []int) {
-	return file_conf_proto_rawDescGZIP(), []int{2}
+	return file_conf_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *Server) GetHttp() *Server_HTTP {
@@ -212,13 +731,15 @@ type Data struct {
 	Redis         *Data_Redis            `protobuf:"bytes,2,opt,name=redis,proto3" json:"redis,omitempty"`
 	ChDatabase    *Data_Database         `protobuf:"bytes,3,opt,name=ch_database,json=chDatabase,proto3" json:"ch_database,omitempty"`
 	Elasticsearch *Data_Elasticsearch    `protobuf:"bytes,4,opt,name=elasticsearch,proto3" json:"elasticsearch,omitempty"`
+	Blob          *Data_Blob             `protobuf:"bytes,5,opt,name=blob,proto3" json:"blob,omitempty"`
+	VectorStore   *Data_VectorStore      `protobuf:"bytes,6,opt,name=vector_store,json=vectorStore,proto3" json:"vector_store,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Data) Reset() {
 	*x = Data{}
-	mi := &file_conf_proto_msgTypes[3]
+	mi := &file_conf_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -227,10 +748,201 @@ func (x *Data) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Data) ProtoMessage() {}
+func (*Data) ProtoMessage() {}
+
+func (x *Data) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data.ProtoReflect.Descriptor instead.
+func (*Data) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Data) GetDatabase() *Data_Database {
+	if x != nil {
+		return x.Database
+	}
+	return nil
+}
+
+func (x *Data) GetRedis() *Data_Redis {
+	if x != nil {
+		return x.Redis
+	}
+	return nil
+}
+
+func (x *Data) GetChDatabase() *Data_Database {
+	if x != nil {
+		return x.ChDatabase
+	}
+	return nil
+}
+
+func (x *Data) GetElasticsearch() *Data_Elasticsearch {
+	if x != nil {
+		return x.Elasticsearch
+	}
+	return nil
+}
+
+func (x *Data) GetBlob() *Data_Blob {
+	if x != nil {
+		return x.Blob
+	}
+	return nil
+}
+
+func (x *Data) GetVectorStore() *Data_VectorStore {
+	if x != nil {
+		return x.VectorStore
+	}
+	return nil
+}
+
+// 自定义的正则检测规则
+type Redact_Rule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 敏感信息的类别，记录在检测报告中
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// 正则表达式
+	Pattern       string `protobuf:"bytes,2,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Redact_Rule) Reset() {
+	*x = Redact_Rule{}
+	mi := &file_conf_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Redact_Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Redact_Rule) ProtoMessage() {}
+
+func (x *Redact_Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Redact_Rule.ProtoReflect.Descriptor instead.
+func (*Redact_Rule) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *Redact_Rule) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Redact_Rule) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+// 表格(.xlsx、.csv)解析配置
+type Parser_Spreadsheet struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 表头所在的行号，从1开始，0表示自动识别，-1表示没有表头
+	HeaderRow int32 `protobuf:"varint,1,opt,name=header_row,json=headerRow,proto3" json:"header_row,omitempty"`
+	// 每个文档包含的数据行数，默认每行一个文档
+	RowsPerDoc    int32 `protobuf:"varint,2,opt,name=rows_per_doc,json=rowsPerDoc,proto3" json:"rows_per_doc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Parser_Spreadsheet) Reset() {
+	*x = Parser_Spreadsheet{}
+	mi := &file_conf_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Parser_Spreadsheet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Parser_Spreadsheet) ProtoMessage() {}
+
+func (x *Parser_Spreadsheet) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Parser_Spreadsheet.ProtoReflect.Descriptor instead.
+func (*Parser_Spreadsheet) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *Parser_Spreadsheet) GetHeaderRow() int32 {
+	if x != nil {
+		return x.HeaderRow
+	}
+	return 0
+}
+
+func (x *Parser_Spreadsheet) GetRowsPerDoc() int32 {
+	if x != nil {
+		return x.RowsPerDoc
+	}
+	return 0
+}
+
+// PDF解析配置
+type Parser_Pdf struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 去掉在多个页面重复出现的页眉、页脚
+	StripHeaderFooter bool `protobuf:"varint,1,opt,name=strip_header_footer,json=stripHeaderFooter,proto3" json:"strip_header_footer,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Parser_Pdf) Reset() {
+	*x = Parser_Pdf{}
+	mi := &file_conf_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Parser_Pdf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Parser_Pdf) ProtoMessage() {}
 
-func (x *Data) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_proto_msgTypes[3]
+func (x *Parser_Pdf) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -241,37 +953,16 @@ func (x *Data) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Data.ProtoReflect.Descriptor instead.
-func (*Data) Descriptor() ([]byte, []int) {
-	return file_conf_proto_rawDescGZIP(), []int{3}
-}
-
-func (x *Data) GetDatabase() *Data_Database {
-	if x != nil {
-		return x.Database
-	}
-	return nil
-}
-
-func (x *Data) GetRedis() *Data_Redis {
-	if x != nil {
-		return x.Redis
-	}
-	return nil
-}
-
-func (x *Data) GetChDatabase() *Data_Database {
-	if x != nil {
-		return x.ChDatabase
-	}
-	return nil
+// Deprecated: Use Parser_Pdf.ProtoReflect.Descriptor instead.
+func (*Parser_Pdf) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{4, 1}
 }
 
-func (x *Data) GetElasticsearch() *Data_Elasticsearch {
+func (x *Parser_Pdf) GetStripHeaderFooter() bool {
 	if x != nil {
-		return x.Elasticsearch
+		return x.StripHeaderFooter
 	}
-	return nil
+	return false
 }
 
 type Server_HTTP struct {
@@ -285,7 +976,7 @@ type Server_HTTP struct {
 
 func (x *Server_HTTP) Reset() {
 	*x = Server_HTTP{}
-	mi := &file_conf_proto_msgTypes[4]
+	mi := &file_conf_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -297,7 +988,7 @@ func (x *Server_HTTP) String() string {
 func (*Server_HTTP) ProtoMessage() {}
 
 func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_proto_msgTypes[4]
+	mi := &file_conf_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -310,7 +1001,7 @@ func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Server_HTTP.ProtoReflect.Descriptor instead.
 func (*Server_HTTP) Descriptor() ([]byte, []int) {
-	return file_conf_proto_rawDescGZIP(), []int{2, 0}
+	return file_conf_proto_rawDescGZIP(), []int{7, 0}
 }
 
 func (x *Server_HTTP) GetNetwork() string {
@@ -345,7 +1036,7 @@ type Server_GRPC struct {
 
 func (x *Server_GRPC) Reset() {
 	*x = Server_GRPC{}
-	mi := &file_conf_proto_msgTypes[5]
+	mi := &file_conf_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -357,7 +1048,7 @@ func (x *Server_GRPC) String() string {
 func (*Server_GRPC) ProtoMessage() {}
 
 func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_proto_msgTypes[5]
+	mi := &file_conf_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -370,7 +1061,7 @@ func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Server_GRPC.ProtoReflect.Descriptor instead.
 func (*Server_GRPC) Descriptor() ([]byte, []int) {
-	return file_conf_proto_rawDescGZIP(), []int{2, 1}
+	return file_conf_proto_rawDescGZIP(), []int{7, 1}
 }
 
 func (x *Server_GRPC) GetNetwork() string {
@@ -404,7 +1095,7 @@ type Data_Database struct {
 
 func (x *Data_Database) Reset() {
 	*x = Data_Database{}
-	mi := &file_conf_proto_msgTypes[6]
+	mi := &file_conf_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -416,7 +1107,7 @@ func (x *Data_Database) String() string {
 func (*Data_Database) ProtoMessage() {}
 
 func (x *Data_Database) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_proto_msgTypes[6]
+	mi := &file_conf_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -429,7 +1120,7 @@ func (x *Data_Database) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Data_Database.ProtoReflect.Descriptor instead.
 func (*Data_Database) Descriptor() ([]byte, []int) {
-	return file_conf_proto_rawDescGZIP(), []int{3, 0}
+	return file_conf_proto_rawDescGZIP(), []int{8, 0}
 }
 
 func (x *Data_Database) GetDriver() string {
@@ -460,7 +1151,7 @@ type Data_Redis struct {
 
 func (x *Data_Redis) Reset() {
 	*x = Data_Redis{}
-	mi := &file_conf_proto_msgTypes[7]
+	mi := &file_conf_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -472,7 +1163,7 @@ func (x *Data_Redis) String() string {
 func (*Data_Redis) ProtoMessage() {}
 
 func (x *Data_Redis) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_proto_msgTypes[7]
+	mi := &file_conf_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -485,7 +1176,7 @@ func (x *Data_Redis) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Data_Redis.ProtoReflect.Descriptor instead.
 func (*Data_Redis) Descriptor() ([]byte, []int) {
-	return file_conf_proto_rawDescGZIP(), []int{3, 1}
+	return file_conf_proto_rawDescGZIP(), []int{8, 1}
 }
 
 func (x *Data_Redis) GetMode() string {
@@ -535,7 +1226,7 @@ type Data_Elasticsearch struct {
 
 func (x *Data_Elasticsearch) Reset() {
 	*x = Data_Elasticsearch{}
-	mi := &file_conf_proto_msgTypes[8]
+	mi := &file_conf_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -547,7 +1238,7 @@ func (x *Data_Elasticsearch) String() string {
 func (*Data_Elasticsearch) ProtoMessage() {}
 
 func (x *Data_Elasticsearch) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_proto_msgTypes[8]
+	mi := &file_conf_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -560,7 +1251,7 @@ func (x *Data_Elasticsearch) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Data_Elasticsearch.ProtoReflect.Descriptor instead.
 func (*Data_Elasticsearch) Descriptor() ([]byte, []int) {
-	return file_conf_proto_rawDescGZIP(), []int{3, 2}
+	return file_conf_proto_rawDescGZIP(), []int{8, 2}
 }
 
 func (x *Data_Elasticsearch) GetAddress() string {
@@ -591,17 +1282,413 @@ func (x *Data_Elasticsearch) GetPassword() string {
 	return ""
 }
 
+// 上传的源文件存储
+type Data_Blob struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// local,s3
+	Driver        string           `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
+	Local         *Data_Blob_Local `protobuf:"bytes,2,opt,name=local,proto3" json:"local,omitempty"`
+	S3            *Data_Blob_S3    `protobuf:"bytes,3,opt,name=s3,proto3" json:"s3,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Blob) Reset() {
+	*x = Data_Blob{}
+	mi := &file_conf_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Blob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Blob) ProtoMessage() {}
+
+func (x *Data_Blob) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Blob.ProtoReflect.Descriptor instead.
+func (*Data_Blob) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{8, 3}
+}
+
+func (x *Data_Blob) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *Data_Blob) GetLocal() *Data_Blob_Local {
+	if x != nil {
+		return x.Local
+	}
+	return nil
+}
+
+func (x *Data_Blob) GetS3() *Data_Blob_S3 {
+	if x != nil {
+		return x.S3
+	}
+	return nil
+}
+
+// 文档块的向量存储
+type Data_VectorStore struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// es,pgvector，默认es，pgvector把向量保存在database的文档块表上
+	Driver   string                     `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
+	Pgvector *Data_VectorStore_Pgvector `protobuf:"bytes,2,opt,name=pgvector,proto3" json:"pgvector,omitempty"`
+	// 混合检索，向量检索和全文检索的结果按RRF融合，得分是RRF得分
+	Hybrid        bool `protobuf:"varint,3,opt,name=hybrid,proto3" json:"hybrid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_VectorStore) Reset() {
+	*x = Data_VectorStore{}
+	mi := &file_conf_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_VectorStore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_VectorStore) ProtoMessage() {}
+
+func (x *Data_VectorStore) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_VectorStore.ProtoReflect.Descriptor instead.
+func (*Data_VectorStore) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{8, 4}
+}
+
+func (x *Data_VectorStore) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *Data_VectorStore) GetPgvector() *Data_VectorStore_Pgvector {
+	if x != nil {
+		return x.Pgvector
+	}
+	return nil
+}
+
+func (x *Data_VectorStore) GetHybrid() bool {
+	if x != nil {
+		return x.Hybrid
+	}
+	return false
+}
+
+type Data_Blob_Local struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dir           string                 `protobuf:"bytes,1,opt,name=dir,proto3" json:"dir,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Blob_Local) Reset() {
+	*x = Data_Blob_Local{}
+	mi := &file_conf_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Blob_Local) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Blob_Local) ProtoMessage() {}
+
+func (x *Data_Blob_Local) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Blob_Local.ProtoReflect.Descriptor instead.
+func (*Data_Blob_Local) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{8, 3, 0}
+}
+
+func (x *Data_Blob_Local) GetDir() string {
+	if x != nil {
+		return x.Dir
+	}
+	return ""
+}
+
+type Data_Blob_S3 struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Endpoint  string                 `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	AccessKey string                 `protobuf:"bytes,2,opt,name=access_key,json=accessKey,proto3" json:"access_key,omitempty"`
+	SecretKey string                 `protobuf:"bytes,3,opt,name=secret_key,json=secretKey,proto3" json:"secret_key,omitempty"`
+	Bucket    string                 `protobuf:"bytes,4,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Region    string                 `protobuf:"bytes,5,opt,name=region,proto3" json:"region,omitempty"`
+	UseSsl    bool                   `protobuf:"varint,6,opt,name=use_ssl,json=useSsl,proto3" json:"use_ssl,omitempty"`
+	// 使用路径方式访问bucket，minio需要开启
+	PathStyle     bool `protobuf:"varint,7,opt,name=path_style,json=pathStyle,proto3" json:"path_style,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Blob_S3) Reset() {
+	*x = Data_Blob_S3{}
+	mi := &file_conf_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Blob_S3) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Blob_S3) ProtoMessage() {}
+
+func (x *Data_Blob_S3) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Blob_S3.ProtoReflect.Descriptor instead.
+func (*Data_Blob_S3) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{8, 3, 1}
+}
+
+func (x *Data_Blob_S3) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *Data_Blob_S3) GetAccessKey() string {
+	if x != nil {
+		return x.AccessKey
+	}
+	return ""
+}
+
+func (x *Data_Blob_S3) GetSecretKey() string {
+	if x != nil {
+		return x.SecretKey
+	}
+	return ""
+}
+
+func (x *Data_Blob_S3) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *Data_Blob_S3) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Data_Blob_S3) GetUseSsl() bool {
+	if x != nil {
+		return x.UseSsl
+	}
+	return false
+}
+
+func (x *Data_Blob_S3) GetPathStyle() bool {
+	if x != nil {
+		return x.PathStyle
+	}
+	return false
+}
+
+// pgvector的HNSW索引参数，修改后需要删除已有的索引
+type Data_VectorStore_Pgvector struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 每个节点的最大连接数，默认16
+	M int32 `protobuf:"varint,1,opt,name=m,proto3" json:"m,omitempty"`
+	// 构建索引时的候选列表大小，默认64
+	EfConstruction int32 `protobuf:"varint,2,opt,name=ef_construction,json=efConstruction,proto3" json:"ef_construction,omitempty"`
+	// 检索时的候选列表大小，需要不小于topK的4倍，默认使用数据库的设置
+	EfSearch int32 `protobuf:"varint,3,opt,name=ef_search,json=efSearch,proto3" json:"ef_search,omitempty"`
+	// 全文检索使用的文本搜索配置，默认simple，修改后需要删除已有的全文索引
+	TextSearchConfig string `protobuf:"bytes,4,opt,name=text_search_config,json=textSearchConfig,proto3" json:"text_search_config,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Data_VectorStore_Pgvector) Reset() {
+	*x = Data_VectorStore_Pgvector{}
+	mi := &file_conf_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_VectorStore_Pgvector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_VectorStore_Pgvector) ProtoMessage() {}
+
+func (x *Data_VectorStore_Pgvector) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_VectorStore_Pgvector.ProtoReflect.Descriptor instead.
+func (*Data_VectorStore_Pgvector) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{8, 4, 0}
+}
+
+func (x *Data_VectorStore_Pgvector) GetM() int32 {
+	if x != nil {
+		return x.M
+	}
+	return 0
+}
+
+func (x *Data_VectorStore_Pgvector) GetEfConstruction() int32 {
+	if x != nil {
+		return x.EfConstruction
+	}
+	return 0
+}
+
+func (x *Data_VectorStore_Pgvector) GetEfSearch() int32 {
+	if x != nil {
+		return x.EfSearch
+	}
+	return 0
+}
+
+func (x *Data_VectorStore_Pgvector) GetTextSearchConfig() string {
+	if x != nil {
+		return x.TextSearchConfig
+	}
+	return ""
+}
+
 var File_conf_proto protoreflect.FileDescriptor
 
 const file_conf_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
 	"conf.proto\x12\n" +
-	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\"\x86\x01\n" +
+	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\"\xe8\x02\n" +
 	"\tBootstrap\x12*\n" +
 	"\x06server\x18\x01 \x01(\v2\x12.kratos.api.ServerR\x06server\x12$\n" +
 	"\x04data\x18\x02 \x01(\v2\x10.kratos.api.DataR\x04data\x12'\n" +
-	"\x03app\x18\x03 \x01(\v2\x15.kratos.api.AppConfigR\x03app\"V\n" +
+	"\x03app\x18\x03 \x01(\v2\x15.kratos.api.AppConfigR\x03app\x12*\n" +
+	"\x06upload\x18\x04 \x01(\v2\x12.kratos.api.UploadR\x06upload\x12*\n" +
+	"\x06parser\x18\x05 \x01(\v2\x12.kratos.api.ParserR\x06parser\x12'\n" +
+	"\x05chunk\x18\x06 \x01(\v2\x11.kratos.api.ChunkR\x05chunk\x12*\n" +
+	"\x06redact\x18\a \x01(\v2\x12.kratos.api.RedactR\x06redact\x123\n" +
+	"\tembedding\x18\b \x01(\v2\x15.kratos.api.EmbeddingR\tembedding\"\xf6\x02\n" +
+	"\tEmbedding\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12\x19\n" +
+	"\bbase_url\x18\x03 \x01(\tR\abaseUrl\x12\x17\n" +
+	"\aapi_key\x18\x04 \x01(\tR\x06apiKey\x12\x1e\n" +
+	"\n" +
+	"dimensions\x18\x05 \x01(\x05R\n" +
+	"dimensions\x123\n" +
+	"\atimeout\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12\x14\n" +
+	"\x05cache\x18\a \x01(\tR\x05cache\x126\n" +
+	"\tcache_ttl\x18\b \x01(\v2\x19.google.protobuf.DurationR\bcacheTtl\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\t \x01(\x05R\tbatchSize\x12 \n" +
+	"\vconcurrency\x18\n" +
+	" \x01(\x05R\vconcurrency\x12\x1f\n" +
+	"\vmax_retries\x18\v \x01(\x05R\n" +
+	"maxRetries\"\xcd\x01\n" +
+	"\x06Redact\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x1c\n" +
+	"\tdetectors\x18\x03 \x03(\tR\tdetectors\x12-\n" +
+	"\x05rules\x18\x04 \x03(\v2\x17.kratos.api.Redact.RuleR\x05rules\x12\x12\n" +
+	"\x04salt\x18\x05 \x01(\tR\x04salt\x1a4\n" +
+	"\x04Rule\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x18\n" +
+	"\apattern\x18\x02 \x01(\tR\apattern\"\xc5\x03\n" +
+	"\x05Chunk\x12\x1c\n" +
+	"\ttokenizer\x18\x01 \x01(\tR\ttokenizer\x12\x1a\n" +
+	"\bencoding\x18\x02 \x01(\tR\bencoding\x12\x1d\n" +
+	"\n" +
+	"chunk_size\x18\x03 \x01(\x05R\tchunkSize\x12!\n" +
+	"\foverlap_size\x18\x04 \x01(\x05R\voverlapSize\x12\x1d\n" +
+	"\n" +
+	"merge_size\x18\x05 \x01(\x05R\tmergeSize\x12\x1a\n" +
+	"\bstrategy\x18\x06 \x01(\tR\bstrategy\x123\n" +
+	"\x15breakpoint_percentile\x18\a \x01(\x01R\x14breakpointPercentile\x12$\n" +
+	"\x0emin_chunk_size\x18\b \x01(\x05R\fminChunkSize\x12!\n" +
+	"\fparent_child\x18\t \x01(\bR\vparentChild\x12(\n" +
+	"\x10child_chunk_size\x18\n" +
+	" \x01(\x05R\x0echildChunkSize\x12.\n" +
+	"\x13questions_per_chunk\x18\v \x01(\x05R\x11questionsPerChunk\x12-\n" +
+	"\x12enrich_concurrency\x18\f \x01(\x05R\x11enrichConcurrency\"\xfb\x01\n" +
+	"\x06Parser\x12@\n" +
+	"\vspreadsheet\x18\x01 \x01(\v2\x1e.kratos.api.Parser.SpreadsheetR\vspreadsheet\x12(\n" +
+	"\x03pdf\x18\x02 \x01(\v2\x16.kratos.api.Parser.PdfR\x03pdf\x1aN\n" +
+	"\vSpreadsheet\x12\x1d\n" +
+	"\n" +
+	"header_row\x18\x01 \x01(\x05R\theaderRow\x12 \n" +
+	"\frows_per_doc\x18\x02 \x01(\x05R\n" +
+	"rowsPerDoc\x1a5\n" +
+	"\x03Pdf\x12.\n" +
+	"\x13strip_header_footer\x18\x01 \x01(\bR\x11stripHeaderFooter\"Z\n" +
+	"\x06Upload\x12\x10\n" +
+	"\x03dir\x18\x01 \x01(\tR\x03dir\x12\x19\n" +
+	"\bmax_size\x18\x02 \x01(\x03R\amaxSize\x12#\n" +
+	"\rallowed_types\x18\x03 \x03(\tR\fallowedTypes\"V\n" +
 	"\tAppConfig\x12\x10\n" +
 	"\x03env\x18\x01 \x01(\tR\x03env\x12#\n" +
 	"\rlocalize_path\x18\x02 \x01(\tR\flocalizePath\x12\x12\n" +
@@ -618,13 +1705,16 @@ const file_conf_proto_rawDesc = "" +
 	"\x04GRPC\x12\x18\n" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
 	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
-	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\xfa\x04\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\xda\n" +
+	"\n" +
 	"\x04Data\x125\n" +
 	"\bdatabase\x18\x01 \x01(\v2\x19.kratos.api.Data.DatabaseR\bdatabase\x12,\n" +
 	"\x05redis\x18\x02 \x01(\v2\x16.kratos.api.Data.RedisR\x05redis\x12:\n" +
 	"\vch_database\x18\x03 \x01(\v2\x19.kratos.api.Data.DatabaseR\n" +
 	"chDatabase\x12D\n" +
-	"\relasticsearch\x18\x04 \x01(\v2\x1e.kratos.api.Data.ElasticsearchR\relasticsearch\x1a:\n" +
+	"\relasticsearch\x18\x04 \x01(\v2\x1e.kratos.api.Data.ElasticsearchR\relasticsearch\x12)\n" +
+	"\x04blob\x18\x05 \x01(\v2\x15.kratos.api.Data.BlobR\x04blob\x12?\n" +
+	"\fvector_store\x18\x06 \x01(\v2\x1c.kratos.api.Data.VectorStoreR\vvectorStore\x1a:\n" +
 	"\bDatabase\x12\x16\n" +
 	"\x06driver\x18\x01 \x01(\tR\x06driver\x12\x16\n" +
 	"\x06source\x18\x02 \x01(\tR\x06source\x1a\xcb\x01\n" +
@@ -639,7 +1729,33 @@ const file_conf_proto_rawDesc = "" +
 	"\n" +
 	"index_name\x18\x02 \x01(\tR\tindexName\x12\x1a\n" +
 	"\busername\x18\x03 \x01(\tR\busername\x12\x1a\n" +
-	"\bpassword\x18\x04 \x01(\tR\bpasswordB*Z(vivalink.com/app/internal/task/conf;confb\x06proto3"
+	"\bpassword\x18\x04 \x01(\tR\bpassword\x1a\xdf\x02\n" +
+	"\x04Blob\x12\x16\n" +
+	"\x06driver\x18\x01 \x01(\tR\x06driver\x121\n" +
+	"\x05local\x18\x02 \x01(\v2\x1b.kratos.api.Data.Blob.LocalR\x05local\x12(\n" +
+	"\x02s3\x18\x03 \x01(\v2\x18.kratos.api.Data.Blob.S3R\x02s3\x1a\x19\n" +
+	"\x05Local\x12\x10\n" +
+	"\x03dir\x18\x01 \x01(\tR\x03dir\x1a\xc6\x01\n" +
+	"\x02S3\x12\x1a\n" +
+	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x12\x1d\n" +
+	"\n" +
+	"access_key\x18\x02 \x01(\tR\taccessKey\x12\x1d\n" +
+	"\n" +
+	"secret_key\x18\x03 \x01(\tR\tsecretKey\x12\x16\n" +
+	"\x06bucket\x18\x04 \x01(\tR\x06bucket\x12\x16\n" +
+	"\x06region\x18\x05 \x01(\tR\x06region\x12\x17\n" +
+	"\ause_ssl\x18\x06 \x01(\bR\x06useSsl\x12\x1d\n" +
+	"\n" +
+	"path_style\x18\a \x01(\bR\tpathStyle\x1a\x8f\x02\n" +
+	"\vVectorStore\x12\x16\n" +
+	"\x06driver\x18\x01 \x01(\tR\x06driver\x12A\n" +
+	"\bpgvector\x18\x02 \x01(\v2%.kratos.api.Data.VectorStore.PgvectorR\bpgvector\x12\x16\n" +
+	"\x06hybrid\x18\x03 \x01(\bR\x06hybrid\x1a\x8c\x01\n" +
+	"\bPgvector\x12\f\n" +
+	"\x01m\x18\x01 \x01(\x05R\x01m\x12'\n" +
+	"\x0fef_construction\x18\x02 \x01(\x05R\x0eefConstruction\x12\x1b\n" +
+	"\tef_search\x18\x03 \x01(\x05R\befSearch\x12,\n" +
+	"\x12text_search_config\x18\x04 \x01(\tR\x10textSearchConfigB*Z(vivalink.com/app/internal/task/conf;confb\x06proto3"
 
 var (
 	file_conf_proto_rawDescOnce sync.Once
@@ -653,39 +1769,67 @@ func file_conf_proto_rawDescGZIP() []byte {
 	return file_conf_proto_rawDescData
 }
 
-var file_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_conf_proto_goTypes = []any{
-	(*Bootstrap)(nil),           // 0: kratos.api.Bootstrap
-	(*AppConfig)(nil),           // 1: kratos.api.AppConfig
-	(*Server)(nil),              // 2: kratos.api.Server
-	(*Data)(nil),                // 3: kratos.api.Data
-	(*Server_HTTP)(nil),         // 4: kratos.api.Server.HTTP
-	(*Server_GRPC)(nil),         // 5: kratos.api.Server.GRPC
-	(*Data_Database)(nil),       // 6: kratos.api.Data.Database
-	(*Data_Redis)(nil),          // 7: kratos.api.Data.Redis
-	(*Data_Elasticsearch)(nil),  // 8: kratos.api.Data.Elasticsearch
-	(*durationpb.Duration)(nil), // 9: google.protobuf.Duration
+	(*Bootstrap)(nil),                 // 0: kratos.api.Bootstrap
+	(*Embedding)(nil),                 // 1: kratos.api.Embedding
+	(*Redact)(nil),                    // 2: kratos.api.Redact
+	(*Chunk)(nil),                     // 3: kratos.api.Chunk
+	(*Parser)(nil),                    // 4: kratos.api.Parser
+	(*Upload)(nil),                    // 5: kratos.api.Upload
+	(*AppConfig)(nil),                 // 6: kratos.api.AppConfig
+	(*Server)(nil),                    // 7: kratos.api.Server
+	(*Data)(nil),                      // 8: kratos.api.Data
+	(*Redact_Rule)(nil),               // 9: kratos.api.Redact.Rule
+	(*Parser_Spreadsheet)(nil),        // 10: kratos.api.Parser.Spreadsheet
+	(*Parser_Pdf)(nil),                // 11: kratos.api.Parser.Pdf
+	(*Server_HTTP)(nil),               // 12: kratos.api.Server.HTTP
+	(*Server_GRPC)(nil),               // 13: kratos.api.Server.GRPC
+	(*Data_Database)(nil),             // 14: kratos.api.Data.Database
+	(*Data_Redis)(nil),                // 15: kratos.api.Data.Redis
+	(*Data_Elasticsearch)(nil),        // 16: kratos.api.Data.Elasticsearch
+	(*Data_Blob)(nil),                 // 17: kratos.api.Data.Blob
+	(*Data_VectorStore)(nil),          // 18: kratos.api.Data.VectorStore
+	(*Data_Blob_Local)(nil),           // 19: kratos.api.Data.Blob.Local
+	(*Data_Blob_S3)(nil),              // 20: kratos.api.Data.Blob.S3
+	(*Data_VectorStore_Pgvector)(nil), // 21: kratos.api.Data.VectorStore.Pgvector
+	(*durationpb.Duration)(nil),       // 22: google.protobuf.Duration
 }
 var file_conf_proto_depIdxs = []int32{
-	2,  // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
-	3,  // 1: kratos.api.Bootstrap.data:type_name -> kratos.api.Data
-	1,  // 2: kratos.api.Bootstrap.app:type_name -> kratos.api.AppConfig
-	4,  // 3: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
-	5,  // 4: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
-	4,  // 5: kratos.api.Server.inner_http:type_name -> kratos.api.Server.HTTP
-	6,  // 6: kratos.api.Data.database:type_name -> kratos.api.Data.Database
-	7,  // 7: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
-	6,  // 8: kratos.api.Data.ch_database:type_name -> kratos.api.Data.Database
-	8,  // 9: kratos.api.Data.elasticsearch:type_name -> kratos.api.Data.Elasticsearch
-	9,  // 10: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
-	9,  // 11: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
-	9,  // 12: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
-	9,  // 13: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
-	14, // [14:14] is the sub-list for method output_type
-	14, // [14:14] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	7,  // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
+	8,  // 1: kratos.api.Bootstrap.data:type_name -> kratos.api.Data
+	6,  // 2: kratos.api.Bootstrap.app:type_name -> kratos.api.AppConfig
+	5,  // 3: kratos.api.Bootstrap.upload:type_name -> kratos.api.Upload
+	4,  // 4: kratos.api.Bootstrap.parser:type_name -> kratos.api.Parser
+	3,  // 5: kratos.api.Bootstrap.chunk:type_name -> kratos.api.Chunk
+	2,  // 6: kratos.api.Bootstrap.redact:type_name -> kratos.api.Redact
+	1,  // 7: kratos.api.Bootstrap.embedding:type_name -> kratos.api.Embedding
+	22, // 8: kratos.api.Embedding.timeout:type_name -> google.protobuf.Duration
+	22, // 9: kratos.api.Embedding.cache_ttl:type_name -> google.protobuf.Duration
+	9,  // 10: kratos.api.Redact.rules:type_name -> kratos.api.Redact.Rule
+	10, // 11: kratos.api.Parser.spreadsheet:type_name -> kratos.api.Parser.Spreadsheet
+	11, // 12: kratos.api.Parser.pdf:type_name -> kratos.api.Parser.Pdf
+	12, // 13: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
+	13, // 14: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
+	12, // 15: kratos.api.Server.inner_http:type_name -> kratos.api.Server.HTTP
+	14, // 16: kratos.api.Data.database:type_name -> kratos.api.Data.Database
+	15, // 17: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
+	14, // 18: kratos.api.Data.ch_database:type_name -> kratos.api.Data.Database
+	16, // 19: kratos.api.Data.elasticsearch:type_name -> kratos.api.Data.Elasticsearch
+	17, // 20: kratos.api.Data.blob:type_name -> kratos.api.Data.Blob
+	18, // 21: kratos.api.Data.vector_store:type_name -> kratos.api.Data.VectorStore
+	22, // 22: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
+	22, // 23: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
+	22, // 24: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
+	22, // 25: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
+	19, // 26: kratos.api.Data.Blob.local:type_name -> kratos.api.Data.Blob.Local
+	20, // 27: kratos.api.Data.Blob.s3:type_name -> kratos.api.Data.Blob.S3
+	21, // 28: kratos.api.Data.VectorStore.pgvector:type_name -> kratos.api.Data.VectorStore.Pgvector
+	29, // [29:29] is the sub-list for method output_type
+	29, // [29:29] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_conf_proto_init() }
@@ -699,7 +1843,7 @@ func file_conf_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_conf_proto_rawDesc), len(file_conf_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -18,7 +18,7 @@ func PromptTemplate() prompt.ChatTemplate {
 			"5. 保持回答专业、简洁、准确\n"+
 			"6. 必要时可引用参考内容中的具体数据或原文\n\n"+
 			"当前提供的参考内容：\n"+
-			//"{docs}\n\n"+
+			"{docs}\n\n"+
 			""),
 		// 插入需要的对话历史（新对话的话这里不填）
 		// optional=false 表示必需的消息列表，在模版输入中找不到对应变量会报错，这里不需要报错
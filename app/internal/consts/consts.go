@@ -1,8 +1,9 @@
 package consts
 
 const (
-	StatusPending  int32 = 0
-	StatusIndexing int32 = 1
-	StatusActive   int32 = 2
-	StatusFailed   int32 = 3
+	StatusPending    int32 = 0
+	StatusIndexing   int32 = 1
+	StatusActive     int32 = 2
+	StatusFailed     int32 = 3
+	StatusSuperseded int32 = 4 // 已被新版本替代
 )
@@ -3,8 +3,10 @@ package data
 import (
 	"ragx/app/internal/biz"
 	"ragx/app/internal/biz/entity"
+	"ragx/app/internal/biz/query"
 	"ragx/app/internal/conf"
 	"ragx/app/internal/data/repo"
+	"ragx/app/pkg/blob"
 	logging "ragx/app/pkg/logger"
 
 	redisHelper "ragx/app/pkg/cache/redis"
@@ -12,6 +14,7 @@ import (
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -19,6 +22,8 @@ import (
 // ProviderSet is data providers.
 var ProviderSet = wire.NewSet(
 	NewData,
+	NewBlobStore,
+	NewEmbeddingCache,
 	repo.NewKnowledgeBaseRepo,
 	repo.NewKnowledgeDocumentRepo,
 	repo.NewKnowledgeChunkRepo,
@@ -55,5 +60,54 @@ func NewData(c *conf.Data, logger log.Logger) (biz.Data, func(), error) {
 	if err := db.AutoMigrate(&entity.KnowledgeBase{}, &entity.KnowledgeDocument{}, &entity.KnowledgeChunk{}); err != nil {
 		logHelper.Fatalf("Got error when auto migrate database, the error is '%+v'", gerror.Wrap(err, ""))
 	}
-	return &data{db: db}, cleanup, nil
+	// biz中的查询条件使用query包的全局变量
+	query.SetDefault(db)
+	d := &data{db: db}
+	if c.Redis != nil && len(c.Redis.Addrs) > 0 {
+		rdb := newRedis(c.Redis)
+		d.rdb = redisHelper.NewClient(rdb)
+		cleanup = func() {
+			logHelper.Info("closing the data resources")
+			if err := rdb.Close(); err != nil {
+				logHelper.Errorf("close redis err: %+v", gerror.Wrap(err, ""))
+			}
+		}
+	}
+	return d, cleanup, nil
+}
+
+// 创建redis客户端，mode为cluster时使用集群模式
+func newRedis(c *conf.Data_Redis) redis.UniversalClient {
+	return redis.NewUniversalClient(&redis.UniversalOptions{
+		Addrs:         c.Addrs,
+		Password:      c.Password,
+		ReadTimeout:   c.ReadTimeout.AsDuration(),
+		WriteTimeout:  c.WriteTimeout.AsDuration(),
+		IsClusterMode: c.Mode == "cluster",
+	})
+}
+
+// NewBlobStore 根据配置创建上传源文件的存储，默认使用本地目录
+func NewBlobStore(c *conf.Data) (blob.BlobStore, error) {
+	b := c.GetBlob()
+	switch b.GetDriver() {
+	case "", "local":
+		dir := b.GetLocal().GetDir()
+		if dir == "" {
+			dir = "./blobs"
+		}
+		return blob.NewLocalStore(dir)
+	case "s3":
+		s3 := b.GetS3()
+		return blob.NewS3Store(&blob.S3Config{
+			Endpoint:  s3.Endpoint,
+			AccessKey: s3.AccessKey,
+			SecretKey: s3.SecretKey,
+			Bucket:    s3.Bucket,
+			Region:    s3.Region,
+			UseSSL:    s3.UseSsl,
+			PathStyle: s3.PathStyle,
+		})
+	}
+	return nil, gerror.Newf("unsupported blob driver: %s", b.GetDriver())
 }
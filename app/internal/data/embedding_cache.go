@@ -0,0 +1,121 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"ragx/app/internal/biz"
+	"ragx/app/internal/conf"
+	"ragx/app/pkg/embedder"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// redis中向量缓存键的前缀
+const embeddingCachePrefix = "ragx:embedding:"
+
+// NewEmbeddingCache 根据配置创建向量缓存：memory、redis、postgres，没有配置时返回nil，不缓存
+func NewEmbeddingCache(c *conf.Bootstrap, d biz.Data) (embedder.Cache, error) {
+	e := c.GetEmbedding()
+	switch e.GetCache() {
+	case "":
+		return nil, nil
+	case "memory":
+		return embedder.NewMemoryCache(), nil
+	case "redis":
+		if d.Rdb() == nil {
+			return nil, gerror.New("embedding cache redis requires data.redis")
+		}
+		return &redisEmbeddingCache{d: d, ttl: e.GetCacheTtl().AsDuration()}, nil
+	case "postgres":
+		if err := d.DB().AutoMigrate(&embeddingCacheEntry{}); err != nil {
+			return nil, gerror.Wrap(err, "")
+		}
+		return &pgEmbeddingCache{db: d.DB()}, nil
+	}
+	return nil, gerror.Newf("unsupported embedding cache: %s", e.GetCache())
+}
+
+// 使用redis缓存向量，值为编码后的向量
+type redisEmbeddingCache struct {
+	d   biz.Data
+	ttl time.Duration
+}
+
+func (c *redisEmbeddingCache) Get(ctx context.Context, keys []string) (map[string][]float64, error) {
+	redisKeys := make([]string, len(keys))
+	for i, k := range keys {
+		redisKeys[i] = embeddingCachePrefix + k
+	}
+	vals, err := c.d.Rdb().PipelineGet(ctx, redisKeys)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string][]float64, len(keys))
+	for i, val := range vals {
+		if val == "" {
+			continue
+		}
+		v, err := embedder.DecodeVector([]byte(val))
+		if err != nil {
+			continue
+		}
+		res[keys[i]] = v
+	}
+	return res, nil
+}
+
+func (c *redisEmbeddingCache) Set(ctx context.Context, vectors map[string][]float64) error {
+	values := make(map[string]string, len(vectors))
+	for k, v := range vectors {
+		values[embeddingCachePrefix+k] = string(embedder.EncodeVector(v))
+	}
+	return c.d.Rdb().PipelineSetEx(ctx, values, c.ttl)
+}
+
+// 向量缓存表，向量编码后保存
+type embeddingCacheEntry struct {
+	Key       string    `gorm:"column:key;primaryKey"`
+	Vector    []byte    `gorm:"column:vector;not null"`
+	CreatedAt time.Time `gorm:"column:created_at;not null"`
+}
+
+func (*embeddingCacheEntry) TableName() string {
+	return "embedding_cache"
+}
+
+// 使用postgres缓存向量，不会过期
+type pgEmbeddingCache struct {
+	db *gorm.DB
+}
+
+func (c *pgEmbeddingCache) Get(ctx context.Context, keys []string) (map[string][]float64, error) {
+	var entries []*embeddingCacheEntry
+	if err := c.db.WithContext(ctx).Where("key IN ?", keys).Find(&entries).Error; err != nil {
+		return nil, gerror.Wrap(err, "")
+	}
+	res := make(map[string][]float64, len(entries))
+	for _, e := range entries {
+		v, err := embedder.DecodeVector(e.Vector)
+		if err != nil {
+			continue
+		}
+		res[e.Key] = v
+	}
+	return res, nil
+}
+
+func (c *pgEmbeddingCache) Set(ctx context.Context, vectors map[string][]float64) error {
+	if len(vectors) == 0 {
+		return nil
+	}
+	entries := make([]*embeddingCacheEntry, 0, len(vectors))
+	now := time.Now()
+	for k, v := range vectors {
+		entries = append(entries, &embeddingCacheEntry{Key: k, Vector: embedder.EncodeVector(v), CreatedAt: now})
+	}
+	err := c.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&entries).Error
+	return gerror.Wrap(err, "")
+}
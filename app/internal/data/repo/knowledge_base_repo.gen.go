@@ -58,7 +58,7 @@ func (d *KnowledgeBaseRepo) Save(ctx context.Context, obj *entity.KnowledgeBase,
 		qu = tx[0]
 	}
 	q := qu.KnowledgeBase
-	columns := []field.Expr{q.Name, q.Description, q.Category, q.Status, q.CreateTime, q.UpdateTime}
+	columns := []field.Expr{q.Name, q.Description, q.Category, q.Status, q.CreateTime, q.UpdateTime, q.Enrich, q.EnrichEmbed}
 	res, err := q.WithContext(ctx).Where(q.ID.Eq(obj.ID)).Select(columns...).UpdateColumns(obj)
 	if err != nil {
 		return 0, gerror.Wrap(err, "")
@@ -58,7 +58,7 @@ func (d *KnowledgeChunkRepo) Save(ctx context.Context, obj *entity.KnowledgeChun
 		qu = tx[0]
 	}
 	q := qu.KnowledgeChunk
-	columns := []field.Expr{q.KnowledgeDocID, q.ChunkID, q.Content, q.Ext, q.Status, q.CreatedAt, q.UpdatedAt}
+	columns := []field.Expr{q.KnowledgeDocID, q.ChunkID, q.Content, q.Ext, q.Status, q.CreatedAt, q.UpdatedAt, q.KnowledgeBaseName, q.ContentHash, q.RecordID, q.ParentID, q.IsParent}
 	res, err := q.WithContext(ctx).Where(q.ID.Eq(obj.ID)).Select(columns...).UpdateColumns(obj)
 	if err != nil {
 		return 0, gerror.Wrap(err, "")
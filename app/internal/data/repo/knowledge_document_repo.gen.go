@@ -58,7 +58,7 @@ func (d *KnowledgeDocumentRepo) Save(ctx context.Context, obj *entity.KnowledgeD
 		qu = tx[0]
 	}
 	q := qu.KnowledgeDocument
-	columns := []field.Expr{q.KnowledgeBaseName, q.FileName, q.Status, q.CreatedAt, q.UpdatedAt}
+	columns := []field.Expr{q.KnowledgeBaseName, q.FileName, q.Status, q.CreatedAt, q.UpdatedAt, q.FileHash, q.Version, q.Path, q.URI, q.SourceURL, q.RefreshSchedule, q.ETag, q.LastModified, q.NextRefreshAt, q.RespectRobots, q.Selector, q.FieldMapping, q.ChunkStrategy, q.EnrichTokens, q.PiiReport}
 	res, err := q.WithContext(ctx).Where(q.ID.Eq(obj.ID)).Select(columns...).UpdateColumns(obj)
 	if err != nil {
 		return 0, gerror.Wrap(err, "")
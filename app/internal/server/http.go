@@ -1,6 +1,7 @@
 package server
 
 import (
+	"expvar"
 	pb "ragx/api/gen"
 	"ragx/app/internal/conf"
 	"ragx/app/internal/service"
@@ -19,6 +20,7 @@ import (
 func NewHTTPServer(c *conf.Server, logger log.Logger,
 	streamService *service.StreamService,
 	kbService *service.KnowledgeBaseService,
+	docService *service.KnowledgeDocumentService,
 	indexerService *service.IndexerService) *http.Server {
 	var opts = []http.ServerOption{
 		http.Middleware(
@@ -44,12 +46,18 @@ func NewHTTPServer(c *conf.Server, logger log.Logger,
 	fs := staticHttp.FileServer(staticHttp.Dir(staticDir))
 	srv.HandlePrefix("/assets", staticHttp.StripPrefix("/assets", fs))
 
+	// 运行指标，包括嵌入的累计统计
+	srv.Handle("/debug/vars", expvar.Handler())
+
 	// 如果需要默认首页，可以添加
 	srv.HandleFunc("/", func(w staticHttp.ResponseWriter, r *staticHttp.Request) {
 		staticHttp.ServeFile(w, r, "../../frontend/dist/index.html")
 	})
 	service.RegisterStreamServiceHTTPServer(srv, streamService)
 	service.RegisterIndexerServiceHTTPServer(srv, indexerService)
+	pb.RegisterIndexerServiceHTTPServer(srv, indexerService)
 	pb.RegisterKnowledgeBaseServiceHTTPServer(srv, kbService)
+	pb.RegisterKnowledgeDocumentServiceHTTPServer(srv, docService)
+	service.RegisterKnowledgeDocumentServiceDownloadHTTPServer(srv, docService)
 	return srv
 }
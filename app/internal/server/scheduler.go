@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"ragx/app/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// 检查到期文档的间隔
+const refreshInterval = time.Minute
+
+// SchedulerServer 后台定时任务，定时重新抓取到期的网页文档，实现了kratos的transport.Server接口
+type SchedulerServer struct {
+	refreshUc *biz.KnowledgeRefreshUsecase
+	log       *log.Helper
+	stop      chan struct{}
+}
+
+func NewSchedulerServer(refreshUc *biz.KnowledgeRefreshUsecase, logger log.Logger) *SchedulerServer {
+	return &SchedulerServer{refreshUc: refreshUc, log: log.NewHelper(logger), stop: make(chan struct{})}
+}
+
+// Start 阻塞运行，直到调用Stop
+func (s *SchedulerServer) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		// 停止时取消正在执行的刷新
+		select {
+		case <-s.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	s.refreshUc.CheckLock(ctx)
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if n, err := s.refreshUc.RunDue(ctx); err != nil {
+				s.log.Errorf("SchedulerServer.Start RunDue err: %+v", err)
+			} else if n > 0 {
+				s.log.Infof("refreshed %d documents", n)
+			}
+		}
+	}
+}
+
+func (s *SchedulerServer) Stop(ctx context.Context) error {
+	close(s.stop)
+	return nil
+}
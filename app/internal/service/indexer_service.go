@@ -2,10 +2,14 @@ package service
 
 import (
 	"context"
-	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"ragx/app/internal/biz"
+	"ragx/app/internal/conf"
+	"ragx/app/pkg/archive"
+	"ragx/app/pkg/blob"
+	"ragx/app/pkg/utils"
 
 	pb "ragx/api/gen"
 
@@ -15,6 +19,8 @@ import (
 type IndexerService struct {
 	pb.UnimplementedIndexerServiceServer
 	knowledgeDocumentUc *biz.KnowledgeDocumentUsecase
+	upload              *conf.Upload
+	blobStore           blob.BlobStore
 }
 
 func RegisterIndexerServiceHTTPServer(s *http.Server, srv *IndexerService) {
@@ -22,61 +28,106 @@ func RegisterIndexerServiceHTTPServer(s *http.Server, srv *IndexerService) {
 	r.POST("/api/v1/indexer", srv.UploadIndexer())
 }
 
-func NewIndexerServiceService(knowledgeDocumentUc *biz.KnowledgeDocumentUsecase) *IndexerService {
-	return &IndexerService{knowledgeDocumentUc: knowledgeDocumentUc}
+func NewIndexerServiceService(knowledgeDocumentUc *biz.KnowledgeDocumentUsecase, bc *conf.Bootstrap, blobStore blob.BlobStore) *IndexerService {
+	return &IndexerService{knowledgeDocumentUc: knowledgeDocumentUc, upload: newUploadConfig(bc.Upload), blobStore: blobStore}
 }
 
+// 上传文件索引，支持一次上传多个文件，zip、tar.gz压缩包会在服务端解压，压缩包内的每个文件单独建立索引
 func (s *IndexerService) UploadIndexer() func(ctx http.Context) error {
 	return func(ctx http.Context) error {
 		http.SetOperation(ctx, pb.IndexerService_UploadIndexer_FullMethodName)
-		file, header, err := ctx.Request().FormFile("file")
+		// 边接收边校验文件大小和类型，文件以uuid命名保存到blob存储的知识库目录下
+		form, files, err := s.receive(ctx.Request())
 		if err != nil {
 			return err
 		}
-		defer file.Close()
-		//contentType := header.Header.Get("Content-Type")
-		//if contentType != "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet" {
-		//	return err
-		//}
-		// 文件大小限制, 10MB
-		//if header.Size > 10*1024*1024 {
-		//	return err
-		//}
 
-		// 指定保存目录 - 可以根据需要修改这个路径
-		saveDir := "./uploads"
-
-		// 确保目录存在
-		if err := os.MkdirAll(saveDir, 0755); err != nil {
-			return err
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return s.knowledgeDocumentUc.Create(ctx, req.(*pb.UploadIndexerRequest))
+		})
+		index := func(uri, path, fileName string) (*pb.UploadIndexerReply, error) {
+			out, err := h(ctx, &pb.UploadIndexerRequest{
+				KnowledgeName: form.KnowledgeName, Uri: uri, Path: path, FileName: fileName,
+				Selector: form.Selector, FieldMapping: form.FieldMapping, ChunkStrategy: form.ChunkStrategy,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return out.(*pb.UploadIndexerReply), nil
 		}
 
-		// 构建完整的文件保存路径
-		savePath := filepath.Join(saveDir, header.Filename)
-
-		// 创建目标文件
-		dst, err := os.Create(savePath)
-		if err != nil {
-			return err
+		// 只上传了一个普通文件，保持原有的返回格式
+		if len(files) == 1 && !archive.IsArchive(files[0].Name) {
+			reply, err := index(blob.URI(files[0].Key), "", files[0].Name)
+			if err != nil {
+				return err
+			}
+			reply.Files = []*pb.UploadIndexerFile{fileResult(files[0].Name, "", reply, nil)}
+			return ctx.Result(200, reply)
 		}
-		defer dst.Close()
 
-		// 将上传的文件内容复制到目标文件
-		if _, err := io.Copy(dst, file); err != nil {
-			return err
+		reply := &pb.UploadIndexerReply{}
+		for _, f := range files {
+			if !archive.IsArchive(f.Name) {
+				r, err := index(blob.URI(f.Key), "", f.Name)
+				reply.Files = append(reply.Files, fileResult(f.Name, "", r, err))
+				continue
+			}
+			reply.Files = append(reply.Files, s.indexArchive(ctx, form.KnowledgeName, f, index)...)
 		}
+		return ctx.Result(200, reply)
+	}
+}
 
-		var req pb.UploadIndexerRequest
-		req.KnowledgeName = ctx.Form().Get("knowledge_name")
-		req.Uri = savePath
-		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
-			return s.knowledgeDocumentUc.Create(ctx, req.(*pb.UploadIndexerRequest))
-		})
-		out, err := h(ctx, &req)
-		if err != nil {
-			return err
+// 抓取网页并建立索引
+func (s *IndexerService) IngestUrls(ctx context.Context, req *pb.IngestUrlsRequest) (*pb.IngestUrlsReply, error) {
+	// 知识库名称会作为blob存储的目录
+	if !validKnowledgeName(req.KnowledgeName) {
+		return nil, pb.ErrorUploadKnowledgeNameInvalid("知识库名称不合法: %q", req.KnowledgeName)
+	}
+	return s.knowledgeDocumentUc.IngestURLs(ctx, req)
+}
+
+// 单个文件的处理结果
+func fileResult(fileName, path string, reply *pb.UploadIndexerReply, err error) *pb.UploadIndexerFile {
+	res := &pb.UploadIndexerFile{FileName: fileName, Path: path}
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.DocumentId = reply.DocumentId
+	res.DocIds = reply.DocIds
+	res.Existed = reply.Existed
+	res.Version = reply.Version
+	return res
+}
+
+// 解压压缩包，将每个文件保存到blob存储后建立索引，本地的临时文件处理完后删除
+func (s *IndexerService) indexArchive(ctx context.Context, knowledgeName string, f *uploadFile,
+	index func(uri, path, fileName string) (*pb.UploadIndexerReply, error)) []*pb.UploadIndexerFile {
+	defer os.Remove(f.LocalPath)
+	// 解压到单独的目录，避免不同压缩包内的同名文件互相覆盖
+	id := utils.UniqueID()
+	dir := filepath.Join(filepath.Dir(f.LocalPath), id)
+	defer os.RemoveAll(dir)
+	entries, err := archive.Extract(f.LocalPath, dir, archive.DefaultLimit)
+	if err != nil {
+		return []*pb.UploadIndexerFile{fileResult(f.Name, "", nil, err)}
+	}
+	res := make([]*pb.UploadIndexerFile, 0, len(entries))
+	for _, e := range entries {
+		// 压缩包内的文件同样只处理允许的类型
+		if err := s.checkFileType(e.LocalPath, e.Name); err != nil {
+			res = append(res, fileResult(e.Name, e.Dir, nil, err))
+			continue
 		}
-		reply := out.(*pb.UploadIndexerReply)
-		return ctx.Result(200, reply)
+		key := path.Join(knowledgeName, id, e.Dir, e.Name)
+		if err := s.putBlob(ctx, key, e.LocalPath); err != nil {
+			res = append(res, fileResult(e.Name, e.Dir, nil, err))
+			continue
+		}
+		r, err := index(blob.URI(key), e.Dir, e.Name)
+		res = append(res, fileResult(e.Name, e.Dir, r, err))
 	}
+	return res
 }
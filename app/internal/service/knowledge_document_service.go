@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"io"
+	"mime"
+	"net/url"
+	"path/filepath"
+	"ragx/app/internal/biz"
+	"strconv"
+
+	pb "ragx/api/gen"
+
+	"github.com/go-kratos/kratos/v2/transport/http"
+)
+
+type KnowledgeDocumentService struct {
+	pb.UnimplementedKnowledgeDocumentServiceServer
+	uc *biz.KnowledgeDocumentUsecase
+}
+
+func RegisterKnowledgeDocumentServiceDownloadHTTPServer(s *http.Server, srv *KnowledgeDocumentService) {
+	r := s.Route("/")
+	r.GET("/api/v1/document/{id}/download", srv.DownloadKnowledgeDocument())
+}
+
+func NewKnowledgeDocumentService(uc *biz.KnowledgeDocumentUsecase) *KnowledgeDocumentService {
+	return &KnowledgeDocumentService{uc: uc}
+}
+
+func (s *KnowledgeDocumentService) ListKnowledgeDocument(ctx context.Context, req *pb.ListKnowledgeDocumentRequest) (*pb.ListKnowledgeDocumentReply, error) {
+	return s.uc.List(ctx, req)
+}
+func (s *KnowledgeDocumentService) ListKnowledgeChunk(ctx context.Context, req *pb.ListKnowledgeChunkRequest) (*pb.ListKnowledgeChunkReply, error) {
+	return s.uc.ListChunk(ctx, req)
+}
+
+// 下载文档的源文件
+func (s *KnowledgeDocumentService) DownloadKnowledgeDocument() func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		http.SetOperation(ctx, pb.KnowledgeDocumentService_DownloadKnowledgeDocument_FullMethodName)
+		id, err := strconv.ParseInt(ctx.Vars().Get("id"), 10, 64)
+		if err != nil {
+			return pb.ErrorDocumentNotFound("文档id不合法")
+		}
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			obj, rc, err := s.uc.Download(ctx, req.(*pb.DownloadKnowledgeDocumentRequest).Id)
+			if err != nil {
+				return nil, err
+			}
+			return &download{name: obj.FileName, rc: rc}, nil
+		})
+		out, err := h(ctx, &pb.DownloadKnowledgeDocumentRequest{Id: id})
+		if err != nil {
+			return err
+		}
+		d := out.(*download)
+		defer d.rc.Close()
+
+		contentType := mime.TypeByExtension(filepath.Ext(d.name))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		w := ctx.Response()
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", "attachment; filename*=UTF-8''"+url.PathEscape(d.name))
+		_, err = io.Copy(w, d.rc)
+		return err
+	}
+}
+
+// 待下载的源文件
+type download struct {
+	name string
+	rc   io.ReadCloser
+}
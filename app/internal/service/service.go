@@ -8,4 +8,5 @@ var ProviderSet = wire.NewSet(
 	NewIndexerServiceService,
 	NewStreamService,
 	NewKnowledgeBaseService,
+	NewKnowledgeDocumentService,
 )
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"github.com/bytedance/sonic"
-	"github.com/cloudwego/eino/schema"
 	"github.com/go-kratos/kratos/v2/log"
 	"io"
 	pb "ragx/api/gen"
@@ -49,7 +48,8 @@ func (s *StreamService) ChatStream() func(ctx http.Context) error {
 		if err != nil {
 			return err
 		}
-		sr := out.(*schema.StreamReader[*schema.Message])
+		reply := out.(*biz.ChatStreamReply)
+		sr := reply.Stream
 		defer sr.Close()
 		httpResp := ctx.Response()
 		// 设置响应头
@@ -74,6 +74,11 @@ func (s *StreamService) ChatStream() func(ctx http.Context) error {
 				break
 			}
 			sd.Content = message.Content
+			// 引用的文档块只在第一条消息中返回
+			sd.Document = nil
+			if i == 0 {
+				sd.Document = reply.References
+			}
 			bytes, _ := sonic.Marshal(sd)
 			_, err = httpResp.Write([]byte(fmt.Sprintf("data:%s\n", string(bytes))))
 			if err != nil {
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	nethttp "net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"ragx/app/internal/conf"
+	"ragx/app/pkg/archive"
+	"ragx/app/pkg/utils"
+	"strings"
+	"unicode"
+
+	pb "ragx/api/gen"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// 默认的上传目录
+	defaultUploadDir = "./uploads"
+	// 默认的单个文件大小上限，50MB
+	defaultUploadMaxSize = 50 << 20
+	// 识别文件类型需要读取的字节数
+	sniffLen = 512
+	// 文件名的最大长度
+	maxFileNameLen = 255
+	// 字段映射的最大长度
+	maxFieldMappingLen = 64 << 10
+	// 网页正文选择器的最大长度
+	maxSelectorLen = 1024
+)
+
+// 默认允许上传的文件类型，都是加载器能够解析的类型，zip、gzip是压缩包
+var defaultAllowedTypes = []string{
+	"text/plain",
+	"text/html",
+	"text/xml",
+	"application/pdf",
+	"application/zip",
+	"application/x-gzip",
+}
+
+// 已保存的上传文件
+type uploadFile struct {
+	// 清理后的原始文件名
+	Name string
+	// 本地临时文件路径，保存到blob存储后会被删除，压缩包需要在本地解压所以会保留
+	LocalPath string
+	// blob存储中的key
+	Key string
+}
+
+// 补全上传配置的默认值
+func newUploadConfig(c *conf.Upload) *conf.Upload {
+	res := &conf.Upload{Dir: defaultUploadDir, MaxSize: defaultUploadMaxSize, AllowedTypes: defaultAllowedTypes}
+	if c == nil {
+		return res
+	}
+	if c.Dir != "" {
+		res.Dir = c.Dir
+	}
+	if c.MaxSize > 0 {
+		res.MaxSize = c.MaxSize
+	}
+	if len(c.AllowedTypes) > 0 {
+		res.AllowedTypes = c.AllowedTypes
+	}
+	return res
+}
+
+// 以流的方式读取multipart请求，返回表单中的知识库名称、JSON文件的字段映射、HTML文件的正文选择器、分块策略和保存的文件
+// 文件先保存到临时目录，知识库名称校验通过后再保存到blob存储的知识库目录下，出错时清理掉本地的临时文件
+func (s *IndexerService) receive(r *nethttp.Request) (form *pb.UploadIndexerRequest, files []*uploadFile, err error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, nil, pb.ErrorUploadFileMissing("请使用multipart/form-data上传文件")
+	}
+	defer func() {
+		if err != nil {
+			for _, f := range files {
+				if f.LocalPath != "" {
+					_ = os.Remove(f.LocalPath)
+				}
+			}
+		}
+	}()
+	tmpDir := filepath.Join(s.upload.Dir, ".tmp")
+	if err = os.MkdirAll(tmpDir, 0755); err != nil {
+		return nil, nil, gerror.Wrap(err, "")
+	}
+
+	form = &pb.UploadIndexerRequest{KnowledgeName: r.URL.Query().Get("knowledge_name")}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, files, gerror.Wrap(err, "")
+		}
+		switch part.FormName() {
+		case "knowledge_name":
+			b, err := io.ReadAll(io.LimitReader(part, 256))
+			if err != nil {
+				part.Close()
+				return nil, files, gerror.Wrap(err, "")
+			}
+			form.KnowledgeName = strings.TrimSpace(string(b))
+		case "chunk_strategy":
+			b, err := io.ReadAll(io.LimitReader(part, 64))
+			if err != nil {
+				part.Close()
+				return nil, files, gerror.Wrap(err, "")
+			}
+			form.ChunkStrategy = strings.TrimSpace(string(b))
+		case "selector":
+			// HTML文件正文所在元素的CSS选择器，为空时自动识别正文
+			b, err := io.ReadAll(io.LimitReader(part, maxSelectorLen))
+			if err != nil {
+				part.Close()
+				return nil, files, gerror.Wrap(err, "")
+			}
+			form.Selector = strings.TrimSpace(string(b))
+		case "field_mapping":
+			// 字段映射使用JSON格式，例如{"content_template": "{question}", "id_field": "id"}
+			b, err := io.ReadAll(io.LimitReader(part, maxFieldMappingLen))
+			if err != nil {
+				part.Close()
+				return nil, files, gerror.Wrap(err, "")
+			}
+			if len(bytes.TrimSpace(b)) > 0 {
+				form.FieldMapping = &pb.FieldMapping{}
+				if err = protojson.Unmarshal(b, form.FieldMapping); err != nil {
+					part.Close()
+					return nil, files, pb.ErrorFieldMappingInvalid("字段映射不合法: %s", err.Error())
+				}
+			}
+		case "file":
+			f, err := s.saveUploadFile(tmpDir, part)
+			if err != nil {
+				part.Close()
+				return nil, files, err
+			}
+			files = append(files, f)
+		}
+		part.Close()
+	}
+	if len(files) == 0 {
+		return nil, nil, pb.ErrorUploadFileMissing("没有上传文件")
+	}
+	if !validKnowledgeName(form.KnowledgeName) {
+		return nil, files, pb.ErrorUploadKnowledgeNameInvalid("知识库名称不合法: %q", form.KnowledgeName)
+	}
+
+	// 每个知识库单独一个目录
+	for _, f := range files {
+		if archive.IsArchive(f.Name) {
+			continue
+		}
+		f.Key = path.Join(form.KnowledgeName, filepath.Base(f.LocalPath))
+		if err = s.putBlob(r.Context(), f.Key, f.LocalPath); err != nil {
+			return nil, files, err
+		}
+		_ = os.Remove(f.LocalPath)
+		f.LocalPath = ""
+	}
+	return form, files, nil
+}
+
+// 将本地文件保存到blob存储
+func (s *IndexerService) putBlob(ctx context.Context, key, localPath string) error {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return gerror.Wrap(err, "")
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		return gerror.Wrap(err, "")
+	}
+	return s.blobStore.Put(ctx, key, f, stat.Size())
+}
+
+// 保存单个上传文件，写入的同时校验文件类型和大小，超出限制时删除已写入的内容
+func (s *IndexerService) saveUploadFile(dir string, part *multipart.Part) (*uploadFile, error) {
+	name := sanitizeFileName(part.FileName())
+	br := bufio.NewReaderSize(part, sniffLen)
+	head, err := br.Peek(sniffLen)
+	if err != nil && err != io.EOF {
+		return nil, gerror.Wrap(err, "")
+	}
+	if contentType := sniffContentType(head); !s.allowed(contentType) {
+		return nil, pb.ErrorUploadFileTypeNotAllowed("文件%s的类型%s不支持", name, contentType)
+	}
+
+	savePath := filepath.Join(dir, storageName(name))
+	dst, err := os.OpenFile(savePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, gerror.Wrap(err, "")
+	}
+	// 多读一个字节，用于判断是否超过限制
+	n, err := io.Copy(dst, io.LimitReader(br, s.upload.MaxSize+1))
+	dst.Close()
+	if err == nil && n > s.upload.MaxSize {
+		err = pb.ErrorUploadFileTooLarge("文件%s超过大小限制%d字节", name, s.upload.MaxSize)
+	} else if err != nil {
+		err = gerror.Wrap(err, "")
+	}
+	if err != nil {
+		_ = os.Remove(savePath)
+		return nil, err
+	}
+	return &uploadFile{Name: name, LocalPath: savePath}, nil
+}
+
+// 校验本地文件的类型
+func (s *IndexerService) checkFileType(path, name string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return gerror.Wrap(err, "")
+	}
+	defer f.Close()
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return gerror.Wrap(err, "")
+	}
+	if contentType := sniffContentType(head[:n]); !s.allowed(contentType) {
+		return pb.ErrorUploadFileTypeNotAllowed("文件%s的类型%s不支持", name, contentType)
+	}
+	return nil
+}
+
+// 文件类型是否在允许的列表中
+func (s *IndexerService) allowed(contentType string) bool {
+	for _, t := range s.upload.AllowedTypes {
+		if t == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// 根据文件内容识别文件类型，不信任客户端提供的Content-Type，返回值不包含charset等参数
+func sniffContentType(head []byte) string {
+	contentType := nethttp.DetectContentType(head)
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+	return contentType
+}
+
+// 清理客户端提供的文件名，去掉目录和控制字符，只保留文件名部分
+func sanitizeFileName(name string) string {
+	name = strings.ReplaceAll(name, "\\", "/")
+	name = filepath.Base(name)
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '/' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == ".." {
+		return "file"
+	}
+	if len(name) > maxFileNameLen {
+		ext := fileExt(name)
+		// 按字节截断后去掉不完整的字符
+		name = strings.ToValidUTF8(name[:maxFileNameLen-len(ext)], "") + ext
+	}
+	return name
+}
+
+// 保存的文件名，使用uuid避免覆盖已有的文件，保留扩展名用于选择解析器
+func storageName(name string) string {
+	return utils.UniqueID() + fileExt(name)
+}
+
+// 获取文件扩展名，支持.tar.gz，只保留字母和数字
+func fileExt(name string) string {
+	lower := strings.ToLower(name)
+	ext := filepath.Ext(lower)
+	if strings.HasSuffix(lower, ".tar.gz") {
+		ext = ".tar.gz"
+	}
+	return strings.Map(func(r rune) rune {
+		if r == '.' || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return -1
+	}, ext)
+}
+
+// 知识库名称会作为目录名，不能包含路径分隔符，也不能以.开头，避免与临时目录.tmp等隐藏目录冲突
+func validKnowledgeName(name string) bool {
+	if name == "" || strings.HasPrefix(name, ".") || len(name) > 128 {
+		return false
+	}
+	return !strings.ContainsAny(name, "/\\\x00")
+}
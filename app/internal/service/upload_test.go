@@ -0,0 +1,231 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	nethttp "net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pb "ragx/api/gen"
+	"ragx/app/internal/conf"
+	"ragx/app/pkg/blob"
+)
+
+// 上传的表单，files的key是文件名，value是文件内容
+type uploadForm struct {
+	fields map[string]string
+	files  [][2]string
+}
+
+func newUploadRequest(t *testing.T, form uploadForm) *nethttp.Request {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for k, v := range form.fields {
+		if err := w.WriteField(k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, f := range form.files {
+		fw, err := w.CreateFormFile("file", f[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = io.WriteString(fw, f[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r := httptest.NewRequest(nethttp.MethodPost, "/api/v1/indexer", &buf)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+	return r
+}
+
+func newTestIndexerService(t *testing.T, maxSize int64) (*IndexerService, string) {
+	dir := t.TempDir()
+	store, err := blob.NewLocalStore(filepath.Join(dir, "blob"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	upload := newUploadConfig(&conf.Upload{Dir: filepath.Join(dir, "uploads"), MaxSize: maxSize})
+	return &IndexerService{upload: upload, blobStore: store}, dir
+}
+
+// 临时目录中不应该残留文件
+func assertNoTempFiles(t *testing.T, s *IndexerService) {
+	t.Helper()
+	entries, _ := os.ReadDir(filepath.Join(s.upload.Dir, ".tmp"))
+	if len(entries) != 0 {
+		t.Errorf("%d files left in tmp dir", len(entries))
+	}
+}
+
+func TestReceive(t *testing.T) {
+	s, _ := newTestIndexerService(t, 1024)
+	r := newUploadRequest(t, uploadForm{
+		fields: map[string]string{"knowledge_name": " kb ", "selector": " article .content ",
+			"chunk_strategy": " semantic ", "field_mapping": `{"content_template": "{question}", "id_field": "id"}`},
+		files: [][2]string{{"../../etc/a.txt", "hello"}, {"b.md", "# title"}},
+	})
+	form, files, err := s.receive(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if form.KnowledgeName != "kb" || form.Selector != "article .content" || form.ChunkStrategy != "semantic" ||
+		form.FieldMapping.GetIdField() != "id" {
+		t.Fatalf("form = %+v", form)
+	}
+	if len(files) != 2 || files[0].Name != "a.txt" || files[1].Name != "b.md" {
+		t.Fatalf("files = %+v", files)
+	}
+	for _, f := range files {
+		// 文件以uuid命名保存到知识库目录下，本地临时文件已删除
+		if !strings.HasPrefix(f.Key, "kb/") || filepath.Ext(f.Key) != filepath.Ext(f.Name) || f.LocalPath != "" {
+			t.Errorf("file = %+v", f)
+		}
+		rc, err := s.blobStore.Get(context.Background(), f.Key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rc.Close()
+	}
+	assertNoTempFiles(t, s)
+}
+
+func TestReceiveRejected(t *testing.T) {
+	tests := []struct {
+		name  string
+		form  uploadForm
+		check func(error) bool
+	}{
+		{
+			name:  "no file",
+			form:  uploadForm{fields: map[string]string{"knowledge_name": "kb"}},
+			check: pb.IsUploadFileMissing,
+		},
+		{
+			name:  "too large",
+			form:  uploadForm{fields: map[string]string{"knowledge_name": "kb"}, files: [][2]string{{"a.txt", "ok"}, {"b.txt", strings.Repeat("a", 65)}}},
+			check: pb.IsUploadFileTooLarge,
+		},
+		{
+			// 文件类型根据内容识别，与文件名无关
+			name:  "mime not allowed",
+			form:  uploadForm{fields: map[string]string{"knowledge_name": "kb"}, files: [][2]string{{"a.txt", "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"}}},
+			check: pb.IsUploadFileTypeNotAllowed,
+		},
+		{
+			name:  "staging dir as knowledge name",
+			form:  uploadForm{fields: map[string]string{"knowledge_name": ".tmp"}, files: [][2]string{{"a.txt", "ok"}}},
+			check: pb.IsUploadKnowledgeNameInvalid,
+		},
+		{
+			name:  "knowledge name with separator",
+			form:  uploadForm{fields: map[string]string{"knowledge_name": "a/../b"}, files: [][2]string{{"a.txt", "ok"}}},
+			check: pb.IsUploadKnowledgeNameInvalid,
+		},
+		{
+			name:  "invalid field mapping",
+			form:  uploadForm{fields: map[string]string{"knowledge_name": "kb", "field_mapping": "{"}, files: [][2]string{{"a.txt", "ok"}}},
+			check: pb.IsFieldMappingInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, dir := newTestIndexerService(t, 64)
+			_, _, err := s.receive(newUploadRequest(t, tt.form))
+			if !tt.check(err) {
+				t.Fatalf("err = %v", err)
+			}
+			assertNoTempFiles(t, s)
+			// 校验失败时不会写入blob存储
+			entries, _ := os.ReadDir(filepath.Join(dir, "blob"))
+			if len(entries) != 0 {
+				t.Errorf("%d entries written to blob store", len(entries))
+			}
+		})
+	}
+
+	s, _ := newTestIndexerService(t, 64)
+	r := httptest.NewRequest(nethttp.MethodPost, "/api/v1/indexer", strings.NewReader("{}"))
+	r.Header.Set("Content-Type", "application/json")
+	if _, _, err := s.receive(r); !pb.IsUploadFileMissing(err) {
+		t.Errorf("non multipart err = %v", err)
+	}
+}
+
+func TestSanitizeFileName(t *testing.T) {
+	long := strings.Repeat("文", 100) + ".pdf"
+	tests := []struct {
+		name, want string
+	}{
+		{"a.txt", "a.txt"},
+		{"../../etc/passwd", "passwd"},
+		{`C:\Users\a\报告.pdf`, "报告.pdf"},
+		{"a\x00b\r\n.txt", "ab.txt"},
+		{"  a.txt  ", "a.txt"},
+		{"", "file"},
+		{"..", "file"},
+		{"dir/", "dir"},
+		{"/", "file"},
+		// 超长时保留扩展名，截断后仍是合法的UTF-8
+		{long, strings.Repeat("文", 83) + ".pdf"},
+	}
+	for _, tt := range tests {
+		if got := sanitizeFileName(tt.name); got != tt.want {
+			t.Errorf("sanitizeFileName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFileExt(t *testing.T) {
+	tests := []struct {
+		name, want string
+	}{
+		{"a.txt", ".txt"},
+		{"A.PDF", ".pdf"},
+		{"a.tar.gz", ".tar.gz"},
+		{"a.TGZ", ".tgz"},
+		{"a", ""},
+		{"a.t x$t", ".txt"},
+		{"a.文档", "."},
+	}
+	for _, tt := range tests {
+		if got := fileExt(tt.name); got != tt.want {
+			t.Errorf("fileExt(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestValidKnowledgeName(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"kb", true},
+		{"产品文档", true},
+		{"kb.v2", true},
+		{"", false},
+		{".", false},
+		{"..", false},
+		{".tmp", false},
+		{".hidden", false},
+		{"a/b", false},
+		{`a\b`, false},
+		{"a\x00", false},
+		{strings.Repeat("a", 128), true},
+		{strings.Repeat("a", 129), false},
+	}
+	for _, tt := range tests {
+		if got := validKnowledgeName(tt.name); got != tt.want {
+			t.Errorf("validKnowledgeName(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -2,7 +2,7 @@ package ai
 
 import (
 	"context"
-	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	"fmt"
 	"github.com/cloudwego/eino-ext/components/model/openai"
 	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/components/embedding"
@@ -11,6 +11,11 @@ import (
 	"github.com/cloudwego/eino/components/retriever"
 	"github.com/elastic/go-elasticsearch/v8"
 	"log"
+	"ragx/app/pkg/blob"
+	"ragx/app/pkg/docparser"
+	"ragx/app/pkg/embedder"
+	"ragx/app/pkg/redact"
+	"ragx/app/pkg/tokenizer"
 )
 
 const (
@@ -20,6 +25,8 @@ const (
 	DefaultModel = "gpt-4o"
 	// 默认最大token数
 	defaultMaxTokens = 8192
+	// 默认嵌入模型doubao-embedding-text-240715的向量维度
+	defaultEmbeddingDims = 1024
 )
 
 type Client struct {
@@ -52,21 +59,59 @@ type Client struct {
 		| 1.5 - 2         | Extreme randomness  | Artistic creation, game design, exploratory tasks |
 	*/
 	temperature float32
+	// 上传源文件的存储
+	blobStore blob.BlobStore
+	// 表格解析配置
+	spreadsheet *docparser.SpreadsheetConfig
+	// PDF解析配置
+	pdf *docparser.PDFConfig
+	// 文档分块配置
+	chunk *ChunkConfig
+	// 父子块检索时分割子块
+	childSplitter document.Transformer
+	// 为每个文档块生成的问题数，0表示不生成
+	questionsPerChunk int
+	// 增强文档块时同时调用对话模型的数量
+	enrichConcurrency int
+	// 脱敏配置，为空时不脱敏
+	redact *redact.Config
+	// 嵌入器配置，为空时使用方舟和embeddingModelName
+	embedding *embedder.Config
+	// 向量维度，由嵌入器决定
+	embeddingDims int
+	// 批量嵌入配置，包括向量缓存、批大小、并发数和重试
+	embedBatch *embedder.BatchConfig
+	// 包装后的嵌入器，记录嵌入统计
+	embedBatcher *embedder.Batch
+	// 向量存储类型：es、pgvector，为空时使用es
+	vectorStore string
+	// pgvector向量存储配置
+	pgvector *PGVectorConfig
+	// 是否同时使用全文检索，与向量检索的结果按RRF融合
+	hybrid bool
 
 	// 模型，用于生成文本或执行其他模型相关操作
 	ChatModel model.ToolCallingChatModel
 	// 嵌入器，用于将文本转换为向量表示。主要用于文档检索和相似度计算。
 	Embedder embedding.Embedder
 
+	// 分词器，用于计算文档块的token数
+	Tokenizer tokenizer.Tokenizer
 	// 加载器，用于加载文档
 	Loader document.Loader
 	// 转换器，对输入的文档进行各种转换操作，如分割、过滤、合并等，从而得到满足特定需求的文档
 	Transformer document.Transformer
-	// es客户端
+	// 增强转换器，使用对话模型为文档块生成摘要、关键词和上下文标题
+	Enricher document.Transformer
+	// 脱敏器，建立索引前处理文档中的敏感信息，没有启用时为空
+	Redactor *redact.Redactor
+	// es客户端，向量存储不是es时为空
 	ESClient *elasticsearch.Client
-	// 索引器，用于将文档转换为索引，以便进行快速检索
+	// 向量存储，保存文档块的向量并按相似度检索
+	VectorStore VectorStore
+	// 索引器，用于将文档转换为索引，以便进行快速检索，写入VectorStore
 	Indexer indexer.Indexer
-	// 检索器，用于根据查询条件从索引中检索相关文档
+	// 检索器，用于根据查询条件从索引中检索相关文档，与Search相同
 	Retriever retriever.Retriever
 }
 
@@ -99,31 +144,69 @@ func NewClient(apiKey string, opts ...ClientOption) *Client {
 		return c
 	}
 	// 初始化嵌入器
-	embeddingConfig := &ark.EmbeddingConfig{
-		APIKey: c.embeddingApiKey,
-		Model:  c.embeddingModelName,
+	embeddingConfig := c.embedding
+	if embeddingConfig == nil {
+		embeddingConfig = &embedder.Config{
+			Provider:   embedder.ProviderArk,
+			Model:      c.embeddingModelName,
+			APIKey:     c.embeddingApiKey,
+			Dimensions: defaultEmbeddingDims,
+		}
 	}
-	embedder, err := ark.NewEmbedder(context.Background(), embeddingConfig)
+	emb, err := embedder.New(context.Background(), embeddingConfig)
 	if err != nil {
-		log.Fatalf("new openai embedding failed, err: %+v", err)
+		log.Fatalf("new embedder failed, err: %+v", err)
 	}
-	c.Embedder = embedder
+	c.embeddingDims = emb.Dimensions()
+	// 初始化分词器
+	c.chunk = c.chunk.withDefaults()
+	c.Tokenizer, err = tokenizer.New(c.chunk.Tokenizer, c.chunk.Encoding)
+	if err != nil {
+		log.Fatalf("new tokenizer failed, err: %+v", err)
+	}
+	// 缓存向量，分批并发请求嵌入服务，缓存键区分模型和维度
+	batchConfig := embedder.BatchConfig{}
+	if c.embedBatch != nil {
+		batchConfig = *c.embedBatch
+	}
+	batchConfig.Namespace = fmt.Sprintf("%s/%s/%d", embeddingConfig.Provider, embeddingConfig.Model, c.embeddingDims)
+	batchConfig.CountTokens = c.Tokenizer.Count
+	c.embedBatcher = embedder.NewBatch(emb, &batchConfig)
+	c.Embedder = c.embedBatcher
 	// 初始化加载器
-	c.Loader = newLoader()
+	c.Loader = newLoader(c)
+	// 初始化脱敏器
+	if c.redact != nil {
+		if c.Redactor, err = redact.New(c.redact); err != nil {
+			log.Fatalf("new redactor failed, err: %+v", err)
+		}
+	}
 	// 初始化转换器
-	c.Transformer = NewMultiTransformer()
-	// 初始化es客户端
-	c.ESClient, err = elasticsearch.NewClient(elasticsearch.Config{
-		Addresses: []string{c.esAddress},
-		Username:  c.esUsername,
-		Password:  c.esPassword,
-	})
+	c.Transformer = NewMultiTransformer(c.Tokenizer, c.Embedder, c.chunk)
+	c.childSplitter = newChildSplitter(c.Tokenizer, c.chunk)
+	c.Enricher = newEnricher(c.ChatModel, c.Tokenizer, c.enrichConcurrency)
+	// 初始化向量存储
+	switch c.vectorStore {
+	case "", VectorStoreES:
+		c.VectorStore, err = newESStore(c)
+	case VectorStorePGVector:
+		c.VectorStore, err = newPGVectorStore(c.pgvector, c.Embedder, c.embeddingDims)
+	default:
+		err = fmt.Errorf("unsupported vector store: %s", c.vectorStore)
+	}
 	if err != nil {
-		log.Fatalf("new es client failed, err: %+v", err)
+		log.Fatalf("new vector store failed, err: %+v", err)
 	}
-	// 初始化索引器
-	c.Indexer = newIndexer(c)
-	// 初始化检索器
-	c.Retriever = newRetriever(c)
+	// 初始化索引器和检索器
+	c.Indexer = c.VectorStore
+	c.Retriever = &storeRetriever{c: c}
 	return c
 }
+
+// EmbedStats 嵌入统计，包括缓存命中率和实际向量化的token数
+func (c *Client) EmbedStats() *embedder.Stats {
+	if c.embedBatcher == nil {
+		return &embedder.Stats{}
+	}
+	return c.embedBatcher.Stats()
+}
@@ -0,0 +1,247 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"ragx/app/pkg/tokenizer"
+)
+
+const (
+	// 文档摘要
+	MetaSummary = "_summary"
+	// 文档块的关键词
+	MetaKeywords = "_keywords"
+	// 文档块的上下文标题，说明文档块在整个文档中的位置和主题
+	MetaContextHeader = "_context_header"
+	// 向量化时加在内容前面的文本，不保存到扩展数据
+	MetaEmbedPrefix = "_embed_prefix"
+
+	// 默认同时处理的文档块数量
+	DefaultEnrichConcurrency = 4
+	// 生成摘要时最多使用文档开头的token数
+	enrichSummarySize = 4096
+	// 生成文档摘要的提示词
+	enrichSummaryPrompt = "你是一个知识库助手。用不超过100字概括用户提供的文档的主题和要点，使用与文档相同的语言，只输出摘要。"
+	// 生成文档块上下文和关键词的提示词
+	enrichChunkPrompt = "你是一个知识库助手。用户会提供整篇文档的摘要和其中的一个片段。" +
+		`请输出一个JSON对象：header为一句话，说明这个片段在整篇文档中的上下文，例如所属的产品、章节或主题；keywords为片段的3到8个关键词。` +
+		"使用与文档相同的语言，只输出JSON。"
+)
+
+// EnrichUsage 记录增强文档块时调用对话模型的次数和消耗的token数
+type EnrichUsage struct {
+	mu               sync.Mutex
+	Calls            int
+	PromptTokens     int
+	CompletionTokens int
+}
+
+// TotalTokens 消耗的总token数
+func (u *EnrichUsage) TotalTokens() int {
+	return u.PromptTokens + u.CompletionTokens
+}
+
+func (u *EnrichUsage) add(msg *schema.Message) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.Calls++
+	if msg.ResponseMeta != nil && msg.ResponseMeta.Usage != nil {
+		u.PromptTokens += msg.ResponseMeta.Usage.PromptTokens
+		u.CompletionTokens += msg.ResponseMeta.Usage.CompletionTokens
+	}
+}
+
+type enrichOptions struct {
+	// 把上下文标题和关键词加在内容前面一起向量化
+	embed bool
+	// 生成文档摘要使用的文档块，为空时使用要增强的文档块
+	source []*schema.Document
+	// 累计调用次数和token数
+	usage *EnrichUsage
+}
+
+// WithEnrichEmbed 向量化时把上下文标题和关键词加在文档块内容的前面，索引中保存的内容不变
+func WithEnrichEmbed(embed bool) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *enrichOptions) {
+		o.embed = embed
+	})
+}
+
+// WithEnrichSource 指定生成文档摘要使用的完整文档，只增强部分文档块时摘要仍然覆盖整个文档
+func WithEnrichSource(docs []*schema.Document) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *enrichOptions) {
+		o.source = docs
+	})
+}
+
+// WithEnrichUsage 累计调用对话模型的次数和消耗的token数，用于统计成本
+func WithEnrichUsage(usage *EnrichUsage) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *enrichOptions) {
+		o.usage = usage
+	})
+}
+
+// 增强转换器，使用对话模型为文档块补充上下文，结果保存在元数据中
+// 整个文档生成一次摘要，每个文档块生成上下文标题和关键词，检索时裸的文档块内容也能带上所属的文档和主题
+type enricher struct {
+	chatModel   model.BaseChatModel
+	tok         tokenizer.Tokenizer
+	concurrency int
+}
+
+func newEnricher(chatModel model.BaseChatModel, tok tokenizer.Tokenizer, concurrency int) *enricher {
+	if concurrency <= 0 {
+		concurrency = DefaultEnrichConcurrency
+	}
+	return &enricher{chatModel: chatModel, tok: tok, concurrency: concurrency}
+}
+
+// Transform 增强文档块，传入的文档块会被直接修改
+// 摘要生成失败时返回错误，单个文档块失败时跳过该文档块，返回所有失败的原因
+func (e *enricher) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	if len(docs) == 0 {
+		return docs, nil
+	}
+	options := document.GetTransformerImplSpecificOptions(&enrichOptions{}, opts...)
+	if options.usage == nil {
+		options.usage = &EnrichUsage{}
+	}
+	source := options.source
+	if len(source) == 0 {
+		source = docs
+	}
+	summary, err := e.summary(ctx, source, options.usage)
+	if err != nil {
+		return docs, err
+	}
+
+	// 分割出的文档块可能共用同一个元数据，全部生成完后再统一写入
+	headers := make([]string, len(docs))
+	keywords := make([][]string, len(docs))
+	ok := make([]bool, len(docs))
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	sem := make(chan struct{}, e.concurrency)
+	for i, doc := range docs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, doc *schema.Document) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			header, kws, err := e.chunk(ctx, summary, doc, options.usage)
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, gerror.Wrapf(err, "enrich chunk %s", doc.ID))
+				mu.Unlock()
+				return
+			}
+			headers[i], keywords[i], ok[i] = header, kws, true
+		}(i, doc)
+	}
+	wg.Wait()
+	for i, doc := range docs {
+		meta := make(map[string]any, len(doc.MetaData)+4)
+		for k, v := range doc.MetaData {
+			meta[k] = v
+		}
+		meta[MetaSummary] = summary
+		if ok[i] {
+			meta[MetaContextHeader] = headers[i]
+			meta[MetaKeywords] = keywords[i]
+			if options.embed {
+				meta[MetaEmbedPrefix] = embedPrefix(headers[i], keywords[i])
+			}
+		}
+		doc.MetaData = meta
+	}
+	return docs, errors.Join(errs...)
+}
+
+// 为整个文档生成摘要，文档过长时只使用开头的部分
+func (e *enricher) summary(ctx context.Context, docs []*schema.Document, usage *EnrichUsage) (string, error) {
+	var sb strings.Builder
+	for _, doc := range docs {
+		if sb.Len() > 0 {
+			sb.WriteString("\n\n")
+		}
+		sb.WriteString(doc.Content)
+	}
+	content := tokenizer.Truncate(e.tok, sb.String(), enrichSummarySize)
+	if name, _ := docs[0].MetaData[MetaFileName].(string); name != "" {
+		content = "文件名：" + name + "\n\n" + content
+	}
+	msg, err := e.chatModel.Generate(ctx, []*schema.Message{
+		schema.SystemMessage(enrichSummaryPrompt),
+		schema.UserMessage(content),
+	})
+	if err != nil {
+		return "", gerror.Wrap(err, "generate summary")
+	}
+	usage.add(msg)
+	return strings.TrimSpace(msg.Content), nil
+}
+
+// 为文档块生成上下文标题和关键词
+func (e *enricher) chunk(ctx context.Context, summary string, doc *schema.Document, usage *EnrichUsage) (string, []string, error) {
+	msg, err := e.chatModel.Generate(ctx, []*schema.Message{
+		schema.SystemMessage(enrichChunkPrompt),
+		schema.UserMessage(fmt.Sprintf("文档摘要：\n%s\n\n文档片段：\n%s", summary, doc.Content)),
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	usage.add(msg)
+	// 模型可能在JSON外面包上代码块或其它说明，只取第一个{到最后一个}之间的内容
+	content := msg.Content
+	start, end := strings.Index(content, "{"), strings.LastIndex(content, "}")
+	if start < 0 || end < start {
+		return "", nil, gerror.Newf("invalid enrichment output: %q", content)
+	}
+	var res struct {
+		Header   string   `json:"header"`
+		Keywords []string `json:"keywords"`
+	}
+	if err = json.Unmarshal([]byte(content[start:end+1]), &res); err != nil {
+		return "", nil, gerror.Wrapf(err, "invalid enrichment output: %q", content)
+	}
+	keywords := make([]string, 0, len(res.Keywords))
+	for _, k := range res.Keywords {
+		if k = strings.TrimSpace(k); k != "" {
+			keywords = append(keywords, k)
+		}
+	}
+	return strings.TrimSpace(res.Header), keywords, nil
+}
+
+// 向量化时加在内容前面的文本
+func embedPrefix(header string, keywords []string) string {
+	var sb strings.Builder
+	if header != "" {
+		sb.WriteString(header)
+		sb.WriteString("\n")
+	}
+	if len(keywords) > 0 {
+		sb.WriteString("关键词：")
+		sb.WriteString(strings.Join(keywords, "，"))
+		sb.WriteString("\n")
+	}
+	if sb.Len() > 0 {
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
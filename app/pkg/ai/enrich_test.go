@@ -0,0 +1,135 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+
+	"ragx/app/pkg/tokenizer"
+)
+
+// 摘要返回固定内容，文档块按内容返回对应的输出
+func newFakeEnrichModel(outputs map[string]string) *fakeChatModel {
+	return &fakeChatModel{generate: func(system, user string) (string, error) {
+		if system == enrichSummaryPrompt {
+			return " 产品手册摘要 \n", nil
+		}
+		for content, out := range outputs {
+			if strings.HasSuffix(user, "文档片段：\n"+content) {
+				return out, nil
+			}
+		}
+		return "", errors.New("model unavailable")
+	}}
+}
+
+func TestEnrichTransform(t *testing.T) {
+	m := newFakeEnrichModel(map[string]string{
+		// 代码块包裹的JSON
+		"安装步骤": "```json\n{\"header\": \" 产品手册的安装章节 \", \"keywords\": [\"安装\", \" \", \" 部署 \"]}\n```",
+		// JSON前后带有说明
+		"常见问题": `以下是结果：{"header": "常见问题", "keywords": ["FAQ"]} 希望有帮助`,
+		"无效输出": "没有JSON",
+	})
+	// 分割出的文档块共用同一个元数据
+	shared := map[string]any{MetaFileName: "manual.md"}
+	docs := []*schema.Document{
+		{ID: "1", Content: "安装步骤", MetaData: shared},
+		{ID: "2", Content: "常见问题", MetaData: shared},
+		{ID: "3", Content: "无效输出", MetaData: shared},
+		{ID: "4", Content: "未知片段", MetaData: shared},
+	}
+	usage := &EnrichUsage{}
+	e := newEnricher(m, tokenizer.NewEstimator(), 2)
+	docs, err := e.Transform(context.Background(), docs, WithEnrichEmbed(true), WithEnrichUsage(usage))
+
+	// 单个文档块失败不影响其它文档块，返回所有失败的原因
+	if err == nil || !strings.Contains(err.Error(), "enrich chunk 3") || !strings.Contains(err.Error(), "enrich chunk 4") {
+		t.Fatalf("err = %v", err)
+	}
+	want := []struct {
+		header   string
+		keywords []string
+		prefix   string
+	}{
+		{"产品手册的安装章节", []string{"安装", "部署"}, "产品手册的安装章节\n关键词：安装，部署\n\n"},
+		{"常见问题", []string{"FAQ"}, "常见问题\n关键词：FAQ\n\n"},
+		{}, {},
+	}
+	for i, doc := range docs {
+		if doc.MetaData[MetaSummary] != "产品手册摘要" || doc.MetaData[MetaFileName] != "manual.md" {
+			t.Errorf("doc %s metadata = %v", doc.ID, doc.MetaData)
+		}
+		if want[i].header == "" {
+			if _, ok := doc.MetaData[MetaContextHeader]; ok {
+				t.Errorf("failed doc %s has header: %v", doc.ID, doc.MetaData)
+			}
+			continue
+		}
+		if doc.MetaData[MetaContextHeader] != want[i].header || !reflect.DeepEqual(doc.MetaData[MetaKeywords], want[i].keywords) ||
+			doc.MetaData[MetaEmbedPrefix] != want[i].prefix {
+			t.Errorf("doc %s metadata = %v", doc.ID, doc.MetaData)
+		}
+	}
+	// 共用的元数据不会被修改
+	if len(shared) != 1 {
+		t.Errorf("shared metadata modified: %v", shared)
+	}
+	// 摘要一次，文档块三次，输出无效的调用也计入，模型出错的调用不计入
+	if usage.Calls != 4 || usage.PromptTokens != 40 || usage.CompletionTokens != 12 || usage.TotalTokens() != 52 {
+		t.Errorf("usage = %+v", usage)
+	}
+	// 摘要的输入带上文件名
+	if user := m.inputs[0][1].Content; !strings.HasPrefix(user, "文件名：manual.md\n\n安装步骤\n\n常见问题") {
+		t.Errorf("summary input = %q", user)
+	}
+}
+
+func TestEnrichSummary(t *testing.T) {
+	tok := tokenizer.NewEstimator()
+	m := newFakeEnrichModel(map[string]string{})
+	e := newEnricher(m, tok, 1)
+	long := strings.Repeat("知识库检索增强生成", 2000)
+	docs := []*schema.Document{{ID: "1", Content: long, MetaData: map[string]any{}}}
+	summary, err := e.summary(context.Background(), docs, &EnrichUsage{})
+	if err != nil || summary != "产品手册摘要" {
+		t.Fatalf("summary = %q, %v", summary, err)
+	}
+	// 过长的文档只使用开头的部分
+	user := m.inputs[0][1].Content
+	if n := tok.Count(user); n > enrichSummarySize || n < enrichSummarySize-10 || !strings.HasPrefix(long, user) {
+		t.Errorf("summary input has %d tokens", n)
+	}
+
+	// 摘要失败时返回错误，不再增强文档块
+	m.generate = func(system, user string) (string, error) { return "", errors.New("model unavailable") }
+	m.inputs = nil
+	if _, err = e.Transform(context.Background(), docs); err == nil {
+		t.Fatal("expected summary error")
+	}
+	if len(m.inputs) != 1 {
+		t.Errorf("model called %d times", len(m.inputs))
+	}
+}
+
+func TestEmbedPrefix(t *testing.T) {
+	tests := []struct {
+		header   string
+		keywords []string
+		want     string
+	}{
+		{"", nil, ""},
+		{"标题", nil, "标题\n\n"},
+		{"", []string{"a", "b"}, "关键词：a，b\n\n"},
+		{"标题", []string{"a"}, "标题\n关键词：a\n\n"},
+	}
+	for _, tt := range tests {
+		if got := embedPrefix(tt.header, tt.keywords); got != tt.want {
+			t.Errorf("embedPrefix(%q, %v) = %q, want %q", tt.header, tt.keywords, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"github.com/bytedance/sonic"
+	"github.com/cloudwego/eino-ext/components/retriever/es8"
+	"github.com/cloudwego/eino-ext/components/retriever/es8/search_mode"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/deletebyquery"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+)
+
+// 基于Elasticsearch 8的向量存储，内容向量和问题向量分别由两个检索器搜索
+type esStore struct {
+	indexer.Indexer
+	client    *elasticsearch.Client
+	indexName string
+	// 向量字段对应的检索器
+	retrievers map[string]retriever.Retriever
+}
+
+// 创建es向量存储，索引不存在时创建
+func newESStore(c *Client) (*esStore, error) {
+	client, err := elasticsearch.NewClient(elasticsearch.Config{
+		Addresses: []string{c.esAddress},
+		Username:  c.esUsername,
+		Password:  c.esPassword,
+	})
+	if err != nil {
+		return nil, gerror.Wrap(err, "")
+	}
+	c.ESClient = client
+	return &esStore{
+		Indexer:   newIndexer(c),
+		client:    client,
+		indexName: c.indexName,
+		retrievers: map[string]retriever.Retriever{
+			FieldContentVector:   newRetriever(c, FieldContentVector),
+			FieldQAContentVector: newRetriever(c, FieldQAContentVector),
+		},
+	}, nil
+}
+
+func (s *esStore) Search(ctx context.Context, query string, opts *SearchOptions) ([]*schema.Document, error) {
+	filters := esFilters(opts.Filters)
+	if opts.FullText {
+		return s.fullTextSearch(ctx, query, opts.TopK, filters)
+	}
+	field := opts.VectorField
+	if field == "" {
+		field = FieldContentVector
+	}
+	rtr, ok := s.retrievers[field]
+	if !ok {
+		return nil, gerror.Newf("unsupported vector field: %s", field)
+	}
+	if field == FieldQAContentVector {
+		// 只搜索有问题向量的文档块
+		filters = append(filters, types.Query{Exists: &types.ExistsQuery{Field: FieldQAContentVector}})
+	}
+	rOpts := []retriever.Option{retriever.WithTopK(opts.TopK), retriever.WithScoreThreshold(opts.ScoreThreshold)}
+	if len(filters) > 0 {
+		rOpts = append(rOpts, es8.WithFilters(filters))
+	}
+	docs, err := rtr.Retrieve(ctx, query, rOpts...)
+	if err != nil {
+		return nil, gerror.Wrap(err, "")
+	}
+	return docs, nil
+}
+
+// 按内容全文检索，得分是es的BM25得分
+func (s *esStore) fullTextSearch(ctx context.Context, query string, topK int, filters []types.Query) ([]*schema.Document, error) {
+	req := &search.Request{
+		Query: &types.Query{Bool: &types.BoolQuery{
+			Must:   []types.Query{{Match: map[string]types.MatchQuery{FieldContent: {Query: query}}}},
+			Filter: filters,
+		}},
+		// 不需要返回向量
+		Source_: &types.SourceFilter{Excludes: []string{FieldContentVector, FieldQAContentVector}},
+	}
+	if topK > 0 {
+		req.Size = &topK
+	}
+	res, err := search.NewSearchFunc(s.client)().Index(s.indexName).Request(req).Do(ctx)
+	if err != nil {
+		return nil, gerror.Wrap(err, "")
+	}
+	docs := make([]*schema.Document, 0, len(res.Hits.Hits))
+	for _, hit := range res.Hits.Hits {
+		doc, err := parseESHit(ctx, hit)
+		if err != nil {
+			return nil, gerror.Wrap(err, "")
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// 元数据过滤条件，知识库名称是keyword字段，其它键精确匹配可过滤的元数据字段中的同名子字段
+func esFilters(filters map[string]string) []types.Query {
+	res := make([]types.Query, 0, len(filters)+1)
+	for k, v := range filters {
+		field := k
+		if k != KnowledgeName {
+			field = FieldMeta + "." + k
+		}
+		res = append(res, types.Query{Term: map[string]types.TermQuery{field: {Value: v}}})
+	}
+	return res
+}
+
+// es索引中的文档不区分引用它的文档，复用时不需要处理
+func (s *esStore) Reuse(ctx context.Context, ids []string) error {
+	return nil
+}
+
+// 根据文档id从es索引中删除文档
+func (s *esStore) Delete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := deletebyquery.NewDeleteByQueryFunc(s.client)(s.indexName).Query(&types.Query{
+		Ids: &types.IdsQuery{Values: ids},
+	}).Do(ctx)
+	if err != nil {
+		return gerror.Wrap(err, "")
+	}
+	return nil
+}
+
+// 创建并配置一个基于Elasticsearch 8的检索器，vectorField为用于向量搜索的字段
+func newRetriever(c *Client, vectorField string) retriever.Retriever {
+	// 配置ES8检索器参数
+	retrieverConfig := &es8.RetrieverConfig{
+		Client: c.ESClient,  // Elasticsearch客户端
+		Index:  c.indexName, // 索引名称
+		// 设置搜索模式为稠密向量相似度搜索（余弦相似度）
+		SearchMode: search_mode.SearchModeDenseVectorSimilarity(
+			search_mode.DenseVectorSimilarityTypeCosineSimilarity, // 使用余弦相似度算法
+			vectorField, // 指定用于向量搜索的字段
+		),
+		Embedding: c.Embedder, // 嵌入器，用于将文本转换为向量
+		// 自定义结果解析器，用于处理ES返回的命中结果
+		ResultParser: parseESHit,
+	}
+	// 创建ES8检索器实例
+	rtr, err := es8.NewRetriever(context.Background(), retrieverConfig)
+	if err != nil {
+		log.Fatalf("new es retriever failed, err: %+v", err) // 创建失败时记录错误并退出
+	}
+	return rtr
+}
+
+// 解析es返回的命中结果
+func parseESHit(ctx context.Context, hit types.Hit) (doc *schema.Document, err error) {
+	// 创建基础文档对象
+	doc = &schema.Document{
+		ID:       *hit.Id_,         // 文档ID
+		MetaData: map[string]any{}, // 元数据字典
+	}
+
+	// 解析ES返回的源数据
+	var src map[string]any
+	if err = sonic.Unmarshal(hit.Source_, &src); err != nil {
+		return nil, err
+	}
+
+	// 遍历源数据中的每个字段，根据字段类型进行相应处理
+	for field, val := range src {
+		switch field {
+		case FieldContent: // 内容字段
+			doc.Content = val.(string) // 设置文档内容
+		case FieldContentVector: // 内容向量字段
+			var v []float64
+			// 将接口切片转换为float64切片
+			for _, item := range val.([]interface{}) {
+				v = append(v, item.(float64))
+			}
+			doc.WithDenseVector(v) // 设置文档的稠密向量
+		case FieldQAContentVector, FieldQAContent, FieldMeta: // QA相关字段和只用于过滤的元数据字段
+			// 这些字段不返回给客户端，跳过处理
+
+		case FieldExtra: // 额外信息字段
+			if val == nil {
+				continue // 空值跳过
+			}
+			doc.MetaData[FieldExtra] = val.(string) // 设置额外信息元数据
+		case KnowledgeName: // 知识库名称字段
+			doc.MetaData[KnowledgeName] = val.(string) // 设置知识库名称元数据
+		default: // 未知字段
+			return nil, fmt.Errorf("unexpected field=%s, val=%v", field, val)
+		}
+	}
+
+	// 如果命中结果有评分，设置文档评分
+	if hit.Score_ != nil {
+		doc.WithScore(float64(*hit.Score_))
+	}
+
+	return doc, nil
+}
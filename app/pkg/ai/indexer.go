@@ -5,10 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
+
+	"ragx/app/pkg/docparser"
+	"ragx/app/pkg/splitter"
 	"ragx/app/pkg/utils"
 
 	"github.com/elastic/go-elasticsearch/v8/typedapi/indices/create"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/indices/exists"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/indices/putmapping"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
 
 	"github.com/cloudwego/eino-ext/components/indexer/es8"
@@ -22,6 +28,8 @@ const (
 	KnowledgeName = "_knowledge_name"
 	// 扩展数据字段
 	FieldExtra = "ext"
+	// 可过滤的元数据字段，扩展数据中的字符串、数字和布尔值转换为字符串后保存，es中是flattened类型，只用于过滤
+	FieldMeta = "meta"
 	// 内容字段
 	FieldContent = "content"
 	// 内容向量字段
@@ -31,14 +39,31 @@ const (
 	// 问答内容向量字段
 	FieldQAContentVector = "qa_content_vector"
 
+	// 文件名
+	MetaFileName = "_file_name"
+	// 文件在压缩包内的目录
+	MetaPath = "_path"
+	// 网页的规范地址
+	MetaURL = "_url"
+	// 子块所属的父块id
+	MetaParentID = "parent_id"
+
 	Title1 = "h1"
 	Title2 = "h2"
 	Title3 = "h3"
+	Title4 = "h4"
+	Title5 = "h5"
+	Title6 = "h6"
 )
 
 var (
 	// ext 里面需要存储的数据
-	ExtKeys = []string{"_extension", "_file_name", "_source", "h1", "h2", "h3"}
+	ExtKeys = []string{"_extension", MetaFileName, "_source", MetaPath, MetaURL, "h1", "h2", "h3", "h4", "h5", "h6",
+		docparser.MetaSheet, docparser.MetaRow, docparser.MetaRowEnd, docparser.MetaSlideNumber, docparser.MetaTitle, docparser.MetaPage,
+		docparser.MetaSubject, docparser.MetaFrom, docparser.MetaTo, docparser.MetaDate, docparser.MetaMessageID,
+		docparser.MetaInReplyTo, docparser.MetaThreadID, docparser.MetaAttachment, docparser.MetaRecordID, docparser.MetaFields,
+		splitter.MetaLanguage, splitter.MetaPackage, splitter.MetaSymbol, splitter.MetaReceiver, splitter.MetaKind,
+		splitter.MetaLineStart, splitter.MetaLineEnd, MetaParentID, MetaSummary, MetaKeywords, MetaContextHeader}
 )
 
 // 创建一个新的索引器
@@ -51,8 +76,8 @@ func newIndexer(c *Client) indexer.Indexer {
 		Client: c.ESClient,
 		// 索引名称
 		Index: c.indexName,
-		// 批量处理大小
-		BatchSize: 10,
+		// 批量处理大小，每批的文本由嵌入器再分批并发请求
+		BatchSize: c.embedBatcher.Capacity(),
 		Embedding: c.Embedder,
 		// 将文档转换为索引字段的函数
 		DocumentToFields: func(ctx context.Context, doc *schema.Document) (field2Value map[string]es8.FieldValue, err error) {
@@ -65,15 +90,19 @@ func newIndexer(c *Client) indexer.Indexer {
 				return
 			}
 			// 处理文档元数据
+			var meta map[string]string
 			if doc.MetaData != nil {
-				extra, err := json.Marshal(getExtData(doc))
+				extra, err := ExtJSON(doc)
 				if err != nil {
 					return nil, err
 				}
-				doc.MetaData[FieldExtra] = string(extra)
+				doc.MetaData[FieldExtra] = extra
+				if meta, err = filterableMeta(extra); err != nil {
+					return nil, err
+				}
 			}
 			// 返回字段映射，包含内容、扩展数据、知识库名称和问答内容
-			return map[string]es8.FieldValue{
+			fields := map[string]es8.FieldValue{
 				// 内容字段
 				FieldContent: {
 					// 文档内容
@@ -85,17 +114,32 @@ func newIndexer(c *Client) indexer.Indexer {
 				FieldExtra: {
 					Value: doc.MetaData[FieldExtra],
 				},
+				// 可过滤的元数据字段
+				FieldMeta: {
+					Value: meta,
+				},
 				// 知识库名称字段
 				KnowledgeName: {
 					Value: knowledgeName,
 				},
-				// 问答内容字段
-				//FieldQAContent: {
-				//	Value: doc.MetaData[FieldQAContent],
-				//	// 问答内容向量字段
-				//	EmbedKey: FieldQAContentVector,
-				//},
-			}, nil
+			}
+			// 增强时指定了向量化前缀的，前缀和内容一起向量化，索引中保存的内容不变
+			if prefix, _ := doc.MetaData[MetaEmbedPrefix].(string); prefix != "" {
+				content := fields[FieldContent]
+				content.Stringify = func(val any) (string, error) {
+					return prefix + doc.Content, nil
+				}
+				fields[FieldContent] = content
+			}
+			// 问答内容字段，只有生成了问题的文档块才有
+			if qa, _ := doc.MetaData[FieldQAContent].(string); qa != "" {
+				fields[FieldQAContent] = es8.FieldValue{
+					Value: qa,
+					// 问答内容向量字段
+					EmbedKey: FieldQAContentVector,
+				}
+			}
+			return fields, nil
 		},
 	}
 	idx, err := es8.NewIndexer(context.Background(), config)
@@ -106,6 +150,7 @@ func newIndexer(c *Client) indexer.Indexer {
 }
 
 // es索引是否存在，不存在则创建
+// 已有的索引添加可过滤的元数据字段，添加之前写入的文档块没有这个字段，需要重新索引后才能按元数据过滤
 func createIndexIfNotExists(c *Client) error {
 	ctx := context.Background()
 	indexExists, err := exists.NewExistsFunc(c.ESClient)(c.indexName).Do(ctx)
@@ -113,21 +158,28 @@ func createIndexIfNotExists(c *Client) error {
 		return err
 	}
 	if indexExists {
-		return nil
+		_, err = putmapping.NewPutMappingFunc(c.ESClient)(c.indexName).Request(&putmapping.Request{
+			Properties: map[string]types.Property{
+				FieldMeta: types.NewFlattenedProperty(),
+			},
+		}).Do(ctx)
+		return err
 	}
 	_, err = create.NewCreateFunc(c.ESClient)(c.indexName).Request(&create.Request{
 		Mappings: &types.TypeMapping{
 			Properties: map[string]types.Property{
-				FieldContent:  types.NewTextProperty(),
-				FieldExtra:    types.NewTextProperty(),
-				KnowledgeName: types.NewKeywordProperty(),
+				FieldContent:   types.NewTextProperty(),
+				FieldExtra:     types.NewTextProperty(),
+				FieldMeta:      types.NewFlattenedProperty(),
+				FieldQAContent: types.NewTextProperty(),
+				KnowledgeName:  types.NewKeywordProperty(),
 				FieldContentVector: &types.DenseVectorProperty{
-					Dims:       utils.Ptr(1024), // same as embedding dimensions
+					Dims:       utils.Ptr(c.embeddingDims), // same as embedding dimensions
 					Index:      utils.Ptr(true),
 					Similarity: utils.Ptr("cosine"),
 				},
 				FieldQAContentVector: &types.DenseVectorProperty{
-					Dims:       utils.Ptr(1024), // same as embedding dimensions
+					Dims:       utils.Ptr(c.embeddingDims), // same as embedding dimensions
 					Index:      utils.Ptr(true),
 					Similarity: utils.Ptr("cosine"),
 				},
@@ -151,3 +203,38 @@ func getExtData(doc *schema.Document) map[string]any {
 	}
 	return res
 }
+
+// ExtJSON 文档的扩展数据，JSON格式，写入索引和数据库的文档块都使用这个格式
+func ExtJSON(doc *schema.Document) (string, error) {
+	extra, err := json.Marshal(getExtData(doc))
+	if err != nil {
+		return "", gerror.Wrap(err, "")
+	}
+	return string(extra), nil
+}
+
+// 扩展数据中可以精确过滤的字段，字符串、数字和布尔值转换为与postgres的jsonb ->>相同的文本，数组、对象和null不能过滤
+func filterableMeta(ext string) (map[string]string, error) {
+	if ext == "" {
+		return nil, nil
+	}
+	dec := json.NewDecoder(strings.NewReader(ext))
+	// 数字保持JSON中的原文，不转换为float64
+	dec.UseNumber()
+	var m map[string]any
+	if err := dec.Decode(&m); err != nil {
+		return nil, gerror.Wrap(err, "")
+	}
+	res := make(map[string]string, len(m))
+	for k, v := range m {
+		switch v := v.(type) {
+		case string:
+			res[k] = v
+		case json.Number:
+			res[k] = v.String()
+		case bool:
+			res[k] = strconv.FormatBool(v)
+		}
+	}
+	return res, nil
+}
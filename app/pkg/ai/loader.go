@@ -3,12 +3,14 @@ package ai
 import (
 	"context"
 	"log"
+	"maps"
+	"path"
+	"ragx/app/pkg/blob"
+	"ragx/app/pkg/docparser"
 	"ragx/app/pkg/utils"
 
 	"github.com/cloudwego/eino-ext/components/document/loader/file"
 	"github.com/cloudwego/eino-ext/components/document/loader/url"
-	"github.com/cloudwego/eino-ext/components/document/parser/html"
-	"github.com/cloudwego/eino-ext/components/document/parser/pdf"
 	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/components/document/parser"
 	"github.com/cloudwego/eino/schema"
@@ -16,29 +18,41 @@ import (
 )
 
 // 构建组合加载器
-func newLoader() document.Loader {
+func newLoader(c *Client) document.Loader {
 	ctx := context.Background()
 	l := &multiLoader{}
-	// 创建解析器
-	htmlParser, err := html.NewParser(ctx, &html.Config{
-		Selector: utils.Ptr("body"),
-	})
+	// 按页解析PDF，记录页码
+	pdfParser, err := docparser.NewPDFParser(ctx, c.pdf)
 	if err != nil {
-		log.Fatalf("new html parser failed, err: %+v", gerror.Wrap(err, ""))
+		log.Fatalf("new pdf parser failed, err: %+v", gerror.Wrap(err, ""))
 	}
 
-	pdfParser, err := pdf.NewPDFParser(ctx, &pdf.Config{})
+	// 注册特定扩展名的解析器
+	parsers := map[string]parser.Parser{
+		".html":  docparser.NewHTMLParser(),
+		".htm":   docparser.NewHTMLParser(),
+		".pdf":   pdfParser,
+		".docx":  docparser.NewDocxParser(),
+		".xlsx":  docparser.NewXlsxParser(c.spreadsheet),
+		".csv":   docparser.NewCSVParser(c.spreadsheet),
+		".pptx":  docparser.NewPptxParser(),
+		".json":  docparser.NewJSONParser(),
+		".jsonl": docparser.NewJSONLParser(),
+	}
+	// 邮件附件使用上面的解析器解析
+	attachments, err := parser.NewExtParser(ctx, &parser.ExtParserConfig{
+		Parsers:        maps.Clone(parsers),
+		FallbackParser: parser.TextParser{},
+	})
 	if err != nil {
-		log.Fatalf("new pdf parser failed, err: %+v", gerror.Wrap(err, ""))
+		log.Fatalf("new ext parser failed, err: %+v", gerror.Wrap(err, ""))
 	}
+	parsers[".eml"] = docparser.NewEmailParser(attachments)
+	parsers[".mbox"] = docparser.NewMboxParser(attachments)
 
 	// 创建扩展解析器
 	p, err := parser.NewExtParser(ctx, &parser.ExtParserConfig{
-		// 注册特定扩展名的解析器
-		Parsers: map[string]parser.Parser{
-			".html": htmlParser,
-			".pdf":  pdfParser,
-		},
+		Parsers: parsers,
 		// 设置默认解析器，用于处理未知格式
 		FallbackParser: parser.TextParser{},
 	})
@@ -62,6 +76,9 @@ func newLoader() document.Loader {
 		log.Fatalf("new url loader failed, err: %+v", gerror.Wrap(err, ""))
 	}
 	l.urlLoader = urlLoader
+	if c.blobStore != nil {
+		l.blobLoader = &blobLoader{store: c.blobStore, parser: p}
+	}
 	return l
 }
 
@@ -69,6 +86,7 @@ func newLoader() document.Loader {
 type multiLoader struct {
 	fileLoader document.Loader // 文件加载器，用于加载本地文件
 	urlLoader  document.Loader // URL加载器，用于加载远程URL内容
+	blobLoader document.Loader // blob加载器，用于加载blob存储中的文件
 }
 
 // 方法根据源URI的类型选择相应的加载器进行文档加载
@@ -77,6 +95,10 @@ type multiLoader struct {
 // opts: 加载选项，可配置加载行为
 // 返回值: 加载的文档列表和可能的错误
 func (m *multiLoader) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) ([]*schema.Document, error) {
+	// 存储在blob中的上传文件
+	if _, ok := blob.KeyFromURI(src.URI); ok && m.blobLoader != nil {
+		return m.blobLoader.Load(ctx, src, opts...)
+	}
 	// 判断源URI是否为URL
 	if utils.IsURL(src.URI) {
 		// 如果是URL，使用URL加载器
@@ -85,3 +107,27 @@ func (m *multiLoader) Load(ctx context.Context, src document.Source, opts ...doc
 	// 否则使用文件加载器
 	return m.fileLoader.Load(ctx, src, opts...)
 }
+
+// 从blob存储中读取文件并解析，元数据与文件加载器保持一致
+type blobLoader struct {
+	store  blob.BlobStore
+	parser parser.Parser
+}
+
+func (b *blobLoader) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) ([]*schema.Document, error) {
+	key, _ := blob.KeyFromURI(src.URI)
+	rc, err := b.store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	name := path.Base(key)
+	// 加载选项中的解析选项传给解析器，例如网页的正文选择器
+	loaderOpts := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
+	parserOpts := append([]parser.Option{parser.WithURI(src.URI), parser.WithExtraMeta(map[string]any{
+		MetaFileName: name,
+		"_extension": path.Ext(name),
+		"_source":    src.URI,
+	})}, loaderOpts.ParserOptions...)
+	return b.parser.Parse(ctx, rc, parserOpts...)
+}
@@ -1,5 +1,12 @@
 package ai
 
+import (
+	"ragx/app/pkg/blob"
+	"ragx/app/pkg/docparser"
+	"ragx/app/pkg/embedder"
+	"ragx/app/pkg/redact"
+)
+
 // 是用于配置Client的函数类型
 type ClientOption func(*Client)
 
@@ -68,3 +75,88 @@ func WithEmbeddingApiKey(apiKey string) ClientOption {
 		c.embeddingApiKey = apiKey
 	}
 }
+
+// 设置上传源文件的存储，加载器从中读取blob://开头的文件
+func WithBlobStore(store blob.BlobStore) ClientOption {
+	return func(c *Client) {
+		c.blobStore = store
+	}
+}
+
+// 设置表格解析配置，包括表头识别方式和每个文档包含的行数
+func WithSpreadsheetConfig(conf *docparser.SpreadsheetConfig) ClientOption {
+	return func(c *Client) {
+		c.spreadsheet = conf
+	}
+}
+
+// 设置PDF解析配置
+func WithPDFConfig(conf *docparser.PDFConfig) ClientOption {
+	return func(c *Client) {
+		c.pdf = conf
+	}
+}
+
+// 设置文档分块配置，块的大小和重叠都以token计
+func WithChunkConfig(conf *ChunkConfig) ClientOption {
+	return func(c *Client) {
+		c.chunk = conf
+	}
+}
+
+// 设置为每个文档块生成的问题数，问题向量化后与内容向量一起检索，0表示不生成
+func WithQuestionsPerChunk(n int) ClientOption {
+	return func(c *Client) {
+		c.questionsPerChunk = n
+	}
+}
+
+// 设置增强文档块时同时调用对话模型的数量，<=0时使用DefaultEnrichConcurrency
+func WithEnrichConcurrency(n int) ClientOption {
+	return func(c *Client) {
+		c.enrichConcurrency = n
+	}
+}
+
+// 设置脱敏配置，建立索引前处理文档中的敏感信息，为空时不脱敏
+func WithRedactConfig(conf *redact.Config) ClientOption {
+	return func(c *Client) {
+		c.redact = conf
+	}
+}
+
+// 设置嵌入器配置，可以使用方舟、兼容OpenAI或Ollama接口的服务，以及不需要网络的本地哈希嵌入器
+// 为空时使用方舟，模型和密钥由WithEmbeddingModelName、WithEmbeddingApiKey设置
+func WithEmbedderConfig(conf *embedder.Config) ClientOption {
+	return func(c *Client) {
+		c.embedding = conf
+	}
+}
+
+// 设置批量嵌入配置，包括向量缓存、每次请求的文本数、并发数和重试次数
+func WithEmbedBatchConfig(conf *embedder.BatchConfig) ClientOption {
+	return func(c *Client) {
+		c.embedBatch = conf
+	}
+}
+
+// 设置向量存储类型：es、pgvector，为空时使用es，pgvector需要通过WithPGVectorConfig设置数据库
+func WithVectorStore(name string) ClientOption {
+	return func(c *Client) {
+		c.vectorStore = name
+	}
+}
+
+// 设置pgvector向量存储配置，包括数据库、文档块表和HNSW索引参数
+func WithPGVectorConfig(conf *PGVectorConfig) ClientOption {
+	return func(c *Client) {
+		c.pgvector = conf
+	}
+}
+
+// 设置是否使用混合检索，向量检索和全文检索的结果按RRF融合，检索结果的得分是RRF得分
+func WithHybridSearch(hybrid bool) ClientOption {
+	return func(c *Client) {
+		c.hybrid = hybrid
+	}
+}
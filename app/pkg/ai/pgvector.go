@@ -0,0 +1,349 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"gorm.io/gorm"
+)
+
+const (
+	// HNSW索引每个节点的默认最大连接数
+	defaultHNSWM = 16
+	// 构建HNSW索引时默认的候选列表大小
+	defaultHNSWEfConstruction = 64
+	// pgvector的HNSW索引支持的最大维度
+	maxHNSWDimensions = 2000
+	// 检索时从HNSW索引取出的候选行是topK的倍数，去重后仍能凑满topK个文档块
+	pgvectorCandidates = 4
+	// 全文检索默认的文本搜索配置，只按空白和标点分词，不做词干化
+	defaultTextSearchConfig = "simple"
+)
+
+// 文本搜索配置的名称，会拼接到建索引和检索的语句中
+var textSearchConfigRe = regexp.MustCompile(`^[a-z_][a-z0-9_]*(\.[a-z_][a-z0-9_]*)?$`)
+
+// PGVectorConfig pgvector向量存储配置
+type PGVectorConfig struct {
+	// 数据库连接
+	DB *gorm.DB
+	// 文档块表，需要有chunk_id、content、ext、knowledge_base_name、is_parent、status列
+	// 向量保存在这个表的embedding、qa_embedding列上，启动时自动添加这些列和HNSW索引
+	Table string
+	// 生效中的文档块的status，只检索这个状态的行
+	ActiveStatus int32
+	// HNSW索引每个节点的最大连接数，<=0时使用16
+	M int
+	// 构建HNSW索引时的候选列表大小，<=0时使用64
+	EfConstruction int
+	// 检索时的候选列表大小，需要不小于topK*4，<=0时使用数据库的设置(默认40)
+	EfSearch int
+	// 全文检索的文本搜索配置，为空时使用simple，simple不能切分中文，中文需要安装分词扩展并使用它的配置
+	TextSearchConfig string
+}
+
+// 基于postgres pgvector扩展的向量存储，向量保存在文档块表上，不单独建表
+// 写入前文档块必须已经保存到表中，Store只更新写入时已经存在的行，之后复用文档块的新行由Reuse复制向量
+// 每个生效中的行都有自己的向量，文档被替代后仍然能通过其它引用者的行检索到，检索时按chunk_id去重
+type pgvectorStore struct {
+	db           *gorm.DB
+	table        string
+	activeStatus int32
+	efSearch     int
+	tsConfig     string
+	embedder     embedding.Embedder
+}
+
+// 创建pgvector向量存储，添加向量列和HNSW索引，dims为嵌入器的向量维度
+func newPGVectorStore(conf *PGVectorConfig, emb embedding.Embedder, dims int) (*pgvectorStore, error) {
+	if conf == nil || conf.DB == nil {
+		return nil, gerror.New("pgvector requires a database")
+	}
+	if conf.Table == "" {
+		return nil, gerror.New("pgvector requires a table")
+	}
+	if dims <= 0 || dims > maxHNSWDimensions {
+		return nil, gerror.Newf("pgvector hnsw index supports 1 to %d dimensions, got %d", maxHNSWDimensions, dims)
+	}
+	s := &pgvectorStore{db: conf.DB, table: conf.Table, activeStatus: conf.ActiveStatus, efSearch: conf.EfSearch,
+		tsConfig: conf.TextSearchConfig, embedder: emb}
+	if s.tsConfig == "" {
+		s.tsConfig = defaultTextSearchConfig
+	}
+	if !textSearchConfigRe.MatchString(s.tsConfig) {
+		return nil, gerror.Newf("invalid text search config: %q", s.tsConfig)
+	}
+	m, efConstruction := conf.M, conf.EfConstruction
+	if m <= 0 {
+		m = defaultHNSWM
+	}
+	if efConstruction <= 0 {
+		efConstruction = defaultHNSWEfConstruction
+	}
+	if err := s.migrate(dims, m, efConstruction); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// 添加向量列、HNSW索引和全文检索的GIN索引，已有的向量列维度与嵌入器不一致时返回错误
+func (s *pgvectorStore) migrate(dims, m, efConstruction int) error {
+	stmts := []string{
+		"CREATE EXTENSION IF NOT EXISTS vector",
+		fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS embedding vector(%d)", s.table, dims),
+		fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS qa_content text", s.table),
+		fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS qa_embedding vector(%d)", s.table, dims),
+	}
+	for _, col := range []string{"embedding", "qa_embedding"} {
+		stmts = append(stmts, fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%s_%s ON %s USING hnsw (%s vector_cosine_ops) WITH (m = %d, ef_construction = %d)",
+			s.table, col, s.table, col, m, efConstruction))
+	}
+	// 检索时的表达式需要与索引的表达式相同才能使用索引
+	stmts = append(stmts, fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%s_content_tsv ON %s USING gin (%s)", s.table, s.table, tsVector(s.tsConfig)))
+	for _, stmt := range stmts {
+		if err := s.db.Exec(stmt).Error; err != nil {
+			return gerror.Wrap(err, stmt)
+		}
+	}
+	// vector类型的atttypmod就是维度
+	var existing []int
+	err := s.db.Raw("SELECT atttypmod FROM pg_attribute WHERE attrelid = ?::regclass AND attname IN ('embedding', 'qa_embedding')", s.table).
+		Scan(&existing).Error
+	if err != nil {
+		return gerror.Wrap(err, "")
+	}
+	for _, d := range existing {
+		if d != dims {
+			return gerror.Newf("%s.embedding has %d dimensions, but the embedder returns %d", s.table, d, dims)
+		}
+	}
+	return nil
+}
+
+func (s *pgvectorStore) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) ([]string, error) {
+	knowledgeName, ok := ctx.Value(KnowledgeName).(string)
+	if !ok {
+		return nil, gerror.New("必须提供知识库名称")
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+	o := indexer.GetCommonOptions(&indexer.Options{Embedding: s.embedder}, opts...)
+	// 内容和问题一起向量化，增强时指定了向量化前缀的，前缀和内容一起向量化
+	texts := make([]string, 0, len(docs)*2)
+	for _, doc := range docs {
+		prefix, _ := doc.MetaData[MetaEmbedPrefix].(string)
+		texts = append(texts, prefix+doc.Content)
+	}
+	qaIdx := make([]int, len(docs))
+	for i, doc := range docs {
+		qaIdx[i] = -1
+		if qa, _ := doc.MetaData[FieldQAContent].(string); qa != "" {
+			qaIdx[i] = len(texts)
+			texts = append(texts, qa)
+		}
+	}
+	vectors, err := o.Embedding.EmbedStrings(ctx, texts)
+	if err != nil {
+		return nil, gerror.Wrap(err, "")
+	}
+	if len(vectors) != len(texts) {
+		return nil, gerror.Newf("got %d embeddings, want %d", len(vectors), len(texts))
+	}
+
+	ids := make([]string, 0, len(docs))
+	stmt := fmt.Sprintf("UPDATE %s SET embedding = ?::vector, qa_content = ?, qa_embedding = ?::vector WHERE chunk_id = ? AND knowledge_base_name = ?", s.table)
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, doc := range docs {
+			var qa, qaVector any
+			if qaIdx[i] >= 0 {
+				qa, qaVector = doc.MetaData[FieldQAContent], vectorLiteral(vectors[qaIdx[i]])
+			}
+			res := tx.Exec(stmt, vectorLiteral(vectors[i]), qa, qaVector, doc.ID, knowledgeName)
+			if res.Error != nil {
+				return gerror.Wrap(res.Error, "")
+			}
+			if res.RowsAffected == 0 {
+				return gerror.Newf("chunk %s not found in %s", doc.ID, s.table)
+			}
+			ids = append(ids, doc.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// 检索结果的一行
+type pgvectorHit struct {
+	ChunkID           string  `gorm:"column:chunk_id"`
+	Content           string  `gorm:"column:content"`
+	Ext               string  `gorm:"column:ext"`
+	KnowledgeBaseName string  `gorm:"column:knowledge_base_name"`
+	Score             float64 `gorm:"column:score"`
+}
+
+func (s *pgvectorStore) Search(ctx context.Context, query string, opts *SearchOptions) ([]*schema.Document, error) {
+	col := "embedding"
+	switch {
+	case opts.FullText, opts.VectorField == "", opts.VectorField == FieldContentVector:
+	case opts.VectorField == FieldQAContentVector:
+		col = "qa_embedding"
+	default:
+		return nil, gerror.Newf("unsupported vector field: %s", opts.VectorField)
+	}
+	var stmt string
+	var args []any
+	if opts.FullText {
+		stmt, args = fullTextSQL(s.table, s.tsConfig, s.activeStatus, query, opts)
+	} else {
+		vectors, err := s.embedder.EmbedStrings(ctx, []string{query})
+		if err != nil {
+			return nil, gerror.Wrap(err, "")
+		}
+		if len(vectors) != 1 {
+			return nil, gerror.Newf("got %d embeddings, want 1", len(vectors))
+		}
+		stmt, args = searchSQL(s.table, col, s.activeStatus, vectorLiteral(vectors[0]), opts)
+	}
+
+	var hits []*pgvectorHit
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if s.efSearch > 0 && !opts.FullText {
+			if err := tx.Exec("SET LOCAL hnsw.ef_search = " + strconv.Itoa(s.efSearch)).Error; err != nil {
+				return gerror.Wrap(err, "")
+			}
+		}
+		return gerror.Wrap(tx.Raw(stmt, args...).Scan(&hits).Error, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]*schema.Document, 0, len(hits))
+	for _, h := range hits {
+		doc := &schema.Document{
+			ID:       h.ChunkID,
+			Content:  h.Content,
+			MetaData: map[string]any{FieldExtra: h.Ext, KnowledgeName: h.KnowledgeBaseName},
+		}
+		docs = append(docs, doc.WithScore(h.Score))
+	}
+	return docs, nil
+}
+
+// 生成检索语句，只检索生效中的子块和普通块
+// 多个文档复用同一个文档块时有多行相同chunk_id的向量，先用HNSW索引取出topK*pgvectorCandidates个候选行，
+// 按chunk_id去重后再按距离排序取前topK个，避免重复的行挤占结果
+// <=>是余弦距离，2减去余弦距离就是余弦相似度加1，与es的得分一致
+func searchSQL(table, col string, activeStatus int32, vec string, opts *SearchOptions) (string, []any) {
+	conds := []string{col + " IS NOT NULL", "is_parent = false", "status = ?"}
+	args := []any{vec, activeStatus}
+	conds, args = filterConds(conds, args, opts.Filters)
+	candidates := fmt.Sprintf("SELECT chunk_id, content, ext, knowledge_base_name, %s <=> ?::vector AS distance FROM %s WHERE %s ORDER BY distance",
+		col, table, strings.Join(conds, " AND "))
+	if opts.TopK > 0 {
+		candidates += " LIMIT ?"
+		args = append(args, opts.TopK*pgvectorCandidates)
+	}
+	stmt := "SELECT chunk_id, content, ext, knowledge_base_name, 2 - distance AS score FROM (" +
+		"SELECT DISTINCT ON (chunk_id) * FROM (" + candidates + ") candidates ORDER BY chunk_id, distance) hits"
+	if opts.ScoreThreshold > 0 {
+		stmt += " WHERE 2 - distance >= ?"
+		args = append(args, opts.ScoreThreshold)
+	}
+	stmt += " ORDER BY distance"
+	if opts.TopK > 0 {
+		stmt += " LIMIT ?"
+		args = append(args, opts.TopK)
+	}
+	return stmt, args
+}
+
+// 生成全文检索语句，只检索生效中的子块和普通块，得分是ts_rank，相同chunk_id的行内容相同，去重后按得分排序
+func fullTextSQL(table, tsConfig string, activeStatus int32, query string, opts *SearchOptions) (string, []any) {
+	conds := []string{tsVector(tsConfig) + " @@ q", "is_parent = false", "status = ?"}
+	args := []any{query, activeStatus}
+	conds, args = filterConds(conds, args, opts.Filters)
+	stmt := fmt.Sprintf("SELECT chunk_id, content, ext, knowledge_base_name, score FROM ("+
+		"SELECT DISTINCT ON (chunk_id) chunk_id, content, ext, knowledge_base_name, ts_rank(%s, q) AS score "+
+		"FROM %s, plainto_tsquery('%s', ?) q WHERE %s ORDER BY chunk_id) hits ORDER BY score DESC",
+		tsVector(tsConfig), table, tsConfig, strings.Join(conds, " AND "))
+	if opts.TopK > 0 {
+		stmt += " LIMIT ?"
+		args = append(args, opts.TopK)
+	}
+	return stmt, args
+}
+
+// 全文检索的文档向量表达式，与GIN索引的表达式相同
+func tsVector(tsConfig string) string {
+	return fmt.Sprintf("to_tsvector('%s', content)", tsConfig)
+}
+
+// 添加元数据过滤条件，过滤条件按键排序
+// 扩展数据只匹配字符串、数字和布尔值，与es可过滤的元数据字段一致，数组和对象转换成的文本不会被匹配
+func filterConds(conds []string, args []any, filters map[string]string) ([]string, []any) {
+	keys := make([]string, 0, len(filters))
+	for k := range filters {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if k == KnowledgeName {
+			conds = append(conds, "knowledge_base_name = ?")
+			args = append(args, filters[k])
+			continue
+		}
+		conds = append(conds, "jsonb_typeof(NULLIF(ext, '')::jsonb -> ?) IN ('string', 'number', 'boolean') AND NULLIF(ext, '')::jsonb ->> ? = ?")
+		args = append(args, k, k, filters[k])
+	}
+	return conds, args
+}
+
+// Reuse 把已有行的向量复制到复用同一个文档块的新行上，新行保存时没有向量
+func (s *pgvectorStore) Reuse(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	stmt := fmt.Sprintf("UPDATE %[1]s t SET embedding = src.embedding, qa_content = src.qa_content, qa_embedding = src.qa_embedding "+
+		"FROM (SELECT DISTINCT ON (chunk_id) chunk_id, embedding, qa_content, qa_embedding FROM %[1]s "+
+		"WHERE chunk_id IN ? AND embedding IS NOT NULL ORDER BY chunk_id, id) src "+
+		"WHERE t.chunk_id = src.chunk_id AND t.embedding IS NULL", s.table)
+	return gerror.Wrap(s.db.WithContext(ctx).Exec(stmt, ids).Error, "")
+}
+
+// 清空文档块的向量，文档块表中的行由调用方管理
+func (s *pgvectorStore) Delete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	err := s.db.WithContext(ctx).Table(s.table).Where("chunk_id IN ?", ids).
+		Updates(map[string]any{"embedding": nil, "qa_content": nil, "qa_embedding": nil}).Error
+	return gerror.Wrap(err, "")
+}
+
+// 向量的文本形式，例如[0.1,0.2]，写入时转换为vector类型，pgvector以float32保存，按float32的精度输出
+func vectorLiteral(v []float64) string {
+	var b strings.Builder
+	b.Grow(len(v) * 10)
+	b.WriteByte('[')
+	for i, x := range v {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(x, 'g', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
@@ -0,0 +1,96 @@
+package ai
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestVectorLiteral(t *testing.T) {
+	tests := []struct {
+		v    []float64
+		want string
+	}{
+		{nil, "[]"},
+		{[]float64{1, -0.5, 0}, "[1,-0.5,0]"},
+		// 按float32的精度输出
+		{[]float64{0.1, 1.0 / 3}, "[0.1,0.33333334]"},
+		{[]float64{1e-8}, "[1e-08]"},
+	}
+	for _, tt := range tests {
+		if got := vectorLiteral(tt.v); got != tt.want {
+			t.Errorf("vectorLiteral(%v) = %s, want %s", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestSearchSQL(t *testing.T) {
+	stmt, args := searchSQL("knowledge_chunk", "qa_embedding", 2, "[1,0]", &SearchOptions{
+		TopK:           5,
+		ScoreThreshold: 1.2,
+		Filters:        map[string]string{"_page": "3", KnowledgeName: "kb"},
+	})
+	for _, s := range []string{
+		"FROM knowledge_chunk WHERE qa_embedding IS NOT NULL AND is_parent = false AND status = ? AND knowledge_base_name = ? AND " +
+			"jsonb_typeof(NULLIF(ext, '')::jsonb -> ?) IN ('string', 'number', 'boolean') AND NULLIF(ext, '')::jsonb ->> ? = ?",
+		// 先用HNSW索引取出候选行，按chunk_id去重后再排序取topK
+		"ORDER BY distance LIMIT ?) candidates ORDER BY chunk_id, distance) hits",
+		"SELECT DISTINCT ON (chunk_id) *",
+		// 得分和es一样是余弦相似度加1
+		"2 - distance AS score",
+		"WHERE 2 - distance >= ? ORDER BY distance LIMIT ?",
+	} {
+		if !strings.Contains(stmt, s) {
+			t.Errorf("stmt %q\nmissing %q", stmt, s)
+		}
+	}
+	// 过滤条件按键排序，_knowledge_name排在_page前面
+	want := []any{"[1,0]", int32(2), "kb", "_page", "_page", "3", 5 * pgvectorCandidates, 1.2, 5}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+	if n := strings.Count(stmt, "?"); n != len(args) {
+		t.Errorf("%d placeholders, %d args", n, len(args))
+	}
+
+	// 没有topK和阈值时不限制数量
+	stmt, args = searchSQL("knowledge_chunk", "embedding", 2, "[1,0]", &SearchOptions{})
+	if strings.Contains(stmt, "LIMIT") || strings.Contains(stmt, ">=") || len(args) != 2 {
+		t.Errorf("stmt = %q, args = %v", stmt, args)
+	}
+}
+
+func TestFullTextSQL(t *testing.T) {
+	stmt, args := fullTextSQL("knowledge_chunk", "simple", 2, "安装 手册", &SearchOptions{
+		TopK:           5,
+		ScoreThreshold: 1.2,
+		Filters:        map[string]string{"_page": "3", KnowledgeName: "kb"},
+	})
+	for _, s := range []string{
+		// 与GIN索引的表达式相同才能使用索引
+		"FROM knowledge_chunk, plainto_tsquery('simple', ?) q WHERE to_tsvector('simple', content) @@ q AND is_parent = false AND status = ? AND knowledge_base_name = ?",
+		"ts_rank(to_tsvector('simple', content), q) AS score",
+		"SELECT DISTINCT ON (chunk_id)",
+		"ORDER BY score DESC LIMIT ?",
+	} {
+		if !strings.Contains(stmt, s) {
+			t.Errorf("stmt %q\nmissing %q", stmt, s)
+		}
+	}
+	// 全文检索的得分不是相似度，不使用得分阈值
+	want := []any{"安装 手册", int32(2), "kb", "_page", "_page", "3", 5}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+	if n := strings.Count(stmt, "?"); n != len(args) {
+		t.Errorf("%d placeholders, %d args", n, len(args))
+	}
+}
+
+func TestTextSearchConfig(t *testing.T) {
+	for name, ok := range map[string]bool{"simple": true, "pg_catalog.english": true, "zh_cfg": true, "english'); DROP TABLE x; --": false, "English": false} {
+		if got := textSearchConfigRe.MatchString(name); got != ok {
+			t.Errorf("textSearchConfigRe.MatchString(%q) = %v, want %v", name, got, ok)
+		}
+	}
+}
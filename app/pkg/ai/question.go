@@ -0,0 +1,101 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// 同时生成问题的文档块数量
+	questionConcurrency = 4
+	// 生成问题的提示词
+	questionPrompt = "你是一个知识库助手。根据用户提供的文档片段，列出%d个用户最可能提出、并且能用这段内容回答的问题。" +
+		"问题要具体、独立成句，使用与文档相同的语言，每行一个问题，不要编号，不要输出其它内容。"
+)
+
+// 去掉模型输出的问题前面的编号，例如"1. "、"- "、"Q: "
+// 编号后的.需要跟着非数字，"3.5版本"中的3.不是编号，跟在.后面的字符保存在第一个分组中
+var questionPrefixRe = regexp.MustCompile(`^\s*(?:\d+(?:[、)）]|\.(\D|$))|[-*•]|[QqＱ问][:：])\s*`)
+
+// GenerateQuestions 使用对话模型为每个文档块生成用户可能提出的问题，每行一个，保存在元数据的FieldQAContent中
+// 写入索引时问题会向量化到qa_content_vector，检索时与内容向量一起搜索，问句形式的查询更容易命中
+// 没有启用时直接返回，单个文档块生成失败时跳过该文档块，返回所有失败的原因
+func (c *Client) GenerateQuestions(ctx context.Context, docs []*schema.Document) error {
+	if c.questionsPerChunk <= 0 || len(docs) == 0 {
+		return nil
+	}
+	// 分割出的文档块可能共用同一个元数据，全部生成完后再统一写入
+	res := make([][]string, len(docs))
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	sem := make(chan struct{}, questionConcurrency)
+	for i, doc := range docs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, doc *schema.Document) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			questions, err := c.questions(ctx, doc.Content)
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, gerror.Wrapf(err, "generate questions for chunk %s", doc.ID))
+				mu.Unlock()
+				return
+			}
+			res[i] = questions
+		}(i, doc)
+	}
+	wg.Wait()
+	for i, doc := range docs {
+		if len(res[i]) == 0 {
+			continue
+		}
+		meta := make(map[string]any, len(doc.MetaData)+1)
+		for k, v := range doc.MetaData {
+			meta[k] = v
+		}
+		meta[FieldQAContent] = strings.Join(res[i], "\n")
+		doc.MetaData = meta
+	}
+	return errors.Join(errs...)
+}
+
+// 为一段内容生成问题
+func (c *Client) questions(ctx context.Context, content string) ([]string, error) {
+	msg, err := c.ChatModel.Generate(ctx, []*schema.Message{
+		schema.SystemMessage(fmt.Sprintf(questionPrompt, c.questionsPerChunk)),
+		schema.UserMessage(content),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	for _, line := range strings.Split(msg.Content, "\n") {
+		q := trimQuestionPrefix(line)
+		if q == "" {
+			continue
+		}
+		res = append(res, q)
+		if len(res) == c.questionsPerChunk {
+			break
+		}
+	}
+	return res, nil
+}
+
+// 去掉问题前面的编号和首尾空白
+func trimQuestionPrefix(line string) string {
+	return strings.TrimSpace(questionPrefixRe.ReplaceAllString(line, "${1}"))
+}
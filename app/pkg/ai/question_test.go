@@ -0,0 +1,133 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// 按最后一条用户消息返回固定回复的对话模型，记录收到的消息
+type fakeChatModel struct {
+	mu       sync.Mutex
+	inputs   [][]*schema.Message
+	generate func(system, user string) (string, error)
+}
+
+func (m *fakeChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	m.mu.Lock()
+	m.inputs = append(m.inputs, input)
+	m.mu.Unlock()
+	content, err := m.generate(input[0].Content, input[len(input)-1].Content)
+	if err != nil {
+		return nil, err
+	}
+	msg := schema.AssistantMessage(content, nil)
+	msg.ResponseMeta = &schema.ResponseMeta{Usage: &schema.TokenUsage{PromptTokens: 10, CompletionTokens: 3}}
+	return msg, nil
+}
+
+func (m *fakeChatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	return m, nil
+}
+
+func (m *fakeChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	msg, err := m.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{msg}), nil
+}
+
+func TestTrimQuestionPrefix(t *testing.T) {
+	tests := []struct {
+		line, want string
+	}{
+		{"如何安装？", "如何安装？"},
+		{"1. 如何安装？", "如何安装？"},
+		{"  12.如何安装？", "如何安装？"},
+		{"2、如何配置？", "如何配置？"},
+		{"3) How to install?", "How to install?"},
+		{"4）如何升级？", "如何升级？"},
+		{"- 如何卸载？", "如何卸载？"},
+		{"* 如何卸载？", "如何卸载？"},
+		{"• 如何卸载？", "如何卸载？"},
+		{"Q: What is RAG?", "What is RAG?"},
+		{"q：什么是RAG？", "什么是RAG？"},
+		{"Ｑ：什么是RAG？", "什么是RAG？"},
+		{"问：什么是RAG？", "什么是RAG？"},
+		// 问题本身以数字开头时不去掉
+		{"3.5版本有哪些新功能？", "3.5版本有哪些新功能？"},
+		{"2024年的营收是多少？", "2024年的营收是多少？"},
+		{"1. 3.5版本有哪些新功能？", "3.5版本有哪些新功能？"},
+		{"问题是什么？", "问题是什么？"},
+		{"1.", ""},
+		{"   ", ""},
+	}
+	for _, tt := range tests {
+		if got := trimQuestionPrefix(tt.line); got != tt.want {
+			t.Errorf("trimQuestionPrefix(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestQuestions(t *testing.T) {
+	m := &fakeChatModel{generate: func(system, user string) (string, error) {
+		return "1. 如何安装？\n\n2. 如何配置？\r\n- 如何升级？\n3、如何卸载？", nil
+	}}
+	c := &Client{ChatModel: m, questionsPerChunk: 3}
+	res, err := c.questions(context.Background(), "安装手册")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 跳过空行，最多保留questionsPerChunk个问题
+	if strings.Join(res, "|") != "如何安装？|如何配置？|如何升级？" {
+		t.Errorf("questions = %q", res)
+	}
+	if system := m.inputs[0][0].Content; !strings.Contains(system, "列出3个") {
+		t.Errorf("system prompt = %q", system)
+	}
+}
+
+func TestGenerateQuestions(t *testing.T) {
+	m := &fakeChatModel{generate: func(system, user string) (string, error) {
+		if user == "失败" {
+			return "", errors.New("model unavailable")
+		}
+		return "1. " + user + "是什么？\n2. 如何使用" + user + "？", nil
+	}}
+	c := &Client{ChatModel: m, questionsPerChunk: 2}
+	// 分割出的文档块共用同一个元数据，并发生成时不能写入共用的元数据
+	shared := map[string]any{MetaFileName: "a.md"}
+	docs := make([]*schema.Document, 0, 20)
+	for i := range 20 {
+		docs = append(docs, &schema.Document{ID: fmt.Sprint(i), Content: fmt.Sprintf("功能%d", i), MetaData: shared})
+	}
+	docs = append(docs, &schema.Document{ID: "bad", Content: "失败", MetaData: shared})
+	err := c.GenerateQuestions(context.Background(), docs)
+	if err == nil || !strings.Contains(err.Error(), "chunk bad") {
+		t.Fatalf("err = %v", err)
+	}
+	for i, doc := range docs[:20] {
+		want := fmt.Sprintf("功能%d是什么？\n如何使用功能%d？", i, i)
+		if doc.MetaData[FieldQAContent] != want || doc.MetaData[MetaFileName] != "a.md" {
+			t.Errorf("doc %s metadata = %v", doc.ID, doc.MetaData)
+		}
+	}
+	// 失败的文档块没有问题，共用的元数据不变
+	if _, ok := docs[20].MetaData[FieldQAContent]; ok || len(shared) != 1 {
+		t.Errorf("failed doc metadata = %v, shared = %v", docs[20].MetaData, shared)
+	}
+
+	// 没有启用时不调用模型
+	m.inputs = nil
+	c.questionsPerChunk = 0
+	if err = c.GenerateQuestions(context.Background(), docs); err != nil || len(m.inputs) != 0 {
+		t.Errorf("err = %v, %d calls", err, len(m.inputs))
+	}
+}
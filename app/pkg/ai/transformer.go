@@ -4,29 +4,118 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"ragx/app/pkg/splitter"
+	"ragx/app/pkg/tokenizer"
 	"ragx/app/pkg/utils"
 	"strings"
 
 	"github.com/cloudwego/eino-ext/components/document/loader/file"
-	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/markdown"
 	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/recursive"
 	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/embedding"
 	"github.com/cloudwego/eino/schema"
 	"github.com/gogf/gf/v2/errors/gerror"
 )
 
-// 创建一个新的组合转换器
-func NewMultiTransformer() document.Transformer {
+const (
+	// 默认文档块的大小上限，token数
+	DefaultChunkSize = 512
+	// 默认相邻文档块的重叠，token数
+	DefaultOverlapSize = 64
+	// 默认markdown文档块合并后的大小上限，token数
+	DefaultMergeSize = 256
+	// 默认子块的大小上限，token数
+	DefaultChildChunkSize = 128
+
+	// 按分隔符递归分割为固定大小的文档块
+	ChunkStrategyRecursive = "recursive"
+	// 按相邻句子的语义相似度分割，需要向量化每个句子
+	ChunkStrategySemantic = "semantic"
+)
+
+// 分割文本使用的分隔符：换行符、中文句号、中英文问号和感叹号
+var chunkSeparators = []string{"\n", "。", "?", "？", "!", "！"}
+
+// ChunkConfig 文档分块配置，大小和重叠都以token计，中英文文本的块大小基本一致，也不会超过嵌入模型的输入上限
+type ChunkConfig struct {
+	// 分词器：estimate按字符类别估算，bpe使用BPE词表精确计算，默认estimate
+	Tokenizer string
+	// BPE词表的编码名称，默认cl100k_base
+	Encoding string
+	// 文档块的大小上限，<=0时使用DefaultChunkSize
+	ChunkSize int
+	// 相邻文档块的重叠，<=0时使用DefaultOverlapSize
+	OverlapSize int
+	// markdown文档块合并后的大小上限，<=0时使用DefaultMergeSize
+	MergeSize int
+	// 默认的分块策略：recursive、semantic，默认recursive，上传文档时可以单独指定
+	Strategy string
+	// 语义分块时，相邻句子的相似度低于这个百分位时切分，<=0时使用splitter.DefaultBreakpointPercentile
+	BreakpointPercentile float64
+	// 语义分块时文档块的大小下限，<=0时使用splitter.DefaultSemanticMinSize，上限为ChunkSize
+	MinChunkSize int
+	// 父子块检索：分割出的文档块作为父块只保存在数据库，再分割为较小的子块写入索引
+	ParentChild bool
+	// 子块的大小上限，<=0时使用DefaultChildChunkSize
+	ChildChunkSize int
+}
+
+// 填充默认值
+func (c *ChunkConfig) withDefaults() *ChunkConfig {
+	res := ChunkConfig{}
+	if c != nil {
+		res = *c
+	}
+	if res.ChunkSize <= 0 {
+		res.ChunkSize = DefaultChunkSize
+	}
+	if res.OverlapSize <= 0 {
+		res.OverlapSize = DefaultOverlapSize
+	}
+	// 重叠不能超过块大小
+	res.OverlapSize = min(res.OverlapSize, res.ChunkSize/2)
+	if res.MergeSize <= 0 {
+		res.MergeSize = DefaultMergeSize
+	}
+	if res.Strategy == "" {
+		res.Strategy = ChunkStrategyRecursive
+	}
+	if res.ChildChunkSize <= 0 {
+		res.ChildChunkSize = DefaultChildChunkSize
+	}
+	res.ChildChunkSize = min(res.ChildChunkSize, res.ChunkSize)
+	return &res
+}
+
+// 转换器的选项
+type transformOptions struct {
+	// 分块策略
+	strategy string
+}
+
+// WithChunkStrategy 指定文档的分块策略，为空时使用配置的默认策略
+func WithChunkStrategy(strategy string) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *transformOptions) {
+		if strategy != "" {
+			o.strategy = strategy
+		}
+	})
+}
+
+// 创建一个新的组合转换器，tok用于计算文档块的token数，embedder用于语义分块
+func NewMultiTransformer(tok tokenizer.Tokenizer, embedder embedding.Embedder, conf *ChunkConfig) document.Transformer {
+	conf = conf.withDefaults()
 	// 初始化基础转换器实例
-	trans := &multiTransformer{}
+	trans := &multiTransformer{strategy: conf.Strategy}
 	// 创建上下文对象，用于控制请求生命周期和传递元数据
 	ctx := context.Background()
 
 	// 配置递归分割器参数
 	config := &recursive.Config{
-		ChunkSize:   1000,                                    // 每个文本块大小为1000个字符
-		OverlapSize: 100,                                     // 块之间有100个字符的重叠（10%），避免上下文断裂
-		Separators:  []string{"\n", "。", "?", "？", "!", "！"}, // 分割符：换行符、中文句号、中英文问号和感叹号
+		ChunkSize:   conf.ChunkSize,   // 每个文本块的token数上限
+		OverlapSize: conf.OverlapSize, // 块之间重叠的token数，避免上下文断裂
+		Separators:  chunkSeparators,  // 分割符
+		LenFunc:     tok.Count,        // 按token计算长度
 	}
 
 	// 创建递归分割器实例
@@ -35,34 +124,88 @@ func NewMultiTransformer() document.Transformer {
 		log.Fatalf("create recursive splitter failed, err: %v", gerror.Wrap(err, ""))
 	}
 
-	// 配置Markdown文档特殊处理
-	mdTrans, err := markdown.NewHeaderSplitter(ctx, &markdown.HeaderConfig{
-		// 标题级别映射：Markdown标题符号 -> 内部标题级别标识
-		Headers: map[string]string{
-			"#":   Title1, // 一级标题
-			"##":  Title2, // 二级标题
-			"###": Title3, // 三级标题
-		},
-		TrimHeaders: false, // 保留标题文本内容（不进行修剪）
-	})
-	if err != nil {
-		log.Fatalf("create markdown splitter failed, err: %v", gerror.Wrap(err, ""))
-	}
+	// 配置Markdown文档特殊处理：按一到六级标题分割，代码块、表格、列表不会被切开
+	mdTrans := splitter.NewMarkdownSplitter(&splitter.MarkdownConfig{ChunkSize: conf.ChunkSize, Tokenizer: tok})
 
 	// 将两种分割器组合到转换器中
 	trans.recursive = recTrans // 通用递归分割器
 	trans.markdown = mdTrans   // Markdown专用分割器
+	// 源代码按声明分割
+	trans.code = splitter.NewCodeSplitter(&splitter.CodeConfig{ChunkSize: conf.ChunkSize, Tokenizer: tok})
+	// 普通文本按语义分割，句子的分隔符与递归分割器相同
+	if embedder != nil {
+		trans.semantic, err = splitter.NewSemanticSplitter(&splitter.SemanticConfig{
+			Embedder:             embedder,
+			Tokenizer:            tok,
+			Separators:           chunkSeparators,
+			BreakpointPercentile: conf.BreakpointPercentile,
+			MinSize:              conf.MinChunkSize,
+			MaxSize:              conf.ChunkSize,
+		})
+		if err != nil {
+			log.Fatalf("create semantic splitter failed, err: %+v", err)
+		}
+	}
 
 	// 返回完整的文档转换器
 	return trans
 }
 
+// 创建子块分割器，子块之间的重叠为子块大小的八分之一
+func newChildSplitter(tok tokenizer.Tokenizer, conf *ChunkConfig) document.Transformer {
+	trans, err := recursive.NewSplitter(context.Background(), &recursive.Config{
+		ChunkSize:   conf.ChildChunkSize,
+		OverlapSize: conf.ChildChunkSize / 8,
+		Separators:  chunkSeparators,
+		LenFunc:     tok.Count,
+	})
+	if err != nil {
+		log.Fatalf("create child splitter failed, err: %v", gerror.Wrap(err, ""))
+	}
+	return trans
+}
+
+// ParentChild 是否启用父子块检索，没有分块配置时不启用
+func (c *Client) ParentChild() bool {
+	return c.chunk != nil && c.chunk.ParentChild
+}
+
+// SplitChildren 将父块分割为写入索引的子块，子块继承父块的元数据，并在MetaParentID中记录父块id
+// 父块需要已经有id
+func (c *Client) SplitChildren(ctx context.Context, parents []*schema.Document) ([]*schema.Document, error) {
+	var children []*schema.Document
+	for _, parent := range parents {
+		docs, err := c.childSplitter.Transform(ctx, []*schema.Document{parent})
+		if err != nil {
+			return nil, gerror.Wrap(err, "")
+		}
+		for _, doc := range docs {
+			meta := make(map[string]any, len(parent.MetaData)+1)
+			for k, v := range parent.MetaData {
+				meta[k] = v
+			}
+			meta[MetaParentID] = parent.ID
+			children = append(children, &schema.Document{ID: utils.NewUUID(), Content: doc.Content, MetaData: meta})
+		}
+	}
+	return children, nil
+}
+
+// 不需要分割的文档类型
+var noSplitExtensions = map[any]bool{".xlsx": true, ".csv": true}
+
 // 组合转换器，用于对文档进行多个转换操作
 type multiTransformer struct {
 	// 用于处理Markdown格式的文档
 	markdown document.Transformer
 	// 用于处理递归文档结构的转换器
 	recursive document.Transformer
+	// 用于处理源代码，按函数、类型等声明分割
+	code document.Transformer
+	// 按语义分割普通文本，没有嵌入器时为空
+	semantic document.Transformer
+	// 默认的分块策略
+	strategy string
 }
 
 // Transform 文档转换方法，根据文档类型选择合适的分割器进行处理
@@ -71,6 +214,16 @@ type multiTransformer struct {
 // opts: 转换器选项（可变参数）
 // 返回值: 处理后的文档切片，error 错误信息
 func (m *multiTransformer) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	// 表格按行输出的文档已经是完整的记录，不再分割
+	if len(docs) > 0 && noSplitExtensions[docs[0].MetaData[file.MetaKeyExtension]] {
+		return docs, nil
+	}
+	// 源代码使用代码分割器，避免把函数从中间切开
+	if len(docs) > 0 {
+		if ext, _ := docs[0].MetaData[file.MetaKeyExtension].(string); splitter.CodeExtensions[ext] {
+			return m.code.Transform(ctx, docs, opts...)
+		}
+	}
 	// 用于判断是否包含Markdown文档
 	isMd := false
 	// 遍历文档切片，检查是否包含Markdown格式文档
@@ -88,17 +241,24 @@ func (m *multiTransformer) Transform(ctx context.Context, docs []*schema.Documen
 		// 这样可以保持Markdown的标题层级结构
 		return m.markdown.Transform(ctx, docs, opts...)
 	}
-	// 如果不包含Markdown文档，使用通用递归分割器
+	// 如果不包含Markdown文档，使用通用递归分割器或者语义分割器
 	// 适用于普通文本、HTML等其他格式的文档
+	options := document.GetTransformerImplSpecificOptions(&transformOptions{strategy: m.strategy}, opts...)
+	if options.strategy == ChunkStrategySemantic {
+		if m.semantic == nil {
+			return nil, gerror.New("semantic chunking requires an embedder")
+		}
+		return m.semantic.Transform(ctx, docs, opts...)
+	}
 	return m.recursive.Transform(ctx, docs, opts...)
 }
 
 // 添加文档ID并合并
-// 主要功能：为文档添加唯一ID，并对Markdown文档进行智能合并
+// 主要功能：为文档添加唯一ID，并对Markdown文档进行智能合并，合并后的大小上限以token计
 // ctx: 上下文对象，用于控制请求生命周期
 // docs: 待处理的文档切片
 // 返回值: 处理后的文档切片和可能的错误
-func DocAddIDAndMerge(ctx context.Context, docs []*schema.Document) (output []*schema.Document, err error) {
+func (c *Client) DocAddIDAndMerge(ctx context.Context, docs []*schema.Document) (output []*schema.Document, err error) {
 	// 为所有没有ID的文档生成唯一ID
 	for _, doc := range docs {
 		if doc.ID == "" {
@@ -113,8 +273,8 @@ func DocAddIDAndMerge(ctx context.Context, docs []*schema.Document) (output []*s
 
 	// 创建新的文档切片，用于存储合并后的文档
 	ndocs := make([]*schema.Document, 0, len(docs))
-	var nd *schema.Document // 当前正在合并的文档指针
-	maxLen := 512           // 合并后文档的最大长度限制
+	var nd *schema.Document     // 当前正在合并的文档指针
+	maxLen := c.chunk.MergeSize // 合并后文档的最大token数
 
 	// 遍历所有文档进行智能合并
 	for _, doc := range docs {
@@ -125,7 +285,7 @@ func DocAddIDAndMerge(ctx context.Context, docs []*schema.Document) (output []*s
 		}
 
 		// 检查2: 如果合并后长度超过限制，结束当前合并
-		if nd != nil && len(nd.Content)+len(doc.Content) > maxLen {
+		if nd != nil && c.Tokenizer.Count(nd.Content+"\n\n"+doc.Content) > maxLen {
 			ndocs = append(ndocs, nd)
 			nd = nil
 		}
@@ -146,12 +306,12 @@ func DocAddIDAndMerge(ctx context.Context, docs []*schema.Document) (output []*s
 		if nd == nil {
 			nd = doc // 开始新的合并组
 		} else {
-			// 合并二级标题
-			mergeTitle(nd, doc, Title2)
-			// 合并三级标题
-			mergeTitle(nd, doc, Title3)
-			// 合并内容
-			nd.Content += doc.Content
+			// 合并二级到六级标题，合并后的文档块带上所有被合并的小节标题
+			for _, key := range []string{Title2, Title3, Title4, Title5, Title6} {
+				mergeTitle(nd, doc, key)
+			}
+			// 合并内容，块之间空一行，避免代码块、表格与后面的内容连在一起
+			nd.Content += "\n\n" + doc.Content
 		}
 	}
 
@@ -168,6 +328,13 @@ func DocAddIDAndMerge(ctx context.Context, docs []*schema.Document) (output []*s
 	return ndocs, nil
 }
 
+// ContentHash 计算文档块内容的哈希，用于在同一知识库内对文档块去重
+// 哈希前会对内容做归一化：去掉首尾空白，并将连续的空白字符合并为一个空格，
+// 这样仅有换行、缩进差异的文档块也会被视为相同内容
+func ContentHash(content string) string {
+	return utils.SHA256Hex([]byte(strings.Join(strings.Fields(content), " ")))
+}
+
 // getMdContentWithTitle 为Markdown文档生成带标题的内容
 // doc: 文档对象
 // 返回值: 格式化后的内容字符串（标题 + 内容）
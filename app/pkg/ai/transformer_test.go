@@ -0,0 +1,112 @@
+package ai
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/file"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+
+	"ragx/app/pkg/tokenizer"
+)
+
+func mdDoc(content string, titles map[string]any) *schema.Document {
+	meta := map[string]any{file.MetaKeyExtension: ".md", file.MetaKeySource: "manual.md"}
+	for k, v := range titles {
+		meta[k] = v
+	}
+	return &schema.Document{Content: content, MetaData: meta}
+}
+
+func TestDocAddIDAndMergeTitles(t *testing.T) {
+	c := &Client{chunk: &ChunkConfig{MergeSize: 1000}, Tokenizer: tokenizer.NewEstimator()}
+	docs := []*schema.Document{
+		mdDoc("安装", map[string]any{Title1: "手册", Title2: "部署", Title3: "Linux", Title4: "依赖", Title5: "系统包", Title6: "apt"}),
+		mdDoc("配置", map[string]any{Title1: "手册", Title2: "部署", Title3: "Linux", Title4: "配置", Title5: "系统包", Title6: "yum"}),
+		mdDoc("启动", map[string]any{Title1: "手册", Title2: "部署", Title3: "Linux", Title4: "启动"}),
+		// 一级标题不同时不合并
+		mdDoc("附录", map[string]any{Title1: "附录"}),
+	}
+	res, err := c.DocAddIDAndMerge(context.Background(), docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 2 {
+		t.Fatalf("got %d docs, want 2", len(res))
+	}
+	// 合并后的文档块带上所有小节的四到六级标题，而不只是第一个小节的
+	want := "h1:手册 h2:部署 h3:Linux h4:依赖,配置,启动 h5:系统包 h6:apt,yum \n安装\n\n配置\n\n启动"
+	if res[0].Content != want {
+		t.Errorf("merged content = %q\nwant %q", res[0].Content, want)
+	}
+	if res[1].Content != "h1:附录 \n附录" {
+		t.Errorf("content = %q", res[1].Content)
+	}
+	for _, doc := range res {
+		if doc.ID == "" {
+			t.Error("doc id not set")
+		}
+	}
+}
+
+func TestExtKeysTitles(t *testing.T) {
+	for _, key := range []string{Title1, Title2, Title3, Title4, Title5, Title6} {
+		if !slices.Contains(ExtKeys, key) {
+			t.Errorf("ExtKeys missing %s", key)
+		}
+	}
+}
+
+// 按句号分割的子块分割器，子块沿用父块的元数据
+type sentenceSplitter struct{}
+
+func (sentenceSplitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	var res []*schema.Document
+	for _, doc := range docs {
+		for _, s := range strings.SplitAfter(doc.Content, "。") {
+			if s != "" {
+				res = append(res, &schema.Document{ID: doc.ID, Content: s, MetaData: doc.MetaData})
+			}
+		}
+	}
+	return res, nil
+}
+
+func TestSplitChildren(t *testing.T) {
+	c := &Client{childSplitter: sentenceSplitter{}}
+	parentMeta := map[string]any{MetaFileName: "a.md", Title1: "安装"}
+	parents := []*schema.Document{
+		{ID: "p1", Content: "下载安装包。解压到目录。运行安装脚本。", MetaData: parentMeta},
+		{ID: "p2", Content: "短父块", MetaData: parentMeta},
+	}
+	children, err := c.SplitChildren(context.Background(), parents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct{ parent, content string }{
+		{"p1", "下载安装包。"}, {"p1", "解压到目录。"}, {"p1", "运行安装脚本。"}, {"p2", "短父块"},
+	}
+	if len(children) != len(want) {
+		t.Fatalf("got %d children, want %d", len(children), len(want))
+	}
+	ids := map[string]bool{}
+	for i, child := range children {
+		ids[child.ID] = true
+		// 子块继承父块的元数据，并记录父块id
+		if child.MetaData[MetaParentID] != want[i].parent || child.Content != want[i].content ||
+			child.MetaData[MetaFileName] != "a.md" || child.MetaData[Title1] != "安装" {
+			t.Errorf("child %d = %+v", i, child)
+		}
+	}
+	// 每个子块有自己的id，不沿用父块id
+	if len(ids) != len(children) || ids["p1"] || ids["p2"] {
+		t.Errorf("child ids = %v", ids)
+	}
+	// 父块的元数据不变
+	if len(parentMeta) != 2 {
+		t.Errorf("parent metadata modified: %v", parentMeta)
+	}
+}
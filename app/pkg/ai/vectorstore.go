@@ -0,0 +1,194 @@
+package ai
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/bytedance/sonic"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// 使用Elasticsearch 8保存向量
+	VectorStoreES = "es"
+	// 使用postgres的pgvector扩展保存向量，向量保存在文档块表上
+	VectorStorePGVector = "pgvector"
+)
+
+// VectorStore 向量存储，保存文档块的内容向量和问题向量，并按向量相似度检索，也可以按文档块内容全文检索
+// 所有实现的向量检索得分都是余弦相似度加1，取值范围0~2，相同的得分阈值在不同的存储上含义相同
+type VectorStore interface {
+	// Store 向量化并写入文档块，知识库名称从ctx的KnowledgeName中获取，元数据中的FieldQAContent会向量化到问题向量
+	Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) ([]string, error)
+	// Search 在指定的向量字段中检索与查询相似的文档块，或者按内容全文检索，元数据中包含FieldExtra和KnowledgeName
+	Search(ctx context.Context, query string, opts *SearchOptions) ([]*schema.Document, error)
+	// Delete 根据文档块id删除向量
+	Delete(ctx context.Context, ids []string) error
+	// Reuse 已经写入的文档块被新的文档复用，新的引用已经保存到文档块表后调用
+	Reuse(ctx context.Context, ids []string) error
+}
+
+// SearchOptions 检索参数
+type SearchOptions struct {
+	// 搜索的向量字段：FieldContentVector、FieldQAContentVector，为空时使用FieldContentVector
+	VectorField string
+	// 返回的文档块数量
+	TopK int
+	// 得分阈值，低于阈值的文档块不返回，全文检索时不生效
+	ScoreThreshold float64
+	// 元数据过滤，键为KnowledgeName时过滤知识库，其它键精确匹配扩展数据中同名的字符串、数字或布尔值
+	Filters map[string]string
+	// 按文档块内容全文检索(BM25)代替向量检索，忽略VectorField，得分是存储自己的相关度得分，与向量检索的得分不能比较
+	FullText bool
+}
+
+// RRF融合时排名的平滑常数
+const rrfK = 60
+
+type searchOptions struct {
+	filters map[string]string
+}
+
+// WithSearchFilters 设置Retriever检索时的元数据过滤，键为KnowledgeName时过滤知识库，其它键匹配扩展数据中的同名字段
+func WithSearchFilters(filters map[string]string) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *searchOptions) {
+		o.filters = filters
+	})
+}
+
+// 基于向量存储的检索器，与Client.Search相同，同时搜索内容向量和问题向量
+type storeRetriever struct {
+	c *Client
+}
+
+func (r *storeRetriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	o := retriever.GetCommonOptions(&retriever.Options{TopK: new(int), ScoreThreshold: new(float64)}, opts...)
+	so := retriever.GetImplSpecificOptions(&searchOptions{}, opts...)
+	return r.c.Search(ctx, query, &SearchOptions{TopK: *o.TopK, ScoreThreshold: *o.ScoreThreshold, Filters: so.filters})
+}
+
+// Retrieve 检索与问题相关的文档块，knowledgeName不为空时只在该知识库中检索
+func (c *Client) Retrieve(ctx context.Context, knowledgeName, query string, topK int, score float64) ([]*schema.Document, error) {
+	opts := &SearchOptions{TopK: topK, ScoreThreshold: score}
+	if knowledgeName != "" {
+		opts.Filters = map[string]string{KnowledgeName: knowledgeName}
+	}
+	return c.Search(ctx, query, opts)
+}
+
+// Search 检索与查询相关的文档块，忽略opts.VectorField和opts.FullText
+// 启用了问题生成时同时搜索内容向量和问题向量，按文档块合并结果，得分取较高的一个
+// 启用了混合检索时再按内容全文检索，与向量检索的结果按RRF融合，得分是RRF得分而不是余弦相似度，
+// 得分阈值只用于过滤向量检索的结果
+// 扩展数据会解析到元数据中，包括文件名、页码等来源信息
+func (c *Client) Search(ctx context.Context, query string, opts *SearchOptions) ([]*schema.Document, error) {
+	contentOpts := *opts
+	contentOpts.VectorField, contentOpts.FullText = FieldContentVector, false
+	searches := []*SearchOptions{&contentOpts}
+	if c.questionsPerChunk > 0 {
+		qaOpts := contentOpts
+		qaOpts.VectorField = FieldQAContentVector
+		searches = append(searches, &qaOpts)
+	}
+	if c.hybrid {
+		textOpts := *opts
+		textOpts.VectorField, textOpts.FullText, textOpts.ScoreThreshold = "", true, 0
+		searches = append(searches, &textOpts)
+	}
+	results := make([][]*schema.Document, len(searches))
+	errs := make([]error, len(searches))
+	var wg sync.WaitGroup
+	for i, o := range searches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = c.VectorStore.Search(ctx, query, o)
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, gerror.Wrap(err, "")
+		}
+	}
+
+	docs := results[0]
+	if c.questionsPerChunk > 0 && len(results[1]) > 0 {
+		docs = mergeDocs(docs, results[1], opts.TopK)
+	}
+	if c.hybrid {
+		docs = fuseRRF(opts.TopK, docs, results[len(results)-1])
+	}
+	for _, doc := range docs {
+		ext, ok := doc.MetaData[FieldExtra].(string)
+		if !ok || ext == "" {
+			continue
+		}
+		var m map[string]any
+		if err := sonic.UnmarshalString(ext, &m); err != nil {
+			continue
+		}
+		for k, v := range m {
+			if _, ok := doc.MetaData[k]; !ok {
+				doc.MetaData[k] = v
+			}
+		}
+	}
+	return docs, nil
+}
+
+// DeleteDocuments 根据文档块id从向量存储中删除文档块
+func (c *Client) DeleteDocuments(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return c.VectorStore.Delete(ctx, ids)
+}
+
+// 合并两次检索的结果，相同的文档块保留得分较高的一个，按得分从高到低取前topK个
+func mergeDocs(a, b []*schema.Document, topK int) []*schema.Document {
+	byID := make(map[string]*schema.Document, len(a)+len(b))
+	res := make([]*schema.Document, 0, len(a)+len(b))
+	for _, doc := range append(a, b...) {
+		if prev, ok := byID[doc.ID]; ok {
+			if doc.Score() > prev.Score() {
+				prev.WithScore(doc.Score())
+			}
+			continue
+		}
+		byID[doc.ID] = doc
+		res = append(res, doc)
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Score() > res[j].Score() })
+	if topK > 0 && len(res) > topK {
+		res = res[:topK]
+	}
+	return res
+}
+
+// 按RRF(Reciprocal Rank Fusion)融合多路检索的结果，文档块的得分是它在各路结果中1/(rrfK+排名)的和，排名从1开始
+// 各路的得分不需要可比，相同的文档块保留第一次出现的一个，按融合后的得分从高到低取前topK个
+func fuseRRF(topK int, lists ...[]*schema.Document) []*schema.Document {
+	scores := make(map[string]float64)
+	var res []*schema.Document
+	for _, list := range lists {
+		for rank, doc := range list {
+			if _, ok := scores[doc.ID]; !ok {
+				res = append(res, doc)
+			}
+			scores[doc.ID] += 1 / float64(rrfK+rank+1)
+		}
+	}
+	for _, doc := range res {
+		doc.WithScore(scores[doc.ID])
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Score() > res[j].Score() })
+	if topK > 0 && len(res) > topK {
+		res = res[:topK]
+	}
+	return res
+}
@@ -0,0 +1,311 @@
+package ai
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+)
+
+// 全文检索在fakeStore.results中的键
+const fullTextKey = "full_text"
+
+// 记录检索参数的向量存储，按向量字段返回固定的结果，全文检索返回fullTextKey的结果
+type fakeStore struct {
+	mu      sync.Mutex
+	opts    []SearchOptions
+	results map[string][]*schema.Document
+}
+
+func (s *fakeStore) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) ([]string, error) {
+	return nil, nil
+}
+
+func (s *fakeStore) Search(ctx context.Context, query string, opts *SearchOptions) ([]*schema.Document, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.opts = append(s.opts, *opts)
+	key := opts.VectorField
+	if opts.FullText {
+		key = fullTextKey
+	}
+	var res []*schema.Document
+	for _, doc := range s.results[key] {
+		res = append(res, &schema.Document{ID: doc.ID, Content: doc.Content, MetaData: map[string]any{FieldExtra: doc.MetaData[FieldExtra]}})
+		res[len(res)-1].WithScore(doc.Score())
+	}
+	return res, nil
+}
+
+func (s *fakeStore) Delete(ctx context.Context, ids []string) error {
+	return nil
+}
+
+func (s *fakeStore) Reuse(ctx context.Context, ids []string) error {
+	return nil
+}
+
+func scoredDoc(id string, score float64) *schema.Document {
+	return (&schema.Document{ID: id, MetaData: map[string]any{}}).WithScore(score)
+}
+
+func docIDs(docs []*schema.Document) string {
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.ID
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestMergeDocs(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   []*schema.Document
+		topK   int
+		want   string
+		scores []float64
+	}{
+		{
+			name:   "same chunk keeps the higher score",
+			a:      []*schema.Document{scoredDoc("1", 1.5), scoredDoc("2", 1.2)},
+			b:      []*schema.Document{scoredDoc("2", 1.8), scoredDoc("3", 1.1)},
+			topK:   10,
+			want:   "2,1,3",
+			scores: []float64{1.8, 1.5, 1.1},
+		},
+		{
+			name:   "truncated to topK by score",
+			a:      []*schema.Document{scoredDoc("1", 1.3), scoredDoc("2", 1.2)},
+			b:      []*schema.Document{scoredDoc("3", 1.9), scoredDoc("4", 1.0)},
+			topK:   2,
+			want:   "3,1",
+			scores: []float64{1.9, 1.3},
+		},
+		{
+			name:   "topK 0 keeps all",
+			a:      []*schema.Document{scoredDoc("1", 1.3)},
+			b:      []*schema.Document{scoredDoc("1", 1.1), scoredDoc("2", 1.2)},
+			want:   "1,2",
+			scores: []float64{1.3, 1.2},
+		},
+		{
+			// 问题向量的得分更高时，文档块可以进入topK
+			name:   "qa score lifts chunk into topK",
+			a:      []*schema.Document{scoredDoc("1", 1.5), scoredDoc("2", 1.4), scoredDoc("3", 1.3)},
+			b:      []*schema.Document{scoredDoc("3", 1.9)},
+			topK:   2,
+			want:   "3,1",
+			scores: []float64{1.9, 1.5},
+		},
+		{
+			// 得分相同时内容向量的结果在前
+			name:   "equal scores keep order",
+			a:      []*schema.Document{scoredDoc("1", 1.5)},
+			b:      []*schema.Document{scoredDoc("2", 1.5), scoredDoc("3", 1.5)},
+			topK:   2,
+			want:   "1,2",
+			scores: []float64{1.5, 1.5},
+		},
+		{
+			name: "empty",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeDocs(tt.a, tt.b, tt.topK)
+			if docIDs(got) != tt.want {
+				t.Fatalf("mergeDocs() = %s, want %s", docIDs(got), tt.want)
+			}
+			for i, doc := range got {
+				if doc.Score() != tt.scores[i] {
+					t.Errorf("doc %s score = %v, want %v", doc.ID, doc.Score(), tt.scores[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRetrieverOptions(t *testing.T) {
+	store := &fakeStore{results: map[string][]*schema.Document{
+		FieldContentVector:   {scoredDoc("1", 1.4)},
+		FieldQAContentVector: {scoredDoc("1", 1.6), scoredDoc("2", 1.5)},
+	}}
+	store.results[FieldContentVector][0].MetaData[FieldExtra] = `{"_file_name":"a.md","_page":3}`
+	c := &Client{VectorStore: store, questionsPerChunk: 2}
+	c.Retriever = &storeRetriever{c: c}
+
+	filters := map[string]string{KnowledgeName: "kb", "_file_name": "a.md"}
+	docs, err := c.Retriever.Retrieve(context.Background(), "问题",
+		retriever.WithTopK(5), retriever.WithScoreThreshold(1.2), WithSearchFilters(filters))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(store.opts) != 2 {
+		t.Fatalf("Search called %d times, want 2", len(store.opts))
+	}
+	fields := map[string]bool{}
+	for _, o := range store.opts {
+		fields[o.VectorField] = true
+		if o.TopK != 5 || o.ScoreThreshold != 1.2 || !reflect.DeepEqual(o.Filters, filters) {
+			t.Errorf("opts = %+v", o)
+		}
+	}
+	if !fields[FieldContentVector] || !fields[FieldQAContentVector] {
+		t.Errorf("searched fields = %v", fields)
+	}
+	// 内容向量和问题向量的结果合并，扩展数据解析到元数据中
+	if docIDs(docs) != "1,2" || docs[0].Score() != 1.6 {
+		t.Fatalf("docs = %s, score = %v", docIDs(docs), docs[0].Score())
+	}
+	if docs[0].MetaData["_file_name"] != "a.md" || docs[0].MetaData["_page"] != float64(3) {
+		t.Errorf("metadata = %v", docs[0].MetaData)
+	}
+
+	// Retrieve只按知识库过滤，没有启用问题生成时只搜索内容向量
+	store.opts = nil
+	c.questionsPerChunk = 0
+	if _, err = c.Retrieve(context.Background(), "kb", "问题", 3, 0.5); err != nil {
+		t.Fatal(err)
+	}
+	want := SearchOptions{VectorField: FieldContentVector, TopK: 3, ScoreThreshold: 0.5, Filters: map[string]string{KnowledgeName: "kb"}}
+	if len(store.opts) != 1 || !reflect.DeepEqual(store.opts[0], want) {
+		t.Errorf("opts = %+v, want %+v", store.opts, want)
+	}
+}
+
+// 排在第rank位（从0开始）的文档块的RRF得分
+func rrfScore(rank int) float64 {
+	return 1 / float64(rrfK+rank+1)
+}
+
+func TestFuseRRF(t *testing.T) {
+	vector := []*schema.Document{scoredDoc("1", 1.5), scoredDoc("2", 1.4)}
+	text := []*schema.Document{scoredDoc("3", 12.5), scoredDoc("1", 8)}
+	docs := fuseRRF(2, vector, text)
+	// 两路都排在前面的文档块得分最高，只在一路中排名相同的按出现的顺序
+	if docIDs(docs) != "1,3" {
+		t.Fatalf("fuseRRF() = %s", docIDs(docs))
+	}
+	if want := rrfScore(0) + rrfScore(1); docs[0].Score() != want || docs[1].Score() != rrfScore(0) {
+		t.Errorf("scores = %v, %v", docs[0].Score(), docs[1].Score())
+	}
+	if docs = fuseRRF(0, nil, nil); len(docs) != 0 {
+		t.Errorf("fuseRRF() = %s", docIDs(docs))
+	}
+}
+
+func TestHybridSearch(t *testing.T) {
+	store := &fakeStore{results: map[string][]*schema.Document{
+		FieldContentVector: {scoredDoc("1", 1.5), scoredDoc("2", 1.4)},
+		fullTextKey:        {scoredDoc("3", 12.5), scoredDoc("1", 8)},
+	}}
+	store.results[fullTextKey][0].MetaData[FieldExtra] = `{"_page":2}`
+	c := &Client{VectorStore: store, hybrid: true}
+	filters := map[string]string{KnowledgeName: "kb"}
+	docs, err := c.Search(context.Background(), "问题", &SearchOptions{TopK: 3, ScoreThreshold: 1.2, Filters: filters})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 向量检索和全文检索的结果按RRF融合，得分是RRF得分
+	if docIDs(docs) != "1,3,2" || docs[0].Score() != rrfScore(0)+rrfScore(1) {
+		t.Fatalf("docs = %s, score = %v", docIDs(docs), docs[0].Score())
+	}
+	if docs[1].MetaData["_page"] != float64(2) {
+		t.Errorf("metadata = %v", docs[1].MetaData)
+	}
+	// 全文检索使用相同的过滤条件，不使用得分阈值
+	var text *SearchOptions
+	for i, o := range store.opts {
+		if o.FullText {
+			text = &store.opts[i]
+		}
+	}
+	want := SearchOptions{TopK: 3, Filters: filters, FullText: true}
+	if len(store.opts) != 2 || text == nil || !reflect.DeepEqual(*text, want) {
+		t.Errorf("opts = %+v", store.opts)
+	}
+}
+
+// 元数据过滤的用例，es和pgvector的过滤结果都要与match一致
+var filterCases = []struct {
+	name    string
+	ext     string
+	filters map[string]string
+	match   bool
+}{
+	{name: "string", ext: `{"_file_name":"a.md"}`, filters: map[string]string{"_file_name": "a.md"}, match: true},
+	{name: "string prefix", ext: `{"_file_name":"a.md.bak"}`, filters: map[string]string{"_file_name": "a.md"}},
+	{name: "string in another key", ext: `{"_title":"_file_name a.md"}`, filters: map[string]string{"_file_name": "a.md"}},
+	{name: "integer", ext: `{"_page":3}`, filters: map[string]string{"_page": "3"}, match: true},
+	{name: "integer mismatch", ext: `{"_page":13}`, filters: map[string]string{"_page": "3"}},
+	{name: "decimal", ext: `{"_score":1.5}`, filters: map[string]string{"_score": "1.5"}, match: true},
+	{name: "boolean", ext: `{"_flag":true}`, filters: map[string]string{"_flag": "true"}, match: true},
+	{name: "array", ext: `{"_tags":["a"]}`, filters: map[string]string{"_tags": `["a"]`}},
+	{name: "object", ext: `{"_fields":{"a":"b"}}`, filters: map[string]string{"_fields": `{"a": "b"}`}},
+	{name: "null", ext: `{"_page":null}`, filters: map[string]string{"_page": ""}},
+	{name: "missing key", ext: `{"_page":3}`, filters: map[string]string{"_file_name": "a.md"}},
+	{name: "empty ext", ext: ``, filters: map[string]string{"_page": "3"}},
+	{name: "all keys", ext: `{"_file_name":"a.md","_page":3}`, filters: map[string]string{"_file_name": "a.md", "_page": "3"}, match: true},
+	{name: "one key mismatch", ext: `{"_file_name":"a.md","_page":3}`, filters: map[string]string{"_file_name": "a.md", "_page": "4"}},
+}
+
+// es的term查询精确匹配flattened字段的子字段，用写入时保存的可过滤元数据模拟匹配
+func TestESFilters(t *testing.T) {
+	for _, tt := range filterCases {
+		t.Run(tt.name, func(t *testing.T) {
+			meta, err := filterableMeta(tt.ext)
+			if err != nil {
+				t.Fatal(err)
+			}
+			filters := map[string]string{KnowledgeName: "kb"}
+			for k, v := range tt.filters {
+				filters[k] = v
+			}
+			queries := esFilters(filters)
+			if len(queries) != len(filters) {
+				t.Fatalf("%d queries for %d filters", len(queries), len(filters))
+			}
+			match := true
+			for _, q := range queries {
+				if len(q.Term) != 1 {
+					t.Fatalf("query = %+v, want a term query", q)
+				}
+				for field, term := range q.Term {
+					if field == KnowledgeName {
+						continue
+					}
+					key, ok := strings.CutPrefix(field, FieldMeta+".")
+					if !ok {
+						t.Fatalf("term on %s, want a %s subfield", field, FieldMeta)
+					}
+					v, ok := meta[key]
+					match = match && ok && v == term.Value
+				}
+			}
+			if match != tt.match {
+				t.Errorf("match = %v, want %v, meta = %v", match, tt.match, meta)
+			}
+		})
+	}
+}
+
+func TestParseESHit(t *testing.T) {
+	id, score := "c1", types.Float64(1.5)
+	doc, err := parseESHit(context.Background(), types.Hit{Id_: &id, Score_: &score,
+		Source_: []byte(`{"content":"正文","ext":"{\"_page\":3}","meta":{"_page":"3"},"_knowledge_name":"kb","qa_content":"问题"}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 可过滤的元数据只用于过滤，不返回
+	if doc.Content != "正文" || doc.Score() != 1.5 || len(doc.MetaData) != 3 ||
+		doc.MetaData[FieldExtra] != `{"_page":3}` || doc.MetaData[KnowledgeName] != "kb" {
+		t.Errorf("doc = %+v, score = %v", doc, doc.Score())
+	}
+}
@@ -0,0 +1,187 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Limit 解压限制，防止压缩包炸弹
+type Limit struct {
+	// 最多解压的文件数量
+	MaxEntries int
+	// 解压后的文件总大小上限，单位字节
+	MaxTotalSize int64
+}
+
+// DefaultLimit 默认解压限制，最多1000个文件，总大小不超过500MB
+var DefaultLimit = Limit{MaxEntries: 1000, MaxTotalSize: 500 << 20}
+
+// Entry 解压出来的文件
+type Entry struct {
+	// 文件在压缩包内的目录，不包含文件名，根目录为空字符串
+	Dir string
+	// 文件名
+	Name string
+	// 解压后的本地文件路径
+	LocalPath string
+	// 文件大小
+	Size int64
+}
+
+// IsArchive 根据文件名判断是否是支持的压缩包
+func IsArchive(name string) bool {
+	return formatOf(name) != ""
+}
+
+func formatOf(name string) string {
+	name = strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return "zip"
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return "tar.gz"
+	}
+	return ""
+}
+
+// Extract 将压缩包src解压到目录dst，只解压普通文件，目录、链接等会被忽略
+// 压缩包内的路径会保存在Entry.Dir中，包含..或绝对路径的文件会直接返回错误
+func Extract(src, dst string, limit Limit) ([]*Entry, error) {
+	switch formatOf(src) {
+	case "zip":
+		return extractZip(src, dst, limit)
+	case "tar.gz":
+		return extractTarGz(src, dst, limit)
+	}
+	return nil, gerror.Newf("unsupported archive: %s", filepath.Base(src))
+}
+
+func extractZip(src, dst string, limit Limit) ([]*Entry, error) {
+	r, err := zip.OpenReader(src)
+	if err != nil {
+		return nil, gerror.Wrap(err, "")
+	}
+	defer r.Close()
+	e := newExtractor(dst, limit)
+	for _, f := range r.File {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, gerror.Wrap(err, "")
+		}
+		err = e.add(f.Name, rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return e.entries, nil
+}
+
+func extractTarGz(src, dst string, limit Limit) ([]*Entry, error) {
+	f, err := os.Open(src)
+	if err != nil {
+		return nil, gerror.Wrap(err, "")
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, gerror.Wrap(err, "")
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	e := newExtractor(dst, limit)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, gerror.Wrap(err, "")
+		}
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err = e.add(h.Name, tr); err != nil {
+			return nil, err
+		}
+	}
+	return e.entries, nil
+}
+
+type extractor struct {
+	dst     string
+	limit   Limit
+	total   int64
+	entries []*Entry
+}
+
+func newExtractor(dst string, limit Limit) *extractor {
+	return &extractor{dst: dst, limit: limit}
+}
+
+// 解压单个文件，按实际写入的字节数统计大小，不信任压缩包头里记录的大小
+func (e *extractor) add(name string, r io.Reader) error {
+	rel, err := cleanPath(name)
+	if err != nil {
+		return err
+	}
+	if e.limit.MaxEntries > 0 && len(e.entries) >= e.limit.MaxEntries {
+		return gerror.Newf("archive has more than %d files", e.limit.MaxEntries)
+	}
+	localPath := filepath.Join(e.dst, filepath.FromSlash(rel))
+	if err = os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return gerror.Wrap(err, "")
+	}
+	out, err := os.Create(localPath)
+	if err != nil {
+		return gerror.Wrap(err, "")
+	}
+	defer out.Close()
+	if e.limit.MaxTotalSize > 0 {
+		// 多读一个字节，用于判断是否超过限制
+		r = io.LimitReader(r, e.limit.MaxTotalSize-e.total+1)
+	}
+	n, err := io.Copy(out, r)
+	if err != nil {
+		return gerror.Wrap(err, "")
+	}
+	e.total += n
+	if e.limit.MaxTotalSize > 0 && e.total > e.limit.MaxTotalSize {
+		return gerror.Newf("archive uncompressed size exceeds %d bytes", e.limit.MaxTotalSize)
+	}
+	dir := path.Dir(rel)
+	if dir == "." {
+		dir = ""
+	}
+	e.entries = append(e.entries, &Entry{Dir: dir, Name: path.Base(rel), LocalPath: localPath, Size: n})
+	return nil
+}
+
+// 校验压缩包内的路径，返回清理后的相对路径
+func cleanPath(name string) (string, error) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if name == "" || strings.HasPrefix(name, "/") || filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return "", gerror.Newf("illegal path in archive: %s", name)
+	}
+	for _, part := range strings.Split(name, "/") {
+		if part == ".." {
+			return "", gerror.Newf("illegal path in archive: %s", name)
+		}
+	}
+	rel := path.Clean(name)
+	if rel == "." {
+		return "", gerror.Newf("illegal path in archive: %s", name)
+	}
+	return rel, nil
+}
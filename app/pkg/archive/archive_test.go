@@ -0,0 +1,214 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// 压缩包内的文件，link不为空时是指向link的符号链接
+type file struct {
+	name, body, link string
+}
+
+func buildZip(t *testing.T, files []file) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		h := &zip.FileHeader{Name: f.name, Method: zip.Deflate}
+		body := f.body
+		if f.link != "" {
+			h.SetMode(os.ModeSymlink | 0777)
+			body = f.link
+		} else if strings.HasSuffix(f.name, "/") {
+			h.SetMode(os.ModeDir | 0755)
+		} else {
+			h.SetMode(0644)
+		}
+		w, err := zw.CreateHeader(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func buildTarGz(t *testing.T, files []file) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for _, f := range files {
+		h := &tar.Header{Name: f.name, Mode: 0644, Typeflag: tar.TypeReg, Size: int64(len(f.body))}
+		switch {
+		case f.link != "":
+			h.Typeflag, h.Linkname, h.Size = tar.TypeSymlink, f.link, 0
+		case strings.HasSuffix(f.name, "/"):
+			h.Typeflag, h.Mode, h.Size = tar.TypeDir, 0755, 0
+		}
+		if err := tw.WriteHeader(h); err != nil {
+			t.Fatal(err)
+		}
+		if h.Typeflag == tar.TypeReg {
+			if _, err := tw.Write([]byte(f.body)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// 按两种格式分别生成压缩包并解压
+func extractBoth(t *testing.T, files []file, limit Limit, check func(t *testing.T, dst string, entries []*Entry, err error)) {
+	for name, build := range map[string]func(*testing.T, []file) []byte{"a.zip": buildZip, "a.tar.gz": buildTarGz} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			src := filepath.Join(dir, name)
+			if err := os.WriteFile(src, build(t, files), 0644); err != nil {
+				t.Fatal(err)
+			}
+			dst := filepath.Join(dir, "out")
+			entries, err := Extract(src, dst, limit)
+			check(t, dst, entries, err)
+		})
+	}
+}
+
+func TestExtractNested(t *testing.T) {
+	files := []file{
+		{name: "docs/"},
+		{name: "readme.md", body: "# readme"},
+		{name: "docs/guide/intro.md", body: "intro"},
+		{name: "docs/./faq.txt", body: "faq"},
+	}
+	extractBoth(t, files, DefaultLimit, func(t *testing.T, dst string, entries []*Entry, err error) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []Entry{
+			{Dir: "", Name: "readme.md", Size: 8},
+			{Dir: "docs/guide", Name: "intro.md", Size: 5},
+			{Dir: "docs", Name: "faq.txt", Size: 3},
+		}
+		if len(entries) != len(want) {
+			t.Fatalf("got %d entries, want %d", len(entries), len(want))
+		}
+		for i, e := range entries {
+			if e.Dir != want[i].Dir || e.Name != want[i].Name || e.Size != want[i].Size {
+				t.Errorf("entry %d = %+v, want %+v", i, e, want[i])
+			}
+			if !strings.HasPrefix(e.LocalPath, dst+string(filepath.Separator)) {
+				t.Errorf("local path %s outside %s", e.LocalPath, dst)
+			}
+		}
+		body, err := os.ReadFile(filepath.Join(dst, "docs", "guide", "intro.md"))
+		if err != nil || string(body) != "intro" {
+			t.Errorf("intro.md = %q, %v", body, err)
+		}
+	})
+}
+
+func TestExtractIllegalPath(t *testing.T) {
+	for _, name := range []string{"../evil.txt", "docs/../../evil.txt", `..\evil.txt`, "/etc/evil.txt"} {
+		t.Run(name, func(t *testing.T) {
+			files := []file{{name: "ok.txt", body: "ok"}, {name: name, body: "evil"}}
+			extractBoth(t, files, DefaultLimit, func(t *testing.T, dst string, entries []*Entry, err error) {
+				if err == nil || !strings.Contains(err.Error(), "illegal path") {
+					t.Fatalf("err = %v, want illegal path", err)
+				}
+				if _, err := os.Stat(filepath.Join(filepath.Dir(dst), "evil.txt")); !os.IsNotExist(err) {
+					t.Errorf("file written outside dst: %v", err)
+				}
+			})
+		})
+	}
+}
+
+func TestExtractSkipSymlink(t *testing.T) {
+	files := []file{
+		{name: "passwd", link: "/etc/passwd"},
+		{name: "up", link: "../"},
+		{name: "a.txt", body: "a"},
+	}
+	extractBoth(t, files, DefaultLimit, func(t *testing.T, dst string, entries []*Entry, err error) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 || entries[0].Name != "a.txt" {
+			t.Fatalf("entries = %+v", entries)
+		}
+		for _, name := range []string{"passwd", "up"} {
+			if _, err := os.Lstat(filepath.Join(dst, name)); !os.IsNotExist(err) {
+				t.Errorf("symlink %s extracted: %v", name, err)
+			}
+		}
+	})
+}
+
+func TestExtractLimit(t *testing.T) {
+	files := []file{{name: "a.txt", body: "aaaa"}, {name: "b.txt", body: "bbbb"}, {name: "c.txt", body: "cccc"}}
+	extractBoth(t, files, Limit{MaxEntries: 2}, func(t *testing.T, dst string, entries []*Entry, err error) {
+		if err == nil || !strings.Contains(err.Error(), "more than 2 files") {
+			t.Fatalf("err = %v, want max entries error", err)
+		}
+	})
+	extractBoth(t, files, Limit{MaxTotalSize: 10}, func(t *testing.T, dst string, entries []*Entry, err error) {
+		if err == nil || !strings.Contains(err.Error(), "exceeds 10 bytes") {
+			t.Fatalf("err = %v, want max size error", err)
+		}
+	})
+	// 刚好等于限制时可以解压
+	extractBoth(t, files, Limit{MaxEntries: 3, MaxTotalSize: 12}, func(t *testing.T, dst string, entries []*Entry, err error) {
+		if err != nil || len(entries) != 3 {
+			t.Fatalf("entries = %d, err = %v", len(entries), err)
+		}
+	})
+}
+
+func TestExtractUnsupported(t *testing.T) {
+	if _, err := Extract("a.rar", t.TempDir(), DefaultLimit); err == nil {
+		t.Fatal("expected unsupported archive error")
+	}
+}
+
+func TestCleanPath(t *testing.T) {
+	tests := []struct {
+		name, want string
+		ok         bool
+	}{
+		{"a.txt", "a.txt", true},
+		{"docs/./a.txt", "docs/a.txt", true},
+		{`docs\a.txt`, "docs/a.txt", true},
+		{"docs//a.txt", "docs/a.txt", true},
+		{"", "", false},
+		{".", "", false},
+		{"./", "", false},
+		{"../a.txt", "", false},
+		{"docs/../a.txt", "", false},
+		{`..\a.txt`, "", false},
+		{"/a.txt", "", false},
+		{`\a.txt`, "", false},
+	}
+	for _, tt := range tests {
+		got, err := cleanPath(tt.name)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("cleanPath(%q) = %q, %v", tt.name, got, err)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Scheme 存储在BlobStore中的文件uri前缀，如blob://知识库/xxx.pdf
+const Scheme = "blob://"
+
+// ErrNotFound 文件不存在
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore 存储上传的源文件，多实例部署时所有实例共享同一份文件
+type BlobStore interface {
+	// Put 保存文件，size未知时传-1
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	// Get 读取文件，调用方负责关闭，文件不存在时返回ErrNotFound
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete 删除文件，文件不存在时不返回错误
+	Delete(ctx context.Context, key string) error
+}
+
+// URI 根据key生成文件uri
+func URI(key string) string {
+	return Scheme + key
+}
+
+// KeyFromURI 从文件uri中获取key，不是BlobStore中的文件时返回false
+func KeyFromURI(uri string) (string, bool) {
+	if !strings.HasPrefix(uri, Scheme) {
+		return "", false
+	}
+	return strings.TrimPrefix(uri, Scheme), true
+}
+
+// 校验key，只允许相对路径，不能包含..
+func cleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", gerror.Newf("invalid blob key: %s", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == ".." {
+			return "", gerror.Newf("invalid blob key: %s", key)
+		}
+	}
+	return path.Clean(key), nil
+}
@@ -0,0 +1,149 @@
+package blob
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// 进程内的S3模拟服务，只支持路径方式的PUT、GET、HEAD、DELETE对象
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	switch r.Method {
+	case http.MethodPut:
+		body, err := readBody(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.objects[key] = body
+		w.Header().Set("ETag", `"etag"`)
+	case http.MethodGet, http.MethodHead:
+		data, ok := f.objects[key]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			if r.Method == http.MethodGet {
+				_, _ = io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>not found</Message></Error>`)
+			}
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("ETag", `"etag"`)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// 读取请求体，支持aws-chunked编码
+func readBody(r *http.Request) ([]byte, error) {
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return io.ReadAll(r.Body)
+	}
+	var res bytes.Buffer
+	br := bufio.NewReader(r.Body)
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		sizeHex, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+		size, err := strconv.ParseInt(sizeHex, 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		if size == 0 {
+			return res.Bytes(), nil
+		}
+		if _, err = io.CopyN(&res, br, size); err != nil {
+			return nil, err
+		}
+		if _, err = br.ReadString('\n'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func testStore(t *testing.T, s BlobStore) {
+	ctx := context.Background()
+	key := "kb/dir/a.txt"
+	if err := s.Put(ctx, key, strings.NewReader("hello"), 5); err != nil {
+		t.Fatalf("Put err: %+v", err)
+	}
+	rc, err := s.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get err: %+v", err)
+	}
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil || string(data) != "hello" {
+		t.Fatalf("Get = %q, %v, want hello", data, err)
+	}
+	if err = s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete err: %+v", err)
+	}
+	if _, err = s.Get(ctx, key); err != ErrNotFound {
+		t.Fatalf("Get after delete err = %v, want ErrNotFound", err)
+	}
+	if err = s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete missing err: %+v", err)
+	}
+	if err = s.Put(ctx, "../a.txt", strings.NewReader("x"), 1); err == nil {
+		t.Fatal("Put with .. should fail")
+	}
+}
+
+func TestLocalStore(t *testing.T) {
+	s, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, s)
+}
+
+func TestS3Store(t *testing.T) {
+	srv := httptest.NewServer(&fakeS3{objects: map[string][]byte{}})
+	defer srv.Close()
+	s, err := NewS3Store(&S3Config{
+		Endpoint:  strings.TrimPrefix(srv.URL, "http://"),
+		AccessKey: "minioadmin",
+		SecretKey: "minioadmin",
+		Bucket:    "ragx",
+		Region:    "us-east-1",
+		PathStyle: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, s)
+}
+
+func TestKeyFromURI(t *testing.T) {
+	if key, ok := KeyFromURI(URI("kb/a.pdf")); !ok || key != "kb/a.pdf" {
+		t.Fatalf("KeyFromURI = %q, %v", key, ok)
+	}
+	if _, ok := KeyFromURI("./uploads/a.pdf"); ok {
+		t.Fatal("KeyFromURI should not accept local path")
+	}
+}
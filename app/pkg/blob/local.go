@@ -0,0 +1,83 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// LocalStore 本地文件系统存储，适合单实例部署或挂载了共享存储的场景
+type LocalStore struct {
+	dir string
+}
+
+func NewLocalStore(dir string) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, gerror.Wrap(err, "")
+	}
+	return &LocalStore{dir: dir}, nil
+}
+
+func (s *LocalStore) path(key string) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+// Put 先写入临时文件再重命名，避免读到写了一半的文件
+func (s *LocalStore) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return gerror.Wrap(err, "")
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".blob-*")
+	if err != nil {
+		return gerror.Wrap(err, "")
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return gerror.Wrap(err, "")
+	}
+	if err = tmp.Close(); err != nil {
+		return gerror.Wrap(err, "")
+	}
+	if err = os.Rename(tmp.Name(), p); err != nil {
+		return gerror.Wrap(err, "")
+	}
+	return nil
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, gerror.Wrap(err, "")
+	}
+	return f, nil
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return gerror.Wrap(err, "")
+	}
+	return nil
+}
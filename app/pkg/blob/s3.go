@@ -0,0 +1,90 @@
+package blob
+
+import (
+	"context"
+	"io"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Config S3兼容存储的配置，支持aws s3、minio、oss等
+type S3Config struct {
+	Endpoint  string
+	AccessKey string
+	SecretKey string
+	Bucket    string
+	Region    string
+	UseSSL    bool
+	// 使用路径方式访问bucket，minio需要开启
+	PathStyle bool
+}
+
+// S3Store S3兼容的对象存储
+type S3Store struct {
+	client *minio.Client
+	bucket string
+}
+
+func NewS3Store(c *S3Config) (*S3Store, error) {
+	opts := &minio.Options{
+		Creds:  credentials.NewStaticV4(c.AccessKey, c.SecretKey, ""),
+		Secure: c.UseSSL,
+		Region: c.Region,
+	}
+	if c.PathStyle {
+		opts.BucketLookup = minio.BucketLookupPath
+	}
+	client, err := minio.New(c.Endpoint, opts)
+	if err != nil {
+		return nil, gerror.Wrap(err, "")
+	}
+	return &S3Store{client: client, bucket: c.Bucket}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	if _, err = s.client.PutObject(ctx, s.bucket, key, r, size, minio.PutObjectOptions{}); err != nil {
+		return gerror.Wrap(err, "")
+	}
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, s.wrap(err)
+	}
+	// GetObject不会立即发送请求，通过Stat确认文件存在
+	if _, err = obj.Stat(); err != nil {
+		obj.Close()
+		return nil, s.wrap(err)
+	}
+	return obj, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	if err = s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return s.wrap(err)
+	}
+	return nil
+}
+
+func (s *S3Store) wrap(err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrNotFound
+	}
+	return gerror.Wrap(err, "")
+}
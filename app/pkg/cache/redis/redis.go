@@ -329,3 +329,39 @@ func (r *Client) GetSubscriberCount(ctx context.Context, channel string) (count
 func (r *Client) Subscribe(ctx context.Context, channel string) *redis.PubSub {
 	return r.rdb.Subscribe(ctx, channel)
 }
+
+// PipelineGet 使用管道批量获取多个键的值，集群模式下键可以分布在不同的节点。
+// 参数 ctx 为上下文，用于控制请求的生命周期，可进行超时控制、取消操作等。
+// 参数 keys 为要获取值的 Redis 键列表。
+// 返回值 []string 与 keys 一一对应，键不存在时为空字符串，error 表示操作过程中可能出现的错误。
+func (r *Client) PipelineGet(ctx context.Context, keys []string) ([]string, error) {
+	pipe := r.rdb.Pipeline()
+	cmds := make([]*redis.StringCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = pipe.Get(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !gerror.Is(err, redis.Nil) {
+		return nil, gerror.Wrap(err, "pipeline get from redis failed")
+	}
+	vals := make([]string, len(keys))
+	for i, cmd := range cmds {
+		vals[i] = cmd.Val()
+	}
+	return vals, nil
+}
+
+// PipelineSetEx 使用管道批量设置多个键的值，并为每个键设置相同的过期时间。
+// 参数 ctx 为上下文，用于控制请求的生命周期，可进行超时控制、取消操作等。
+// 参数 values 为要设置的键值对映射。
+// 参数 expire 为键的过期时间，为 0 时不过期。
+// 返回值 error 表示操作过程中可能出现的错误。
+func (r *Client) PipelineSetEx(ctx context.Context, values map[string]string, expire time.Duration) error {
+	pipe := r.rdb.Pipeline()
+	for key, val := range values {
+		pipe.Set(ctx, key, val, expire)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return gerror.Wrap(err, "pipeline set to redis failed")
+	}
+	return nil
+}
@@ -0,0 +1,488 @@
+package crawler
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/temoto/robotstxt"
+)
+
+const (
+	// 默认最多抓取的网页数量
+	DefaultMaxPages = 100
+	// 默认的User-Agent
+	DefaultUserAgent = "ragx-crawler"
+	// 默认单个网页的大小上限，10MB
+	DefaultMaxBodySize = 10 << 20
+	// sitemap索引最多嵌套的层数
+	maxSitemapDepth = 3
+)
+
+// Options 抓取选项
+type Options struct {
+	// 只抓取与起始地址相同域名的网页
+	SameDomain bool
+	// 最大抓取深度，0表示只抓取起始地址
+	MaxDepth int
+	// 最多抓取的网页数量，<=0时使用DefaultMaxPages
+	MaxPages int
+	// 网页地址需要匹配其中一个正则表达式，为空表示不限制
+	Include []string
+	// 网页地址匹配其中一个正则表达式时跳过
+	Exclude []string
+	// 遵守robots.txt
+	RespectRobots bool
+	// 为空时使用DefaultUserAgent
+	UserAgent string
+	// 单个网页的大小上限，<=0时使用DefaultMaxBodySize
+	MaxBodySize int64
+	// 允许抓取环回、内网、链路本地等非公网地址，默认不允许，防止通过抓取访问内部服务和云主机元数据
+	AllowPrivate bool
+	// 为空时使用30秒超时、拒绝连接非公网地址的http.Client，不为空时由调用方负责限制访问的地址
+	Client *http.Client
+}
+
+// Page 抓取到的网页
+type Page struct {
+	// 请求的地址
+	URL string
+	// 规范地址，网页没有声明canonical时与URL相同
+	CanonicalURL string
+	// 网页标题
+	Title string
+	// 抓取深度，起始地址为0
+	Depth int
+	// 内容类型，不包含charset等参数
+	ContentType string
+	Body        []byte
+	// 响应头中的ETag和Last-Modified，用于下次条件请求
+	ETag         string
+	LastModified string
+}
+
+// ErrNotModified 条件请求时网页没有变化
+var ErrNotModified = gerror.New("not modified")
+
+// ErrRobotsDisallowed 遵守robots.txt时网页不允许抓取
+var ErrRobotsDisallowed = gerror.New("disallowed by robots.txt")
+
+// Failure 抓取失败的网页
+type Failure struct {
+	URL string
+	Err error
+}
+
+// Result 抓取结果
+type Result struct {
+	Pages  []*Page
+	Failed []*Failure
+}
+
+// Crawler 网页抓取器，按广度优先的顺序抓取网页
+type Crawler struct {
+	opts    Options
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+	client  *http.Client
+
+	mu     sync.Mutex
+	robots map[string]*robotstxt.Group
+}
+
+func New(opts Options) (*Crawler, error) {
+	if opts.MaxPages <= 0 {
+		opts.MaxPages = DefaultMaxPages
+	}
+	if opts.UserAgent == "" {
+		opts.UserAgent = DefaultUserAgent
+	}
+	if opts.MaxBodySize <= 0 {
+		opts.MaxBodySize = DefaultMaxBodySize
+	}
+	c := &Crawler{opts: opts, client: opts.Client, robots: make(map[string]*robotstxt.Group)}
+	if c.client == nil {
+		c.client = newClient(opts.AllowPrivate)
+	}
+	var err error
+	if c.include, err = compile(opts.Include); err != nil {
+		return nil, err
+	}
+	if c.exclude, err = compile(opts.Exclude); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func compile(patterns []string) ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, gerror.Wrapf(err, "invalid pattern: %s", p)
+		}
+		res = append(res, re)
+	}
+	return res, nil
+}
+
+// Crawl 从起始地址开始抓取网页，过滤条件对起始地址同样生效
+// 单个网页抓取失败不会中断抓取，失败的网页记录在Result.Failed中
+func (c *Crawler) Crawl(ctx context.Context, seeds []string) (*Result, error) {
+	type item struct {
+		url   string
+		depth int
+	}
+	res := &Result{}
+	hosts := make(map[string]bool)
+	visited := make(map[string]bool)
+	canonicals := make(map[string]bool)
+	queue := make([]item, 0, len(seeds))
+	for _, s := range seeds {
+		u, err := normalize(s)
+		if err != nil {
+			res.Failed = append(res.Failed, &Failure{URL: s, Err: err})
+			continue
+		}
+		hosts[hostOf(u)] = true
+		if !visited[u] {
+			visited[u] = true
+			queue = append(queue, item{url: u})
+		}
+	}
+
+	for len(queue) > 0 && len(res.Pages) < c.opts.MaxPages {
+		if err := ctx.Err(); err != nil {
+			return res, gerror.Wrap(err, "")
+		}
+		it := queue[0]
+		queue = queue[1:]
+		if c.opts.SameDomain && !hosts[hostOf(it.url)] {
+			continue
+		}
+		if !c.match(it.url) {
+			continue
+		}
+		if c.opts.RespectRobots {
+			ok, err := c.robotsAllowed(ctx, it.url)
+			if err != nil {
+				res.Failed = append(res.Failed, &Failure{URL: it.url, Err: err})
+				continue
+			}
+			if !ok {
+				continue
+			}
+		}
+		page, links, err := c.fetch(ctx, it.url, "", "")
+		if err != nil {
+			res.Failed = append(res.Failed, &Failure{URL: it.url, Err: err})
+			continue
+		}
+		page.Depth = it.depth
+		// 不同地址指向同一个规范地址时只保留一个
+		if !canonicals[page.CanonicalURL] {
+			canonicals[page.CanonicalURL] = true
+			res.Pages = append(res.Pages, page)
+		}
+		if it.depth >= c.opts.MaxDepth {
+			continue
+		}
+		for _, l := range links {
+			if !visited[l] {
+				visited[l] = true
+				queue = append(queue, item{url: l, depth: it.depth + 1})
+			}
+		}
+	}
+	return res, nil
+}
+
+// 网页地址是否满足include、exclude条件
+func (c *Crawler) match(u string) bool {
+	for _, re := range c.exclude {
+		if re.MatchString(u) {
+			return false
+		}
+	}
+	if len(c.include) == 0 {
+		return true
+	}
+	for _, re := range c.include {
+		if re.MatchString(u) {
+			return true
+		}
+	}
+	return false
+}
+
+// robots.txt是否允许抓取，每个域名只请求一次robots.txt
+func (c *Crawler) robotsAllowed(ctx context.Context, rawURL string) (bool, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false, gerror.Wrap(err, "")
+	}
+	key := u.Scheme + "://" + u.Host
+	c.mu.Lock()
+	group, ok := c.robots[key]
+	c.mu.Unlock()
+	if !ok {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, key+"/robots.txt", nil)
+		if err != nil {
+			return false, gerror.Wrap(err, "")
+		}
+		req.Header.Set("User-Agent", c.opts.UserAgent)
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return false, gerror.Wrap(err, "")
+		}
+		// 4xx表示没有限制，5xx表示全部禁止
+		data, err := robotstxt.FromResponse(resp)
+		resp.Body.Close()
+		if err != nil {
+			return false, gerror.Wrap(err, "")
+		}
+		group = data.FindGroup(c.opts.UserAgent)
+		c.mu.Lock()
+		c.robots[key] = group
+		c.mu.Unlock()
+	}
+	p := u.EscapedPath()
+	if u.RawQuery != "" {
+		p += "?" + u.RawQuery
+	}
+	return group.Test(p), nil
+}
+
+// Fetch 抓取单个网页，etag、lastModified不为空时发送条件请求，网页没有变化时返回ErrNotModified
+// 遵守robots.txt时先检查是否允许抓取，不允许时返回ErrRobotsDisallowed
+func (c *Crawler) Fetch(ctx context.Context, u, etag, lastModified string) (*Page, error) {
+	u, err := normalize(u)
+	if err != nil {
+		return nil, err
+	}
+	if c.opts.RespectRobots {
+		ok, err := c.robotsAllowed(ctx, u)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, ErrRobotsDisallowed
+		}
+	}
+	page, _, err := c.fetch(ctx, u, etag, lastModified)
+	return page, err
+}
+
+// 请求网页，返回网页内容和网页中的链接
+func (c *Crawler) fetch(ctx context.Context, u, etag, lastModified string) (*Page, []string, error) {
+	resp, err := c.get(ctx, u, etag, lastModified)
+	if err != nil {
+		return nil, nil, err
+	}
+	page := &Page{URL: u, CanonicalURL: resp.finalURL, ContentType: resp.contentType, Body: resp.body,
+		ETag: resp.etag, LastModified: resp.lastModified}
+	body := resp.body
+	switch resp.contentType {
+	case "text/html", "application/xhtml+xml":
+	case "text/plain":
+		return page, nil, nil
+	default:
+		return nil, nil, gerror.Newf("unsupported content type: %s", resp.contentType)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, gerror.Wrap(err, "")
+	}
+	base, _ := url.Parse(resp.finalURL)
+	// 网页中的<base href>会影响相对地址的解析
+	if href, ok := doc.Find("base[href]").First().Attr("href"); ok {
+		if b := resolve(base, href); b != "" {
+			base, _ = url.Parse(b)
+		}
+	}
+	page.Title = strings.TrimSpace(doc.Find("title").First().Text())
+	if href, ok := doc.Find(`link[rel="canonical"]`).First().Attr("href"); ok {
+		if canonical := resolve(base, href); canonical != "" {
+			page.CanonicalURL = canonical
+		}
+	}
+	var links []string
+	doc.Find("a[href]").Each(func(_ int, s *goquery.Selection) {
+		if rel, _ := s.Attr("rel"); strings.Contains(rel, "nofollow") {
+			return
+		}
+		href, _ := s.Attr("href")
+		if l := resolve(base, href); l != "" {
+			links = append(links, l)
+		}
+	})
+	return page, links, nil
+}
+
+// GET请求的响应
+type response struct {
+	body        []byte
+	contentType string
+	// 跳转后的地址
+	finalURL     string
+	etag         string
+	lastModified string
+}
+
+// 发送GET请求，etag、lastModified不为空时发送条件请求
+func (c *Crawler) get(ctx context.Context, u, etag, lastModified string) (*response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, gerror.Wrap(err, "")
+	}
+	req.Header.Set("User-Agent", c.opts.UserAgent)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, gerror.Wrap(err, "")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, ErrNotModified
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, gerror.Newf("unexpected status: %s", resp.Status)
+	}
+	// 多读一个字节，用于判断是否超过限制
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.opts.MaxBodySize+1))
+	if err != nil {
+		return nil, gerror.Wrap(err, "")
+	}
+	if int64(len(body)) > c.opts.MaxBodySize {
+		return nil, gerror.Newf("page exceeds %d bytes", c.opts.MaxBodySize)
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		contentType = mediaType
+	}
+	finalURL, err := normalize(resp.Request.URL.String())
+	if err != nil {
+		finalURL = u
+	}
+	return &response{
+		body:         body,
+		contentType:  contentType,
+		finalURL:     finalURL,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	}, nil
+}
+
+type sitemapXML struct {
+	URLs     []sitemapLoc `xml:"url"`
+	Sitemaps []sitemapLoc `xml:"sitemap"`
+}
+
+type sitemapLoc struct {
+	Loc string `xml:"loc"`
+}
+
+// Sitemap 读取sitemap.xml中的网页地址，支持sitemap索引，最多返回MaxPages个地址
+func (c *Crawler) Sitemap(ctx context.Context, sitemapURL string) ([]string, error) {
+	var res []string
+	seen := make(map[string]bool)
+	if err := c.sitemap(ctx, sitemapURL, 0, seen, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *Crawler) sitemap(ctx context.Context, sitemapURL string, depth int, seen map[string]bool, res *[]string) error {
+	if depth > maxSitemapDepth || seen[sitemapURL] {
+		return nil
+	}
+	seen[sitemapURL] = true
+	resp, err := c.get(ctx, sitemapURL, "", "")
+	if err != nil {
+		return err
+	}
+	var sm sitemapXML
+	if err = xml.Unmarshal(resp.body, &sm); err != nil {
+		return gerror.Wrapf(err, "invalid sitemap: %s", sitemapURL)
+	}
+	for _, u := range sm.URLs {
+		if len(*res) >= c.opts.MaxPages {
+			return nil
+		}
+		if loc := strings.TrimSpace(u.Loc); loc != "" {
+			*res = append(*res, loc)
+		}
+	}
+	for _, s := range sm.Sitemaps {
+		if len(*res) >= c.opts.MaxPages {
+			return nil
+		}
+		if loc := strings.TrimSpace(s.Loc); loc != "" {
+			if err = c.sitemap(ctx, loc, depth+1, seen, res); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// 解析网页中的链接，只保留http、https地址
+func resolve(base *url.URL, href string) string {
+	ref, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		ref = base.ResolveReference(ref)
+	}
+	u, err := normalize(ref.String())
+	if err != nil {
+		return ""
+	}
+	return u
+}
+
+// 规范化网页地址：去掉fragment，scheme和host转为小写，空路径补全为/
+func normalize(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", gerror.Wrap(err, "")
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", gerror.Newf("invalid url: %s", raw)
+	}
+	u.Host = strings.ToLower(u.Host)
+	u.Fragment = ""
+	u.RawFragment = ""
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	return u.String(), nil
+}
+
+func hostOf(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return ""
+	}
+	return parsed.Hostname()
+}
@@ -0,0 +1,282 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// 创建测试站点，pages的key是路径，value是网页内容，内容中的{{host}}会被替换为站点地址
+func newSite(t *testing.T, pages map[string]string) *httptest.Server {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		switch {
+		case strings.HasSuffix(r.URL.Path, ".xml"):
+			w.Header().Set("Content-Type", "application/xml")
+		case strings.HasSuffix(r.URL.Path, ".txt"):
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		default:
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		}
+		fmt.Fprint(w, strings.ReplaceAll(body, "{{host}}", srv.URL))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func urls(pages []*Page) []string {
+	res := make([]string, 0, len(pages))
+	for _, p := range pages {
+		res = append(res, p.URL)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// 测试站点在127.0.0.1上，需要允许非公网地址
+func crawl(t *testing.T, opts Options, seeds ...string) *Result {
+	opts.AllowPrivate = true
+	c, err := New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := c.Crawl(context.Background(), seeds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestCrawlDepthAndPatterns(t *testing.T) {
+	srv := newSite(t, map[string]string{
+		"/":          `<a href="/a">a</a><a href="/b#top">b</a><a href="/private/x">x</a>`,
+		"/a":         `<a href="/a/deep">deep</a>`,
+		"/b":         `<a href="/">home</a>`,
+		"/a/deep":    `deep`,
+		"/private/x": `private`,
+	})
+
+	res := crawl(t, Options{MaxDepth: 0}, srv.URL)
+	if got := urls(res.Pages); len(got) != 1 || got[0] != srv.URL+"/" {
+		t.Fatalf("depth 0 pages = %v", got)
+	}
+
+	res = crawl(t, Options{MaxDepth: 1, Exclude: []string{"/private/"}}, srv.URL)
+	want := []string{srv.URL + "/", srv.URL + "/a", srv.URL + "/b"}
+	if got := urls(res.Pages); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("depth 1 pages = %v, want %v", got, want)
+	}
+
+	res = crawl(t, Options{MaxDepth: 5, Include: []string{`/a`, `/$`}}, srv.URL)
+	want = []string{srv.URL + "/", srv.URL + "/a", srv.URL + "/a/deep"}
+	if got := urls(res.Pages); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("include pages = %v, want %v", got, want)
+	}
+
+	res = crawl(t, Options{MaxDepth: 5, MaxPages: 2}, srv.URL)
+	if len(res.Pages) != 2 {
+		t.Fatalf("max pages = %d, want 2", len(res.Pages))
+	}
+}
+
+func TestCrawlSameDomain(t *testing.T) {
+	other := newSite(t, map[string]string{"/": `other`})
+	// 通过localhost访问另一个站点，使两个站点的域名不同
+	otherURL := strings.Replace(other.URL, "127.0.0.1", "localhost", 1)
+	srv := newSite(t, map[string]string{
+		"/":  `<a href="` + otherURL + `/">other</a><a href="/a">a</a>`,
+		"/a": `a`,
+	})
+
+	res := crawl(t, Options{MaxDepth: 1, SameDomain: true}, srv.URL)
+	want := []string{srv.URL + "/", srv.URL + "/a"}
+	if got := urls(res.Pages); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("same domain pages = %v, want %v", got, want)
+	}
+	res = crawl(t, Options{MaxDepth: 1}, srv.URL)
+	if len(res.Pages) != 3 {
+		t.Fatalf("cross domain pages = %v", urls(res.Pages))
+	}
+}
+
+func TestCrawlRobotsAndCanonical(t *testing.T) {
+	srv := newSite(t, map[string]string{
+		"/robots.txt": "User-agent: *\nDisallow: /secret\n",
+		"/":           `<a href="/secret">s</a><a href="/dup?utm=1">d</a><a href="/doc.txt">t</a>`,
+		"/secret":     `secret`,
+		"/dup":        `<html><head><link rel="canonical" href="/"></head></html>`,
+		"/doc.txt":    `plain text`,
+	})
+
+	res := crawl(t, Options{MaxDepth: 1, RespectRobots: true}, srv.URL)
+	want := []string{srv.URL + "/", srv.URL + "/doc.txt"}
+	if got := urls(res.Pages); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("robots pages = %v, want %v", got, want)
+	}
+	for _, p := range res.Pages {
+		if p.URL == srv.URL+"/doc.txt" && p.ContentType != "text/plain" {
+			t.Fatalf("content type = %s, want text/plain", p.ContentType)
+		}
+	}
+
+	// 不遵守robots.txt时可以抓取到/secret
+	res = crawl(t, Options{MaxDepth: 1}, srv.URL)
+	if len(res.Pages) != 3 {
+		t.Fatalf("pages without robots = %v", urls(res.Pages))
+	}
+}
+
+func TestSitemap(t *testing.T) {
+	srv := newSite(t, map[string]string{
+		"/sitemap.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>{{host}}/pages.xml</loc></sitemap>
+</sitemapindex>`,
+		"/pages.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>{{host}}/a</loc></url>
+  <url><loc> {{host}}/b </loc></url>
+</urlset>`,
+		"/a": `a`,
+		"/b": `b`,
+	})
+	c, err := New(Options{AllowPrivate: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.Sitemap(context.Background(), srv.URL+"/sitemap.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{srv.URL + "/a", srv.URL + "/b"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("sitemap = %v, want %v", got, want)
+	}
+	res, err := c.Crawl(context.Background(), got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Pages) != 2 || len(res.Failed) != 0 {
+		t.Fatalf("crawl sitemap pages = %v, failed = %d", urls(res.Pages), len(res.Failed))
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(Options{Include: []string{"("}}); err == nil {
+		t.Fatal("invalid pattern should fail")
+	}
+}
+
+func TestFetchNotModified(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, `<title>t</title>`)
+	}))
+	defer srv.Close()
+	c, err := New(Options{AllowPrivate: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, err := c.Fetch(context.Background(), srv.URL, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.ETag != `"v1"` || page.LastModified == "" || page.Title != "t" {
+		t.Fatalf("page = %+v", page)
+	}
+	if _, err = c.Fetch(context.Background(), srv.URL, page.ETag, page.LastModified); err != ErrNotModified {
+		t.Fatalf("err = %v, want ErrNotModified", err)
+	}
+}
+
+func TestFetchRobots(t *testing.T) {
+	srv := newSite(t, map[string]string{
+		"/robots.txt": "User-agent: *\nDisallow: /private\n",
+		"/public":     `<title>public</title>`,
+		"/private":    `<title>private</title>`,
+	})
+	c, err := New(Options{AllowPrivate: true, RespectRobots: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.Fetch(context.Background(), srv.URL+"/public", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.Fetch(context.Background(), srv.URL+"/private", "", ""); !errors.Is(err, ErrRobotsDisallowed) {
+		t.Fatalf("err = %v, want ErrRobotsDisallowed", err)
+	}
+	// 不遵守robots.txt时可以抓取
+	c, err = New(Options{AllowPrivate: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page, err := c.Fetch(context.Background(), srv.URL+"/private", "", ""); err != nil || page.Title != "private" {
+		t.Fatalf("page = %+v, err = %v", page, err)
+	}
+}
+
+func TestPrivateAddressRefused(t *testing.T) {
+	srv := newSite(t, map[string]string{"/": `<a href="/a">a</a>`, "/a": `a`})
+	c, err := New(Options{MaxDepth: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.Fetch(context.Background(), srv.URL, "", ""); !errors.Is(err, ErrPrivateAddress) {
+		t.Fatalf("Fetch() err = %v, want ErrPrivateAddress", err)
+	}
+	res, err := c.Crawl(context.Background(), []string{srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Pages) != 0 || len(res.Failed) != 1 {
+		t.Fatalf("pages = %v, failed = %d", urls(res.Pages), len(res.Failed))
+	}
+}
+
+func TestIsPublic(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"8.8.8.8", true},
+		{"2606:4700:4700::1111", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"100.100.100.200", false},
+		{"224.0.0.1", false},
+		{"255.255.255.255", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:169.254.169.254", false},
+		{"64:ff9b::a9fe:a9fe", false},
+	}
+	for _, tt := range tests {
+		if got := isPublic(netip.MustParseAddr(tt.addr)); got != tt.want {
+			t.Errorf("isPublic(%s) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package crawler
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// ErrPrivateAddress 网页的地址解析到了非公网地址
+var ErrPrivateAddress = gerror.New("private address not allowed")
+
+// 不属于公网的地址段，net/netip中没有判断方法的部分
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // 本网络
+	netip.MustParsePrefix("100.64.0.0/10"),   // 运营商级NAT
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF协议分配
+	netip.MustParsePrefix("192.0.2.0/24"),    // 文档示例
+	netip.MustParsePrefix("198.18.0.0/15"),   // 基准测试
+	netip.MustParsePrefix("198.51.100.0/24"), // 文档示例
+	netip.MustParsePrefix("203.0.113.0/24"),  // 文档示例
+	netip.MustParsePrefix("240.0.0.0/4"),     // 保留地址和广播地址
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64，可能映射到内网的IPv4地址
+	netip.MustParsePrefix("2001:db8::/32"),   // 文档示例
+}
+
+// 创建抓取使用的http.Client，allowPrivate为false时建立连接前检查DNS解析后的地址，重定向后的请求同样会检查
+// 不使用环境变量中的代理，代理本身通常是内网地址，而且经过代理时无法检查目标地址
+func newClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second, KeepAlive: 30 * time.Second}
+	if !allowPrivate {
+		dialer.Control = denyPrivate
+	}
+	return &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: time.Second,
+		},
+	}
+}
+
+// 连接前检查地址，address已经是解析后的IP
+func denyPrivate(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return gerror.Wrap(err, "")
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return gerror.Wrap(err, "")
+	}
+	if !isPublic(addr) {
+		return gerror.Wrapf(ErrPrivateAddress, "%s", addr)
+	}
+	return nil
+}
+
+// 是否是公网地址，拒绝环回、内网、链路本地(包括云主机元数据169.254.169.254)、组播和保留地址
+func isPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() {
+		return false
+	}
+	for _, p := range reservedPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
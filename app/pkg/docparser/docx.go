@@ -0,0 +1,248 @@
+package docparser
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// DocxParser 解析Word文档(.docx)，输出markdown格式的文本
+// 按一到三级标题切分为多个文档，标题记录在h1、h2、h3元数据中，列表、表格转换为markdown
+type DocxParser struct{}
+
+func NewDocxParser() *DocxParser {
+	return &DocxParser{}
+}
+
+func (p *DocxParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	options := parser.GetCommonOptions(&parser.Options{}, opts...)
+	zr, err := openZip(reader)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := readXML(zr, "word/document.xml")
+	if err != nil {
+		return nil, err
+	}
+	body := doc.path("body")
+	if body == nil {
+		return nil, gerror.New("invalid docx: word/document.xml not found")
+	}
+	styles, err := readXML(zr, "word/styles.xml")
+	if err != nil {
+		return nil, err
+	}
+	numbering, err := readXML(zr, "word/numbering.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	w := &docxWriter{
+		headingStyles: headingStyles(styles),
+		orderedLists:  orderedLists(numbering),
+		counters:      make(map[string][]int),
+	}
+	w.blocks(body.Nodes)
+	return w.documents(options, nil), nil
+}
+
+type docxWriter struct {
+	sectionWriter
+	// 样式id对应的标题级别
+	headingStyles map[string]int
+	// 列表编号id对应的每一级是否是有序列表
+	orderedLists map[string][]bool
+	// 有序列表每一级的当前序号
+	counters map[string][]int
+}
+
+// 处理body、单元格等容器内的块级元素
+func (w *docxWriter) blocks(nodes []*xmlNode) {
+	for _, n := range nodes {
+		switch n.XMLName.Local {
+		case "p":
+			w.paragraph(n)
+		case "tbl":
+			w.write(markdownTable(tableRows(n)), false)
+		case "sdt":
+			// 内容控件
+			if c := n.child("sdtContent"); c != nil {
+				w.blocks(c.Nodes)
+			}
+		}
+	}
+}
+
+func (w *docxWriter) paragraph(p *xmlNode) {
+	text := strings.TrimSpace(paragraphText(p))
+	if text == "" {
+		return
+	}
+	pPr := p.child("pPr")
+	if level := w.headingLevel(pPr); level > 0 {
+		w.heading(level, text)
+		return
+	}
+	if numPr := pPr.path("numPr"); numPr != nil {
+		numID := numPr.path("numId").attr("val")
+		if numID != "" && numID != "0" {
+			ilvl, _ := strconv.Atoi(numPr.path("ilvl").attr("val"))
+			w.write(strings.Repeat("  ", ilvl)+w.listMarker(numID, ilvl)+" "+text, true)
+			return
+		}
+	}
+	w.write(text, false)
+}
+
+// 段落的标题级别，不是标题时返回0
+func (w *docxWriter) headingLevel(pPr *xmlNode) int {
+	if lvl := pPr.path("outlineLvl"); lvl != nil {
+		if n, err := strconv.Atoi(lvl.attr("val")); err == nil && n < 9 {
+			return n + 1
+		}
+	}
+	if style := pPr.path("pStyle"); style != nil {
+		return w.headingStyles[style.attr("val")]
+	}
+	return 0
+}
+
+// 列表项的标记，无序列表使用-，有序列表使用序号
+func (w *docxWriter) listMarker(numID string, ilvl int) string {
+	levels := w.orderedLists[numID]
+	if ilvl >= len(levels) || !levels[ilvl] {
+		return "-"
+	}
+	counters := w.counters[numID]
+	for len(counters) <= ilvl {
+		counters = append(counters, 0)
+	}
+	counters[ilvl]++
+	// 上一级的序号变化后，下一级重新开始编号
+	for i := ilvl + 1; i < len(counters); i++ {
+		counters[i] = 0
+	}
+	w.counters[numID] = counters
+	return fmt.Sprintf("%d.", counters[ilvl])
+}
+
+// 段落中的文本，忽略修订中删除的内容和域代码
+func paragraphText(n *xmlNode) string {
+	var b strings.Builder
+	var walk func(n *xmlNode)
+	walk = func(n *xmlNode) {
+		for _, c := range n.Nodes {
+			switch c.XMLName.Local {
+			case "t":
+				b.WriteString(c.Text)
+			case "tab":
+				b.WriteString("\t")
+			case "br", "cr":
+				b.WriteString("\n")
+			case "pPr", "rPr", "del", "instrText", "delText":
+			case "p":
+				// 文本框等嵌套的段落
+				if b.Len() > 0 {
+					b.WriteString("\n")
+				}
+				walk(c)
+			default:
+				walk(c)
+			}
+		}
+	}
+	walk(n)
+	return b.String()
+}
+
+// 表格的每一行，单元格中的多个段落合并为一行，嵌套表格只保留文本
+func tableRows(tbl *xmlNode) [][]string {
+	var rows [][]string
+	for _, tr := range tbl.Nodes {
+		if tr.XMLName.Local != "tr" {
+			continue
+		}
+		var row []string
+		for _, tc := range tr.Nodes {
+			if tc.XMLName.Local != "tc" {
+				continue
+			}
+			row = append(row, strings.Join(strings.Fields(paragraphText(tc)), " "))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// 解析styles.xml，返回标题样式id对应的级别
+// 中文版Word的样式id可能是数字，所以根据样式名称判断，名称固定为英文的heading 1、Title等
+func headingStyles(styles *xmlNode) map[string]int {
+	res := make(map[string]int)
+	if styles == nil {
+		return res
+	}
+	for _, s := range styles.Nodes {
+		if s.XMLName.Local != "style" || s.attr("type") != "paragraph" {
+			continue
+		}
+		id := s.attr("styleId")
+		name := strings.ToLower(s.path("name").attr("val"))
+		switch {
+		case name == "title":
+			res[id] = 1
+		case strings.HasPrefix(name, "heading "):
+			if n, err := strconv.Atoi(strings.TrimPrefix(name, "heading ")); err == nil && n > 0 {
+				res[id] = n
+			}
+		default:
+			if lvl := s.path("pPr", "outlineLvl"); lvl != nil {
+				if n, err := strconv.Atoi(lvl.attr("val")); err == nil && n < 9 {
+					res[id] = n + 1
+				}
+			}
+		}
+	}
+	return res
+}
+
+// 解析numbering.xml，返回列表编号id对应的每一级是否是有序列表
+func orderedLists(numbering *xmlNode) map[string][]bool {
+	res := make(map[string][]bool)
+	if numbering == nil {
+		return res
+	}
+	abstracts := make(map[string][]bool)
+	for _, a := range numbering.Nodes {
+		if a.XMLName.Local != "abstractNum" {
+			continue
+		}
+		var levels []bool
+		for _, lvl := range a.Nodes {
+			if lvl.XMLName.Local != "lvl" {
+				continue
+			}
+			i, err := strconv.Atoi(lvl.attr("ilvl"))
+			if err != nil || i < 0 || i > 8 {
+				continue
+			}
+			for len(levels) <= i {
+				levels = append(levels, false)
+			}
+			numFmt := lvl.path("numFmt").attr("val")
+			levels[i] = numFmt != "" && numFmt != "bullet" && numFmt != "none"
+		}
+		abstracts[a.attr("abstractNumId")] = levels
+	}
+	for _, n := range numbering.Nodes {
+		if n.XMLName.Local == "num" {
+			res[n.attr("numId")] = abstracts[n.path("abstractNumId").attr("val")]
+		}
+	}
+	return res
+}
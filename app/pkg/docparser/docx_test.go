@@ -0,0 +1,102 @@
+package docparser
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+)
+
+// 创建只包含指定文件的Office压缩包
+func newZip(t *testing.T, files map[string]string) *bytes.Buffer {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+const wordNS = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"`
+
+func para(style, text string) string {
+	if style == "" {
+		return `<w:p><w:r><w:t>` + text + `</w:t></w:r></w:p>`
+	}
+	return `<w:p><w:pPr><w:pStyle w:val="` + style + `"/></w:pPr><w:r><w:t>` + text + `</w:t></w:r></w:p>`
+}
+
+func listItem(numID, ilvl, text string) string {
+	return `<w:p><w:pPr><w:numPr><w:ilvl w:val="` + ilvl + `"/><w:numId w:val="` + numID + `"/></w:numPr></w:pPr><w:r><w:t>` + text + `</w:t></w:r></w:p>`
+}
+
+func TestDocxParser(t *testing.T) {
+	body := para("1", "概述") + para("", "引言") +
+		para("2", "安装") +
+		listItem("1", "0", "下载") + listItem("1", "1", "解压") + listItem("1", "0", "运行") +
+		listItem("2", "0", "注意") +
+		`<w:tbl><w:tr><w:tc>` + para("", "参数") + `</w:tc><w:tc>` + para("", "说明") + `</w:tc></w:tr>` +
+		`<w:tr><w:tc>` + para("", "a|b") + `</w:tc><w:tc>` + para("", "第一行") + para("", "第二行") + `</w:tc></w:tr></w:tbl>` +
+		`<w:p><w:r><w:t>保留</w:t></w:r><w:del><w:r><w:delText>删除</w:delText></w:r></w:del></w:p>` +
+		para("Heading4", "细节") + para("", "正文")
+	files := map[string]string{
+		"word/document.xml": `<w:document ` + wordNS + `><w:body>` + body + `</w:body></w:document>`,
+		"word/styles.xml": `<w:styles ` + wordNS + `>
+<w:style w:type="paragraph" w:styleId="1"><w:name w:val="heading 1"/></w:style>
+<w:style w:type="paragraph" w:styleId="2"><w:name w:val="heading 2"/></w:style>
+<w:style w:type="paragraph" w:styleId="Heading4"><w:name w:val="heading 4"/></w:style>
+</w:styles>`,
+		"word/numbering.xml": `<w:numbering ` + wordNS + `>
+<w:abstractNum w:abstractNumId="0"><w:lvl w:ilvl="0"><w:numFmt w:val="decimal"/></w:lvl><w:lvl w:ilvl="1"><w:numFmt w:val="bullet"/></w:lvl></w:abstractNum>
+<w:abstractNum w:abstractNumId="1"><w:lvl w:ilvl="0"><w:numFmt w:val="bullet"/></w:lvl></w:abstractNum>
+<w:num w:numId="1"><w:abstractNumId w:val="0"/></w:num>
+<w:num w:numId="2"><w:abstractNumId w:val="1"/></w:num>
+</w:numbering>`,
+	}
+	docs, err := NewDocxParser().Parse(context.Background(), newZip(t, files),
+		parser.WithURI("a.docx"), parser.WithExtraMeta(map[string]any{"_file_name": "a.docx"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("docs = %d, want 2", len(docs))
+	}
+	if docs[0].Content != "# 概述\n\n引言" || docs[0].MetaData[MetaTitle1] != "概述" || docs[0].MetaData["_file_name"] != "a.docx" {
+		t.Fatalf("doc 0 = %q %v", docs[0].Content, docs[0].MetaData)
+	}
+	if docs[1].MetaData[MetaTitle1] != "概述" || docs[1].MetaData[MetaTitle2] != "安装" {
+		t.Fatalf("doc 1 meta = %v", docs[1].MetaData)
+	}
+	want := strings.Join([]string{
+		"## 安装",
+		"1. 下载\n  - 解压\n2. 运行\n- 注意",
+		"| 参数 | 说明 |\n| --- | --- |\n| a\\|b | 第一行 第二行 |",
+		"保留",
+		"#### 细节",
+		"正文",
+	}, "\n\n")
+	if docs[1].Content != want {
+		t.Fatalf("doc 1 content = %q, want %q", docs[1].Content, want)
+	}
+}
+
+func TestDocxParserInvalid(t *testing.T) {
+	if _, err := NewDocxParser().Parse(context.Background(), strings.NewReader("not a zip")); err == nil {
+		t.Fatal("invalid docx should fail")
+	}
+	if _, err := NewDocxParser().Parse(context.Background(), newZip(t, map[string]string{"a.txt": "a"})); err == nil {
+		t.Fatal("docx without document.xml should fail")
+	}
+}
@@ -0,0 +1,493 @@
+package docparser
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+const (
+	// 邮件主题
+	MetaSubject = "subject"
+	// 发件人
+	MetaFrom = "from"
+	// 收件人
+	MetaTo = "to"
+	// 发送时间，RFC3339格式
+	MetaDate = "date"
+	// 邮件的Message-ID，不含尖括号
+	MetaMessageID = "message_id"
+	// 回复的邮件的Message-ID
+	MetaInReplyTo = "in_reply_to"
+	// 邮件所属会话的id，即会话中第一封邮件的Message-ID
+	MetaThreadID = "thread_id"
+	// 附件的文件名，只有附件解析出的文档才有
+	MetaAttachment = "attachment"
+
+	// 嵌套的multipart、邮件附件最多处理的层数
+	maxMIMEDepth = 5
+)
+
+var (
+	// 引用原邮件的开头，之后的内容都是原邮件，例如"On Mon, Jan 1, 2024 at 10:00 AM Tom <tom@a.com> wrote:"
+	quoteHeaderRe = regexp.MustCompile(`(?i)^(on\s.+wrote:|在.+写道[:：]|-{2,}\s*(original message|原始邮件|转发的邮件)\s*-{2,}|_{10,})\s*$`)
+	// Outlook回复时引用的原邮件头，发件人和发送时间在连续的两行
+	outlookFromRe = regexp.MustCompile(`(?i)^(from|发件人)\s*[:：]`)
+	outlookSentRe = regexp.MustCompile(`(?i)^(sent|date|发送时间|日期)\s*[:：]`)
+
+	headerDecoder = &mime.WordDecoder{CharsetReader: charsetReader}
+)
+
+// EmailParser 解析邮件文件(.eml)，正文优先使用纯文本，没有纯文本时从HTML中提取，并去掉回复时引用的原邮件
+// 主题、发件人、收件人、时间和会话信息记录在元数据中，附件使用attachments解析后作为单独的文档
+type EmailParser struct {
+	attachments parser.Parser
+}
+
+// NewEmailParser attachments用于解析附件，为nil时忽略附件
+func NewEmailParser(attachments parser.Parser) *EmailParser {
+	return &EmailParser{attachments: attachments}
+}
+
+func (p *EmailParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	options := parser.GetCommonOptions(&parser.Options{}, opts...)
+	data, err := readAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	m, err := readEmail(ctx, p.attachments, data, 0)
+	if err != nil {
+		return nil, err
+	}
+	m.threadID = m.rootID()
+	return m.documents(options), nil
+}
+
+// MboxParser 解析mbox格式的邮箱文件(.mbox)，每封邮件的处理方式与EmailParser相同
+// 邮箱内的邮件根据Message-ID和In-Reply-To关联到同一个会话
+type MboxParser struct {
+	attachments parser.Parser
+}
+
+// NewMboxParser attachments用于解析附件，为nil时忽略附件
+func NewMboxParser(attachments parser.Parser) *MboxParser {
+	return &MboxParser{attachments: attachments}
+}
+
+func (p *MboxParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	options := parser.GetCommonOptions(&parser.Options{}, opts...)
+	data, err := readAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	var msgs []*email
+	byID := make(map[string]*email)
+	for _, raw := range splitMbox(data) {
+		m, err := readEmail(ctx, p.attachments, raw, 0)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, m)
+		if m.messageID != "" {
+			byID[m.messageID] = m
+		}
+	}
+	var docs []*schema.Document
+	for _, m := range msgs {
+		m.threadID = threadID(m, byID)
+		docs = append(docs, m.documents(options)...)
+	}
+	return docs, nil
+}
+
+// 会话id，沿着In-Reply-To找到邮箱内最早的邮件
+func threadID(m *email, byID map[string]*email) string {
+	for i := 0; i < 100; i++ {
+		if len(m.references) > 0 {
+			return m.references[0]
+		}
+		parent, ok := byID[m.inReplyTo]
+		if !ok || parent == m {
+			break
+		}
+		m = parent
+	}
+	return m.rootID()
+}
+
+// 拆分mbox文件中的邮件，每封邮件以空行后的"From "行开头
+// 正文中以"From "开头的行在写入时被转义为">From "，需要还原
+func splitMbox(data []byte) [][]byte {
+	var res [][]byte
+	var cur bytes.Buffer
+	started, blank := false, true
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), maxFileSize)
+	for sc.Scan() {
+		line := sc.Bytes()
+		if blank && bytes.HasPrefix(line, []byte("From ")) {
+			if started && cur.Len() > 0 {
+				res = append(res, bytes.Clone(cur.Bytes()))
+			}
+			cur.Reset()
+			started, blank = true, false
+			continue
+		}
+		blank = len(bytes.TrimRight(line, "\r")) == 0
+		if !started {
+			continue
+		}
+		if unquoted := bytes.TrimLeft(line, ">"); len(unquoted) < len(line) && bytes.HasPrefix(unquoted, []byte("From ")) {
+			line = line[1:]
+		}
+		cur.Write(line)
+		cur.WriteString("\n")
+	}
+	if !started {
+		// 没有分隔行时作为一封邮件处理
+		return [][]byte{data}
+	}
+	if cur.Len() > 0 {
+		res = append(res, bytes.Clone(cur.Bytes()))
+	}
+	return res
+}
+
+// 一封邮件的内容
+type email struct {
+	subject    string
+	from       string
+	to         string
+	date       string
+	messageID  string
+	inReplyTo  string
+	references []string
+	threadID   string
+	// 纯文本正文和HTML正文
+	plain []string
+	html  []string
+	// 附件解析出的文档，嵌套的邮件也作为附件
+	attachments []*schema.Document
+}
+
+// 没有邮箱内的其他邮件时，根据邮件头得到会话id
+func (m *email) rootID() string {
+	if len(m.references) > 0 {
+		return m.references[0]
+	}
+	if m.inReplyTo != "" {
+		return m.inReplyTo
+	}
+	return m.messageID
+}
+
+// 邮件正文和附件转换为文档，正文前加上邮件头，检索时能看到邮件的上下文
+func (m *email) documents(options *parser.Options) []*schema.Document {
+	extra := map[string]any{}
+	var headers []string
+	for _, h := range []struct{ key, label, value string }{
+		{MetaSubject, "主题", m.subject},
+		{MetaFrom, "发件人", m.from},
+		{MetaTo, "收件人", m.to},
+		{MetaDate, "时间", m.date},
+	} {
+		if h.value != "" {
+			extra[h.key] = h.value
+			headers = append(headers, h.label+": "+h.value)
+		}
+	}
+	for k, v := range map[string]string{MetaMessageID: m.messageID, MetaInReplyTo: m.inReplyTo, MetaThreadID: m.threadID} {
+		if v != "" {
+			extra[k] = v
+		}
+	}
+
+	var docs []*schema.Document
+	body := strings.TrimSpace(stripQuoted(strings.Join(m.plain, "\n\n")))
+	if body == "" && len(m.html) > 0 {
+		body = strings.TrimSpace(stripQuoted(htmlText(strings.Join(m.html, "\n"))))
+	}
+	if body != "" || len(headers) > 0 {
+		content := strings.TrimSpace(strings.Join(headers, "\n") + "\n\n" + body)
+		docs = append(docs, &schema.Document{Content: content, MetaData: newMeta(options, extra)})
+	}
+	meta := newMeta(options, extra)
+	for _, d := range m.attachments {
+		if d.MetaData == nil {
+			d.MetaData = make(map[string]any)
+		}
+		// 附件保留自己的扩展名、页码等元数据，其余使用邮件的元数据，来源是邮件文件
+		for k, v := range meta {
+			if _, ok := d.MetaData[k]; !ok || k == "_source" {
+				d.MetaData[k] = v
+			}
+		}
+		docs = append(docs, d)
+	}
+	return docs
+}
+
+// 解析一封邮件
+func readEmail(ctx context.Context, attachments parser.Parser, data []byte, depth int) (*email, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return nil, gerror.Wrap(err, "invalid email")
+	}
+	h := msg.Header
+	m := &email{
+		subject:   decodeHeader(h.Get("Subject")),
+		from:      addressList(h.Get("From")),
+		to:        addressList(h.Get("To")),
+		messageID: firstMessageID(h.Get("Message-Id")),
+		inReplyTo: firstMessageID(h.Get("In-Reply-To")),
+	}
+	if t, err := h.Date(); err == nil {
+		m.date = t.Format(time.RFC3339)
+	}
+	for _, id := range strings.Fields(h.Get("References")) {
+		if id = strings.Trim(id, "<>"); id != "" {
+			m.references = append(m.references, id)
+		}
+	}
+	w := &mailWalker{ctx: ctx, attachments: attachments, msg: m, depth: depth}
+	if err = w.part(textproto.MIMEHeader(h), msg.Body, 0); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// 遍历邮件的MIME结构，收集正文和附件
+type mailWalker struct {
+	ctx         context.Context
+	attachments parser.Parser
+	msg         *email
+	// 所在邮件的嵌套层数
+	depth int
+}
+
+// 处理一个MIME部分，depth是multipart的嵌套层数
+func (w *mailWalker) part(h textproto.MIMEHeader, body io.Reader, depth int) error {
+	if depth > maxMIMEDepth {
+		return nil
+	}
+	mediaType, params, err := mime.ParseMediaType(h.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+	switch strings.ToLower(h.Get("Content-Transfer-Encoding")) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			p, err := mr.NextPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return gerror.Wrap(err, "invalid multipart email")
+			}
+			if err = w.part(p.Header, p, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	disposition, dparams, _ := mime.ParseMediaType(h.Get("Content-Disposition"))
+	filename := dparams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	filename = decodeHeader(filename)
+	data, err := io.ReadAll(io.LimitReader(body, maxFileSize))
+	if err != nil {
+		return gerror.Wrap(err, "read email part failed")
+	}
+
+	switch {
+	case mediaType == "message/rfc822":
+		// 转发的邮件，作为单独的文档
+		if w.depth >= maxMIMEDepth {
+			return nil
+		}
+		m, err := readEmail(w.ctx, w.attachments, data, w.depth+1)
+		if err != nil {
+			// 附件解析失败不影响邮件正文
+			return nil
+		}
+		m.threadID = m.rootID()
+		docs := m.documents(&parser.Options{})
+		for _, d := range docs {
+			if _, ok := d.MetaData[MetaAttachment]; !ok {
+				d.MetaData[MetaAttachment] = m.subject
+			}
+		}
+		w.msg.attachments = append(w.msg.attachments, docs...)
+	case disposition == "attachment" || filename != "":
+		return w.attachment(filename, mediaType, data)
+	case mediaType == "text/plain":
+		w.msg.plain = append(w.msg.plain, decodeCharset(data, params["charset"]))
+	case mediaType == "text/html":
+		w.msg.html = append(w.msg.html, decodeCharset(data, params["charset"]))
+	}
+	return nil
+}
+
+// 使用已有的解析器解析附件，只处理有对应解析器的文件和文本文件，忽略图片等
+func (w *mailWalker) attachment(filename, mediaType string, data []byte) error {
+	if w.attachments == nil || filename == "" || len(data) == 0 {
+		return nil
+	}
+	ext := strings.ToLower(path.Ext(filename))
+	if ext == ".eml" {
+		return w.part(textproto.MIMEHeader{"Content-Type": {"message/rfc822"}}, bytes.NewReader(data), 0)
+	}
+	if !strings.HasPrefix(mediaType, "text/") && !hasParser(w.attachments, ext) {
+		return nil
+	}
+	docs, err := w.attachments.Parse(w.ctx, bytes.NewReader(data), parser.WithURI(filename), parser.WithExtraMeta(map[string]any{
+		"_extension":   ext,
+		MetaAttachment: filename,
+	}))
+	if err != nil {
+		// 附件解析失败不影响邮件正文
+		return nil
+	}
+	w.msg.attachments = append(w.msg.attachments, docs...)
+	return nil
+}
+
+// 解析器是否支持该扩展名，不是ExtParser时认为都支持
+func hasParser(p parser.Parser, ext string) bool {
+	ep, ok := p.(*parser.ExtParser)
+	if !ok {
+		return true
+	}
+	_, ok = ep.GetParsers()[ext]
+	return ok
+}
+
+// 去掉回复时引用的原邮件，包括以>开头的行和"xxx wrote:"之后的内容
+func stripQuoted(body string) string {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	kept := make([]string, 0, len(lines))
+	for i, l := range lines {
+		t := strings.TrimSpace(l)
+		if quoteHeaderRe.MatchString(t) {
+			break
+		}
+		if outlookFromRe.MatchString(t) && i+1 < len(lines) && outlookSentRe.MatchString(strings.TrimSpace(lines[i+1])) {
+			break
+		}
+		if strings.HasPrefix(t, ">") {
+			continue
+		}
+		kept = append(kept, l)
+	}
+	return strings.Join(kept, "\n")
+}
+
+// 提取HTML邮件的文本，表格、列表转换为markdown
+func htmlText(s string) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(s))
+	if err != nil {
+		return ""
+	}
+	doc.Find("head,script,style,noscript,template").Remove()
+	// 邮件客户端引用原邮件时使用的元素
+	doc.Find("blockquote[type=cite],.gmail_quote,#divRplyFwdMsg,#appendonsend").Remove()
+	w := &sectionWriter{}
+	for _, n := range doc.Find("body").Nodes {
+		renderBlock(w, n)
+	}
+	w.flush()
+	parts := make([]string, 0, len(w.sections))
+	for _, s := range w.sections {
+		parts = append(parts, s.content)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// 解码邮件头中的编码字，例如=?UTF-8?B?...?=
+func decodeHeader(s string) string {
+	if res, err := headerDecoder.DecodeHeader(s); err == nil {
+		s = res
+	}
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// 地址列表格式化为"名称 <地址>"，解析失败时返回解码后的原始内容
+func addressList(s string) string {
+	if s == "" {
+		return ""
+	}
+	ap := mail.AddressParser{WordDecoder: headerDecoder}
+	list, err := ap.ParseList(s)
+	if err != nil {
+		return decodeHeader(s)
+	}
+	res := make([]string, 0, len(list))
+	for _, a := range list {
+		if a.Name != "" {
+			res = append(res, a.Name+" <"+a.Address+">")
+		} else {
+			res = append(res, a.Address)
+		}
+	}
+	return strings.Join(res, ", ")
+}
+
+// 第一个Message-ID，去掉尖括号
+func firstMessageID(s string) string {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.Trim(fields[0], "<>")
+}
+
+// 将指定字符集的文本转换为UTF-8，中文邮件常用GBK、GB2312
+func decodeCharset(data []byte, charset string) string {
+	r, err := charsetReader(charset, bytes.NewReader(data))
+	if err != nil {
+		return string(data)
+	}
+	res, err := io.ReadAll(r)
+	if err != nil {
+		return string(data)
+	}
+	return string(res)
+}
+
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	charset = strings.ToLower(strings.TrimSpace(charset))
+	if charset == "" || charset == "utf-8" || charset == "us-ascii" {
+		return input, nil
+	}
+	enc, err := htmlindex.Get(charset)
+	if err != nil {
+		return nil, gerror.Wrapf(err, "unsupported charset: %s", charset)
+	}
+	return enc.NewDecoder().Reader(input), nil
+}
@@ -0,0 +1,97 @@
+package docparser
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+)
+
+const testEmail = "From: =?UTF-8?B?5byg5LiJ?= <zhang@example.com>\r\n" +
+	"To: support@example.com\r\n" +
+	"Subject: =?UTF-8?B?55m75b2V5aSx6LSl?=\r\n" +
+	"Date: Mon, 02 Jan 2006 15:04:05 +0800\r\n" +
+	"Message-ID: <b@example.com>\r\n" +
+	"In-Reply-To: <a@example.com>\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=\"mixed\"\r\n" +
+	"\r\n" +
+	"--mixed\r\n" +
+	"Content-Type: multipart/alternative; boundary=\"alt\"\r\n" +
+	"\r\n" +
+	"--alt\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"=E9=87=8D=E7=BD=AE=E5=AF=86=E7=A0=81=E5=90=8E=E4=BB=8D=E7=84=B6=E6=97=A0=E6=B3=95=E7=99=BB=E5=BD=95=E3=80=82\r\n" +
+	"\r\n" +
+	"On Mon, Jan 2, 2006 at 10:00 AM Support <support@example.com> wrote:\r\n" +
+	"> please reset your password\r\n" +
+	"--alt\r\n" +
+	"Content-Type: text/html; charset=utf-8\r\n" +
+	"\r\n" +
+	"<p>HTML body</p>\r\n" +
+	"--alt--\r\n" +
+	"--mixed\r\n" +
+	"Content-Type: text/plain; name=\"log.txt\"\r\n" +
+	"Content-Disposition: attachment; filename=\"log.txt\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"ZXJyb3I6IGludmFsaWQg\r\ndG9rZW4=\r\n" +
+	"--mixed\r\n" +
+	"Content-Type: image/png; name=\"a.png\"\r\n" +
+	"Content-Disposition: attachment; filename=\"a.png\"\r\n" +
+	"\r\n" +
+	"PNG\r\n" +
+	"--mixed--\r\n"
+
+func TestEmailParser(t *testing.T) {
+	attachments, err := parser.NewExtParser(context.Background(), &parser.ExtParserConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs, err := NewEmailParser(attachments).Parse(context.Background(), strings.NewReader(testEmail), parser.WithURI("blob://a.eml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("got %d docs, want 2: %+v", len(docs), docs)
+	}
+	want := "主题: 登录失败\n发件人: 张三 <zhang@example.com>\n收件人: support@example.com\n时间: 2006-01-02T15:04:05+08:00\n\n重置密码后仍然无法登录。"
+	if docs[0].Content != want {
+		t.Errorf("content = %q, want %q", docs[0].Content, want)
+	}
+	m := docs[0].MetaData
+	if m[MetaMessageID] != "b@example.com" || m[MetaInReplyTo] != "a@example.com" || m[MetaThreadID] != "a@example.com" {
+		t.Errorf("unexpected meta: %v", m)
+	}
+	if docs[1].Content != "error: invalid token" || docs[1].MetaData[MetaAttachment] != "log.txt" ||
+		docs[1].MetaData[MetaSubject] != "登录失败" || docs[1].MetaData["_source"] != "blob://a.eml" {
+		t.Errorf("unexpected attachment: %q %v", docs[1].Content, docs[1].MetaData)
+	}
+}
+
+func TestMboxParser(t *testing.T) {
+	mbox := "From a@example.com Mon Jan  2 15:04:05 2006\n" +
+		"Message-ID: <1@x>\nSubject: q\n\nfirst\n>From here\n\n" +
+		"From b@example.com Mon Jan  2 15:05:05 2006\n" +
+		"Message-ID: <2@x>\nIn-Reply-To: <1@x>\nSubject: Re: q\n\nsecond\n\n" +
+		"From c@example.com Mon Jan  2 15:06:05 2006\n" +
+		"Message-ID: <3@x>\nIn-Reply-To: <2@x>\nSubject: Re: q\n\n<p>third</p>\n"
+	docs, err := NewMboxParser(nil).Parse(context.Background(), strings.NewReader(mbox))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 3 {
+		t.Fatalf("got %d docs, want 3", len(docs))
+	}
+	if !strings.HasSuffix(docs[0].Content, "first\nFrom here") {
+		t.Errorf("content = %q", docs[0].Content)
+	}
+	for _, d := range docs {
+		if d.MetaData[MetaThreadID] != "1@x" {
+			t.Errorf("thread = %v, want 1@x", d.MetaData[MetaThreadID])
+		}
+	}
+}
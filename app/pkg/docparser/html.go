@@ -0,0 +1,477 @@
+package docparser
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	// 参与打分的段落的最小文本长度
+	minParagraphLen = 25
+	// 正文节点的兄弟节点得分超过正文得分的这个比例时一起保留
+	siblingScoreRatio = 0.2
+)
+
+var (
+	// 不会包含正文的元素，直接删除
+	junkSelector = "script,style,noscript,template,iframe,svg,canvas,object,embed,form,button,select,input,nav,aside,footer," +
+		"[hidden],[aria-hidden=true],[role=navigation],[role=banner],[role=contentinfo],[role=dialog],[role=complementary]"
+	// class、id命中时认为不是正文，例如导航、侧边栏、评论、cookie提示
+	unlikelyRe = regexp.MustCompile(`(?i)banner|breadcrumb|combx|comment|community|cookie|consent|disqus|extra|foot|header|legends|menu|modal|related|remark|replies|rss|shoutbox|sidebar|skyscraper|social|sponsor|ad-break|agegate|pagination|pager|popup|share|subscribe|newsletter|navbar|toolbar`)
+	// class、id同时命中时仍可能是正文
+	maybeRe = regexp.MustCompile(`(?i)and|article|body|column|content|main|shadow`)
+	// class、id加分
+	positiveRe = regexp.MustCompile(`(?i)article|body|content|entry|hentry|h-entry|main|page|post|text|blog|story|markdown|prose|doc`)
+	// class、id减分
+	negativeRe = regexp.MustCompile(`(?i)hidden|banner|combx|comment|com-|contact|foot|footnote|gdpr|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|tool|widget|cookie|nav|menu|ad-`)
+	// 代码块的语言，例如language-go、lang-go
+	codeLangRe = regexp.MustCompile(`(?:^|\s)lang(?:uage)?-([\w+#.-]+)`)
+
+	// 块级元素，其余元素作为行内元素拼接
+	blockTags = map[atom.Atom]bool{
+		atom.Address: true, atom.Article: true, atom.Aside: true, atom.Blockquote: true, atom.Dd: true,
+		atom.Details: true, atom.Div: true, atom.Dl: true, atom.Dt: true, atom.Fieldset: true,
+		atom.Figcaption: true, atom.Figure: true, atom.Footer: true, atom.Form: true, atom.H1: true,
+		atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true, atom.Header: true,
+		atom.Hr: true, atom.Li: true, atom.Main: true, atom.Nav: true, atom.Ol: true, atom.P: true,
+		atom.Pre: true, atom.Section: true, atom.Summary: true, atom.Table: true, atom.Ul: true,
+	}
+)
+
+type htmlOptions struct {
+	selector string
+}
+
+// WithSelector 指定正文所在元素的CSS选择器，不再自动识别正文
+// 选择器匹配不到元素时仍然自动识别
+func WithSelector(selector string) parser.Option {
+	return parser.WrapImplSpecificOptFn(func(o *htmlOptions) {
+		o.selector = selector
+	})
+}
+
+// HTMLParser 解析网页，按readability的方式给节点打分，只保留正文，去掉导航、侧边栏、页脚等内容
+// 输出markdown格式的文本，按一到三级标题切分为多个文档，表格、代码块、列表转换为markdown，网页标题记录在title元数据中
+type HTMLParser struct{}
+
+func NewHTMLParser() *HTMLParser {
+	return &HTMLParser{}
+}
+
+func (p *HTMLParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	options := parser.GetCommonOptions(&parser.Options{}, opts...)
+	htmlOpts := parser.GetImplSpecificOptions(&htmlOptions{}, opts...)
+	data, err := readAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, gerror.Wrap(err, "invalid html")
+	}
+	title := collapseSpace(doc.Find("title").First().Text())
+	doc.Find("script,style,noscript,template").Remove()
+
+	var roots []*html.Node
+	if htmlOpts.selector != "" {
+		roots = doc.Find(htmlOpts.selector).Nodes
+	}
+	if len(roots) == 0 {
+		roots = mainContent(doc)
+	}
+
+	w := &sectionWriter{}
+	for _, n := range roots {
+		renderBlock(w, n)
+	}
+	extra := map[string]any{}
+	if title != "" {
+		extra[MetaTitle] = title
+	}
+	return w.documents(options, extra), nil
+}
+
+// 识别正文所在的节点，返回正文节点和内容相关的兄弟节点
+func mainContent(doc *goquery.Document) []*html.Node {
+	doc.Find(junkSelector).Remove()
+	// 页面级别的header，文章内的header通常包含标题，需要保留
+	doc.Find("header").Each(func(_ int, s *goquery.Selection) {
+		if s.Closest("article,main,[role=main]").Length() == 0 {
+			s.Remove()
+		}
+	})
+	doc.Find("body *").Each(func(_ int, s *goquery.Selection) {
+		n := s.Get(0)
+		switch n.DataAtom {
+		case atom.Article, atom.Main, atom.Header, atom.A, atom.Table, atom.Tbody, atom.Tr, atom.Td, atom.Th, atom.Pre, atom.Code:
+			return
+		}
+		match := attr(n, "class") + " " + attr(n, "id")
+		if unlikelyRe.MatchString(match) && !maybeRe.MatchString(match) {
+			s.Remove()
+		}
+	})
+
+	body := doc.Find("body").Get(0)
+	if body == nil {
+		return doc.Nodes
+	}
+	scores := make(map[*html.Node]float64)
+	// 按文档顺序记录候选节点，得分相同时取靠前的节点
+	var candidates []*html.Node
+	addScore := func(n *html.Node, score float64) {
+		if _, ok := scores[n]; !ok {
+			scores[n] = initialScore(n)
+			candidates = append(candidates, n)
+		}
+		scores[n] += score
+	}
+	doc.Find("p,pre,td,blockquote,div,section").Each(func(_ int, s *goquery.Selection) {
+		n := s.Get(0)
+		// div、section只有在不包含块级元素时才当作段落
+		if (n.DataAtom == atom.Div || n.DataAtom == atom.Section) && hasBlockChild(n) {
+			return
+		}
+		text := collapseSpace(s.Text())
+		length := utf8.RuneCountInString(text)
+		if length < minParagraphLen {
+			return
+		}
+		score := 1 + float64(strings.Count(text, ",")+strings.Count(text, "，")+strings.Count(text, "。"))
+		score += math.Min(float64(length/100), 3)
+		// 分数传递给父节点和祖先节点，越远的节点得分越低
+		level := 0
+		for a := n.Parent; a != nil && a.Type == html.ElementNode && level < 3; a = a.Parent {
+			switch level {
+			case 0:
+				addScore(a, score)
+			case 1:
+				addScore(a, score/2)
+			default:
+				addScore(a, score/float64(level*3))
+			}
+			level++
+		}
+	})
+
+	var top *html.Node
+	for _, n := range candidates {
+		// 链接密度高的节点通常是导航、目录
+		scores[n] *= 1 - linkDensity(n)
+		if top == nil || scores[n] > scores[top] {
+			top = n
+		}
+	}
+	if top == nil {
+		return []*html.Node{body}
+	}
+	if top.Parent == nil || top == body {
+		return []*html.Node{top}
+	}
+
+	// 正文可能被拆成多个相邻的节点，保留得分较高或文本较长的兄弟节点
+	threshold := math.Max(10, scores[top]*siblingScoreRatio)
+	var res []*html.Node
+	for s := top.Parent.FirstChild; s != nil; s = s.NextSibling {
+		if s.Type != html.ElementNode {
+			continue
+		}
+		keep := s == top
+		if score, ok := scores[s]; ok && score >= threshold {
+			keep = true
+		} else if s.DataAtom == atom.P {
+			length := utf8.RuneCountInString(collapseSpace(nodeText(s)))
+			keep = length > 80 && linkDensity(s) < 0.25
+		}
+		if keep {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// 节点的初始得分，与标签和class、id有关
+func initialScore(n *html.Node) float64 {
+	var score float64
+	switch n.DataAtom {
+	case atom.Article, atom.Main:
+		score = 10
+	case atom.Div, atom.Section:
+		score = 5
+	case atom.Pre, atom.Td, atom.Blockquote:
+		score = 3
+	case atom.Address, atom.Ol, atom.Ul, atom.Dl, atom.Dd, atom.Dt, atom.Li, atom.Form:
+		score = -3
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Th:
+		score = -5
+	}
+	for _, v := range []string{attr(n, "class"), attr(n, "id")} {
+		if v == "" {
+			continue
+		}
+		if negativeRe.MatchString(v) {
+			score -= 25
+		}
+		if positiveRe.MatchString(v) {
+			score += 25
+		}
+	}
+	return score
+}
+
+// 链接文本占全部文本的比例
+func linkDensity(n *html.Node) float64 {
+	total := utf8.RuneCountInString(collapseSpace(nodeText(n)))
+	if total == 0 {
+		return 0
+	}
+	links := 0
+	for _, a := range goquery.NewDocumentFromNode(n).Find("a").Nodes {
+		links += utf8.RuneCountInString(collapseSpace(nodeText(a)))
+	}
+	return float64(links) / float64(total)
+}
+
+func hasBlockChild(n *html.Node) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && blockTags[c.DataAtom] {
+			return true
+		}
+	}
+	return false
+}
+
+// 渲染块级元素，连续的文本和行内元素合并为一个段落
+func renderBlock(w *sectionWriter, n *html.Node) {
+	if n.Type == html.TextNode {
+		w.write(collapseSpace(n.Data), false)
+		return
+	}
+	if n.Type != html.ElementNode && n.Type != html.DocumentNode {
+		return
+	}
+	switch n.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		if text := collapseSpace(inlineText(n)); text != "" {
+			level, _ := strconv.Atoi(n.Data[1:])
+			w.heading(level, text)
+		}
+		return
+	case atom.Pre:
+		w.write(codeBlock(n), false)
+		return
+	case atom.Table:
+		w.write(markdownTable(htmlTableRows(n)), false)
+		return
+	case atom.Ul, atom.Ol:
+		for _, line := range listItems(n, 0) {
+			w.write(line, true)
+		}
+		return
+	case atom.Blockquote:
+		sub := &sectionWriter{}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			renderBlock(sub, c)
+		}
+		sub.flush()
+		var parts []string
+		for _, s := range sub.sections {
+			parts = append(parts, s.content)
+		}
+		if text := strings.Join(parts, "\n\n"); text != "" {
+			w.write("> "+strings.ReplaceAll(text, "\n", "\n> "), false)
+		}
+		return
+	case atom.Hr, atom.Img, atom.Br:
+		return
+	}
+	if !hasBlockChild(n) {
+		w.write(strings.TrimSpace(inlineText(n)), false)
+		return
+	}
+	var inline strings.Builder
+	flushInline := func() {
+		w.write(strings.TrimSpace(inline.String()), false)
+		inline.Reset()
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && blockTags[c.DataAtom] {
+			flushInline()
+			renderBlock(w, c)
+			continue
+		}
+		inline.WriteString(inlineText(c))
+	}
+	flushInline()
+}
+
+// 行内文本，空白合并为一个空格，行内代码使用反引号，br换行
+func inlineText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			b.WriteString(collapseInline(n.Data))
+			return
+		case html.ElementNode:
+		default:
+			return
+		}
+		switch n.DataAtom {
+		case atom.Br:
+			b.WriteString("\n")
+			return
+		case atom.Code:
+			if text := strings.TrimSpace(nodeText(n)); text != "" {
+				b.WriteString("`" + text + "`")
+			}
+			return
+		case atom.Img:
+			return
+		}
+		if blockTags[n.DataAtom] && b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	lines := strings.Split(b.String(), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimSpace(l)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// 代码块保留原始的空白，从class中识别语言
+func codeBlock(pre *html.Node) string {
+	code := strings.Trim(nodeText(pre), "\n")
+	if strings.TrimSpace(code) == "" {
+		return ""
+	}
+	lang := ""
+	if m := codeLangRe.FindStringSubmatch(attr(pre, "class")); m != nil {
+		lang = m[1]
+	}
+	for c := pre.FirstChild; c != nil && lang == ""; c = c.NextSibling {
+		if c.DataAtom == atom.Code {
+			if m := codeLangRe.FindStringSubmatch(attr(c, "class")); m != nil {
+				lang = m[1]
+			}
+		}
+	}
+	return "```" + lang + "\n" + code + "\n```"
+}
+
+// 列表的每一项，嵌套的列表增加缩进
+func listItems(list *html.Node, depth int) []string {
+	var lines []string
+	num := 0
+	for li := list.FirstChild; li != nil; li = li.NextSibling {
+		if li.DataAtom != atom.Li {
+			continue
+		}
+		marker := "-"
+		if list.DataAtom == atom.Ol {
+			num++
+			marker = strconv.Itoa(num) + "."
+		}
+		var text strings.Builder
+		var nested []string
+		for c := li.FirstChild; c != nil; c = c.NextSibling {
+			if c.DataAtom == atom.Ul || c.DataAtom == atom.Ol {
+				nested = append(nested, listItems(c, depth+1)...)
+				continue
+			}
+			text.WriteString(inlineText(c))
+			text.WriteString(" ")
+		}
+		if item := collapseSpace(text.String()); item != "" {
+			lines = append(lines, strings.Repeat("  ", depth)+marker+" "+item)
+		}
+		lines = append(lines, nested...)
+	}
+	return lines
+}
+
+// 表格的每一行，嵌套表格只保留文本
+func htmlTableRows(tbl *html.Node) [][]string {
+	var rows [][]string
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			switch c.DataAtom {
+			case atom.Thead, atom.Tbody, atom.Tfoot:
+				walk(c)
+			case atom.Tr:
+				var row []string
+				for td := c.FirstChild; td != nil; td = td.NextSibling {
+					if td.DataAtom == atom.Td || td.DataAtom == atom.Th {
+						row = append(row, collapseSpace(inlineText(td)))
+					}
+				}
+				if len(row) > 0 {
+					rows = append(rows, row)
+				}
+			}
+		}
+	}
+	walk(tbl)
+	return rows
+}
+
+// 节点内的原始文本
+func nodeText(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		b.WriteString(nodeText(c))
+	}
+	return b.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// 连续的空白合并为一个空格，并去掉首尾空白
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// 连续的空白合并为一个空格，保留首尾的空格，用于拼接行内元素
+func collapseInline(s string) string {
+	if s == "" {
+		return ""
+	}
+	res := collapseSpace(s)
+	if strings.TrimSpace(s[:1]) == "" {
+		res = " " + res
+	}
+	if len(res) > 0 && strings.TrimSpace(s[len(s)-1:]) == "" && res != " " {
+		res += " "
+	}
+	return res
+}
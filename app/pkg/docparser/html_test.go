@@ -0,0 +1,83 @@
+package docparser
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const testArticle = `<!DOCTYPE html>
+<html><head><title>Go 并发入门 - 技术博客</title><style>body{}</style></head>
+<body>
+<header class="site-header"><a href="/">首页</a><a href="/blog">博客</a></header>
+<nav><ul><li><a href="/a">文章一</a></li><li><a href="/b">文章二</a></li></ul></nav>
+<div id="cookie-banner">本站使用cookie，继续浏览表示同意，点击这里了解更多信息。</div>
+<div class="layout">
+  <div class="sidebar"><p>热门文章，热门标签，友情链接，广告位招租，欢迎联系。</p></div>
+  <article class="post">
+    <h1>Go 并发入门</h1>
+    <p>Go 语言通过 goroutine 和 channel 提供了简单的并发模型，开发者可以很容易地编写并发程序，而不需要直接操作线程。</p>
+    <h2>启动 goroutine</h2>
+    <p>使用 <code>go</code> 关键字即可启动一个新的 goroutine，它由运行时调度，开销很小，一个程序可以同时运行成千上万个。</p>
+    <pre><code class="language-go">func main() {
+	go work()
+}</code></pre>
+    <h2>对比</h2>
+    <table><tr><th>方式</th><th>开销</th></tr><tr><td>线程</td><td>大</td></tr><tr><td>goroutine</td><td>小</td></tr></table>
+    <ul><li>简单</li><li>高效<ol><li>调度快</li></ol></li></ul>
+  </article>
+</div>
+<footer>版权所有，保留所有权利，未经许可不得转载，联系我们。</footer>
+</body></html>`
+
+func TestHTMLParser(t *testing.T) {
+	docs, err := NewHTMLParser().Parse(context.Background(), strings.NewReader(testArticle))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 3 {
+		for _, d := range docs {
+			t.Log(d.Content)
+		}
+		t.Fatalf("got %d docs, want 3", len(docs))
+	}
+	var all []string
+	for _, d := range docs {
+		if d.MetaData[MetaTitle] != "Go 并发入门 - 技术博客" {
+			t.Errorf("title = %v", d.MetaData[MetaTitle])
+		}
+		if d.MetaData[MetaTitle1] != "Go 并发入门" {
+			t.Errorf("h1 = %v", d.MetaData[MetaTitle1])
+		}
+		all = append(all, d.Content)
+	}
+	if docs[1].MetaData[MetaTitle2] != "启动 goroutine" || docs[2].MetaData[MetaTitle2] != "对比" {
+		t.Errorf("h2 = %v, %v", docs[1].MetaData[MetaTitle2], docs[2].MetaData[MetaTitle2])
+	}
+	content := strings.Join(all, "\n\n")
+	for _, want := range []string{
+		"使用 `go` 关键字",
+		"```go\nfunc main() {\n\tgo work()\n}\n```",
+		"| 方式 | 开销 |\n| --- | --- |\n| 线程 | 大 |",
+		"- 简单\n- 高效\n  1. 调度快",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("content missing %q:\n%s", want, content)
+		}
+	}
+	for _, unwanted := range []string{"首页", "文章一", "cookie", "热门文章", "版权所有", "body{}"} {
+		if strings.Contains(content, unwanted) {
+			t.Errorf("content contains %q:\n%s", unwanted, content)
+		}
+	}
+}
+
+func TestHTMLParserSelector(t *testing.T) {
+	docs, err := NewHTMLParser().Parse(context.Background(), strings.NewReader(testArticle), WithSelector(".sidebar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 1 || !strings.HasPrefix(docs[0].Content, "热门文章") {
+		t.Fatalf("unexpected docs: %+v", docs)
+	}
+}
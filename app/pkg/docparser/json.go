@@ -0,0 +1,253 @@
+package docparser
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// 记录的唯一标识，来自映射中指定的id字段，重新上传时按它替换旧的记录
+	MetaRecordID = "record_id"
+	// 映射中指定的元数据字段
+	MetaFields = "fields"
+)
+
+// 模板中的字段占位符，例如{question}、{user.name}
+var placeholderRe = regexp.MustCompile(`\{([\w.\-]+)\}`)
+
+// JSONMapping JSON记录的字段映射
+type JSONMapping struct {
+	// 文档内容的模板，使用{字段名}引用字段，嵌套字段使用.分隔，例如"问题: {question}\n答案: {answer}"
+	// 为空时按"字段: 值"的形式渲染所有字段
+	ContentTemplate string `json:"content_template,omitempty"`
+	// 作为元数据的字段
+	MetadataFields []string `json:"metadata_fields,omitempty"`
+	// 记录唯一标识所在的字段
+	IDField string `json:"id_field,omitempty"`
+}
+
+// WithJSONMapping 指定JSON记录的字段映射
+func WithJSONMapping(m *JSONMapping) parser.Option {
+	return parser.WrapImplSpecificOptFn(func(o *JSONMapping) {
+		if m != nil {
+			*o = *m
+		}
+	})
+}
+
+// JSONParser 解析JSON(.json)和JSON Lines(.jsonl)文件，每条记录输出一个文档
+// JSON文件可以是对象数组或单个对象，JSON Lines文件每行一个对象，记录的内容、元数据和唯一标识由JSONMapping指定
+type JSONParser struct {
+	lines bool
+}
+
+// NewJSONParser 解析.json文件
+func NewJSONParser() *JSONParser {
+	return &JSONParser{}
+}
+
+// NewJSONLParser 解析.jsonl文件
+func NewJSONLParser() *JSONParser {
+	return &JSONParser{lines: true}
+}
+
+func (p *JSONParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	options := parser.GetCommonOptions(&parser.Options{}, opts...)
+	mapping := parser.GetImplSpecificOptions(&JSONMapping{}, opts...)
+	data, err := readAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	records, err := p.records(data)
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]*schema.Document, 0, len(records))
+	for i, raw := range records {
+		doc, err := recordDoc(raw, mapping)
+		if err != nil {
+			return nil, gerror.Wrapf(err, "record %d", i+1)
+		}
+		if doc == nil {
+			continue
+		}
+		doc.MetaData = newMeta(options, doc.MetaData)
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// 拆分出每条记录
+func (p *JSONParser) records(data []byte) ([]json.RawMessage, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if p.lines {
+		var res []json.RawMessage
+		sc := bufio.NewScanner(bytes.NewReader(data))
+		sc.Buffer(make([]byte, 0, 64*1024), maxFileSize)
+		for n := 1; sc.Scan(); n++ {
+			line := bytes.TrimSpace(sc.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			if !json.Valid(line) {
+				return nil, gerror.Newf("invalid jsonl: line %d", n)
+			}
+			res = append(res, bytes.Clone(line))
+		}
+		if err := sc.Err(); err != nil {
+			return nil, gerror.Wrap(err, "invalid jsonl")
+		}
+		return res, nil
+	}
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("[")) {
+		var res []json.RawMessage
+		if err := json.Unmarshal(data, &res); err != nil {
+			return nil, gerror.Wrap(err, "invalid json")
+		}
+		return res, nil
+	}
+	if !json.Valid(data) {
+		return nil, gerror.New("invalid json")
+	}
+	return []json.RawMessage{data}, nil
+}
+
+// 将一条记录转换为文档，内容为空时返回nil
+func recordDoc(raw json.RawMessage, m *JSONMapping) (*schema.Document, error) {
+	var record any
+	if err := json.Unmarshal(raw, &record); err != nil {
+		return nil, gerror.Wrap(err, "")
+	}
+	obj, ok := record.(map[string]any)
+	if !ok {
+		// 不是对象的记录直接作为文本
+		text := strings.TrimSpace(valueString(record))
+		if text == "" {
+			return nil, nil
+		}
+		return &schema.Document{Content: text, MetaData: map[string]any{}}, nil
+	}
+
+	var content string
+	if m.ContentTemplate != "" {
+		content = placeholderRe.ReplaceAllStringFunc(m.ContentTemplate, func(s string) string {
+			v, _ := lookup(obj, s[1:len(s)-1])
+			return valueString(v)
+		})
+	} else {
+		keys, err := objectKeys(raw)
+		if err != nil {
+			return nil, err
+		}
+		lines := make([]string, 0, len(keys))
+		for _, k := range keys {
+			if v := strings.TrimSpace(valueString(obj[k])); v != "" {
+				lines = append(lines, k+": "+v)
+			}
+		}
+		content = strings.Join(lines, "\n")
+	}
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, nil
+	}
+
+	meta := map[string]any{}
+	if len(m.MetadataFields) > 0 {
+		fields := make(map[string]any, len(m.MetadataFields))
+		for _, f := range m.MetadataFields {
+			if v, ok := lookup(obj, f); ok && v != nil {
+				fields[f] = v
+			}
+		}
+		meta[MetaFields] = fields
+	}
+	if m.IDField != "" {
+		if v, ok := lookup(obj, m.IDField); ok && v != nil {
+			if id := valueString(v); id != "" {
+				meta[MetaRecordID] = id
+			}
+		}
+	}
+	return &schema.Document{Content: content, MetaData: meta}, nil
+}
+
+// 按路径获取字段的值，嵌套字段使用.分隔
+func lookup(obj map[string]any, path string) (any, bool) {
+	if v, ok := obj[path]; ok {
+		return v, true
+	}
+	var cur any = obj
+	for _, key := range strings.Split(path, ".") {
+		switch c := cur.(type) {
+		case map[string]any:
+			v, ok := c[key]
+			if !ok {
+				return nil, false
+			}
+			cur = v
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(c) {
+				return nil, false
+			}
+			cur = c[i]
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// 字段的值转换为文本，数组、对象使用JSON
+func valueString(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(t)
+	default:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return fmt.Sprint(t)
+		}
+		return string(b)
+	}
+}
+
+// 对象的字段名，保持文件中的顺序
+func objectKeys(raw json.RawMessage) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := dec.Token(); err != nil {
+		return nil, gerror.Wrap(err, "")
+	}
+	var keys []string
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, gerror.Wrap(err, "")
+		}
+		keys = append(keys, t.(string))
+		var skip json.RawMessage
+		if err = dec.Decode(&skip); err != nil {
+			return nil, gerror.Wrap(err, "")
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,53 @@
+package docparser
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestJSONLParser(t *testing.T) {
+	data := `{"id": 1, "question": "如何重置密码", "answer": "在设置页点击重置", "meta": {"team": "账号"}}
+
+{"id": "a-2", "question": "如何注销", "answer": "联系客服", "meta": {"team": "客服"}}
+{"id": 3, "question": "", "answer": ""}
+`
+	docs, err := NewJSONLParser().Parse(context.Background(), strings.NewReader(data), WithJSONMapping(&JSONMapping{
+		ContentTemplate: "问题: {question}\n答案: {answer}",
+		MetadataFields:  []string{"meta.team"},
+		IDField:         "id",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 3 {
+		t.Fatalf("got %d docs, want 3", len(docs))
+	}
+	if docs[0].Content != "问题: 如何重置密码\n答案: 在设置页点击重置" {
+		t.Errorf("content = %q", docs[0].Content)
+	}
+	if docs[1].MetaData[MetaRecordID] != "a-2" || docs[0].MetaData[MetaRecordID] != "1" {
+		t.Errorf("record ids = %v, %v", docs[0].MetaData[MetaRecordID], docs[1].MetaData[MetaRecordID])
+	}
+	if fields, _ := docs[1].MetaData[MetaFields].(map[string]any); fields["meta.team"] != "客服" {
+		t.Errorf("fields = %v", docs[1].MetaData[MetaFields])
+	}
+}
+
+func TestJSONParserDefault(t *testing.T) {
+	data := `[{"title": "退款", "tags": ["a", "b"], "count": 2, "empty": null}]`
+	docs, err := NewJSONParser().Parse(context.Background(), strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 1 || docs[0].Content != "title: 退款\ntags: [\"a\",\"b\"]\ncount: 2" {
+		t.Fatalf("unexpected docs: %+v", docs)
+	}
+	if _, ok := docs[0].MetaData[MetaRecordID]; ok {
+		t.Errorf("unexpected record id")
+	}
+
+	if _, err = NewJSONLParser().Parse(context.Background(), strings.NewReader("{}\n{")); err == nil {
+		t.Errorf("want error for invalid line")
+	}
+}
@@ -0,0 +1,194 @@
+package docparser
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// 标题元数据，与markdown分割器使用的key保持一致
+	MetaTitle1 = "h1"
+	MetaTitle2 = "h2"
+	MetaTitle3 = "h3"
+
+	// Office文件的大小上限，100MB
+	maxFileSize = 100 << 20
+	// 压缩包内单个xml文件解压后的大小上限，防止压缩包炸弹
+	maxPartSize = 200 << 20
+)
+
+// xml节点，保留子节点的顺序，用于解析段落、表格混排的Office文档
+type xmlNode struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Nodes   []*xmlNode `xml:",any"`
+	Text    string     `xml:",chardata"`
+}
+
+// 获取属性值，忽略命名空间，节点为nil时返回空字符串
+func (n *xmlNode) attr(name string) string {
+	if n == nil {
+		return ""
+	}
+	for _, a := range n.Attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// 获取第一个指定名称的子节点，忽略命名空间
+func (n *xmlNode) child(name string) *xmlNode {
+	if n == nil {
+		return nil
+	}
+	for _, c := range n.Nodes {
+		if c.XMLName.Local == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// 按路径获取子节点
+func (n *xmlNode) path(names ...string) *xmlNode {
+	cur := n
+	for _, name := range names {
+		if cur = cur.child(name); cur == nil {
+			return nil
+		}
+	}
+	return cur
+}
+
+// 读取文件内容，超过大小限制时返回错误
+func readAll(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxFileSize+1))
+	if err != nil {
+		return nil, gerror.Wrap(err, "")
+	}
+	if len(data) > maxFileSize {
+		return nil, gerror.Newf("file exceeds %d bytes", maxFileSize)
+	}
+	return data, nil
+}
+
+// 读取整个Office文件，zip需要随机访问
+func openZip(r io.Reader) (*zip.Reader, error) {
+	data, err := readAll(r)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, gerror.Wrap(err, "invalid office file")
+	}
+	return zr, nil
+}
+
+// 解析压缩包内的xml文件，文件不存在时返回nil
+func readXML(zr *zip.Reader, name string) (*xmlNode, error) {
+	for _, f := range zr.File {
+		if f.Name != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, gerror.Wrap(err, "")
+		}
+		defer rc.Close()
+		var n xmlNode
+		if err = xml.NewDecoder(io.LimitReader(rc, maxPartSize)).Decode(&n); err != nil {
+			return nil, gerror.Wrapf(err, "invalid xml: %s", name)
+		}
+		return &n, nil
+	}
+	return nil, nil
+}
+
+// 关系文件中的一条关系
+type relationship struct {
+	Type   string
+	Target string
+}
+
+// 读取part对应的关系文件，返回关系id对应的关系，Target已转换为压缩包内的完整路径
+func readRels(zr *zip.Reader, part string) (map[string]relationship, error) {
+	dir, name := path.Split(part)
+	n, err := readXML(zr, dir+"_rels/"+name+".rels")
+	if err != nil || n == nil {
+		return nil, err
+	}
+	res := make(map[string]relationship)
+	for _, r := range n.Nodes {
+		if r.XMLName.Local != "Relationship" || r.attr("TargetMode") == "External" {
+			continue
+		}
+		target := r.attr("Target")
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join(dir, target)
+		}
+		res[r.attr("Id")] = relationship{Type: r.attr("Type"), Target: target}
+	}
+	return res, nil
+}
+
+// 合并解析选项中的元数据
+func newMeta(opts *parser.Options, extra map[string]any) map[string]any {
+	meta := make(map[string]any, len(opts.ExtraMeta)+len(extra)+1)
+	if opts.URI != "" {
+		meta["_source"] = opts.URI
+	}
+	for k, v := range opts.ExtraMeta {
+		meta[k] = v
+	}
+	for k, v := range extra {
+		meta[k] = v
+	}
+	return meta
+}
+
+// 转义markdown表格单元格中的特殊字符，单元格内不能换行
+func escapeCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// 将二维表格渲染为markdown表格，第一行作为表头
+func markdownTable(rows [][]string) string {
+	cols := 0
+	for _, r := range rows {
+		cols = max(cols, len(r))
+	}
+	if cols == 0 {
+		return ""
+	}
+	var b strings.Builder
+	writeRow := func(r []string) {
+		b.WriteString("|")
+		for i := 0; i < cols; i++ {
+			cell := ""
+			if i < len(r) {
+				cell = escapeCell(r[i])
+			}
+			b.WriteString(" " + cell + " |")
+		}
+		b.WriteString("\n")
+	}
+	writeRow(rows[0])
+	b.WriteString("|" + strings.Repeat(" --- |", cols) + "\n")
+	for _, r := range rows[1:] {
+		writeRow(r)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
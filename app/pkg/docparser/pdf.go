@@ -0,0 +1,147 @@
+package docparser
+
+import (
+	"context"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/cloudwego/eino-ext/components/document/parser/pdf"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// PDF的页码，从1开始
+	MetaPage = "page"
+
+	// 页眉页脚只在每页开头、结尾的几行中查找
+	headerFooterLines = 2
+	// 至少有这么多页时才去掉页眉页脚
+	headerFooterMinPages = 3
+)
+
+// PDFConfig PDF解析配置
+type PDFConfig struct {
+	// 去掉在多个页面重复出现的页眉、页脚
+	StripHeaderFooter bool
+}
+
+// PDFParser 按页解析PDF，每页输出一个文档，页码记录在page元数据中
+type PDFParser struct {
+	conf  PDFConfig
+	inner *pdf.PDFParser
+}
+
+func NewPDFParser(ctx context.Context, conf *PDFConfig) (*PDFParser, error) {
+	inner, err := pdf.NewPDFParser(ctx, &pdf.Config{ToPages: true})
+	if err != nil {
+		return nil, gerror.Wrap(err, "")
+	}
+	p := &PDFParser{inner: inner}
+	if conf != nil {
+		p.conf = *conf
+	}
+	return p, nil
+}
+
+func (p *PDFParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	pages, err := p.inner.Parse(ctx, reader, opts...)
+	if err != nil {
+		return nil, gerror.Wrap(err, "")
+	}
+	contents := make([]string, len(pages))
+	for i, page := range pages {
+		contents[i] = page.Content
+	}
+	if p.conf.StripHeaderFooter {
+		contents = stripHeaderFooter(contents)
+	}
+	docs := make([]*schema.Document, 0, len(pages))
+	for i, page := range pages {
+		content := strings.TrimSpace(contents[i])
+		if content == "" {
+			continue
+		}
+		// 每页的元数据是同一个map，需要复制后再设置页码
+		meta := make(map[string]any, len(page.MetaData)+1)
+		for k, v := range page.MetaData {
+			meta[k] = v
+		}
+		meta[MetaPage] = i + 1
+		docs = append(docs, &schema.Document{Content: content, MetaData: meta})
+	}
+	return docs, nil
+}
+
+// 去掉页眉页脚：每页开头、结尾几个非空行中，在超过一半页面出现的行
+// 比较前把数字替换掉，这样只有页码不同的页脚也能识别出来
+func stripHeaderFooter(pages []string) []string {
+	if len(pages) < headerFooterMinPages {
+		return pages
+	}
+	lines := make([][]string, len(pages))
+	// 每页开头、结尾的非空行的下标
+	edges := make([][]int, len(pages))
+	counts := make(map[string]int)
+	for i, page := range pages {
+		lines[i] = strings.Split(page, "\n")
+		edges[i] = edgeLines(lines[i])
+		seen := make(map[string]bool)
+		for _, idx := range edges[i] {
+			key := lineKey(lines[i][idx])
+			if !seen[key] {
+				seen[key] = true
+				counts[key]++
+			}
+		}
+	}
+	res := make([]string, len(pages))
+	for i := range pages {
+		remove := make(map[int]bool)
+		for _, idx := range edges[i] {
+			if counts[lineKey(lines[i][idx])]*2 > len(pages) {
+				remove[idx] = true
+			}
+		}
+		kept := make([]string, 0, len(lines[i]))
+		for idx, l := range lines[i] {
+			if !remove[idx] {
+				kept = append(kept, l)
+			}
+		}
+		res[i] = strings.Join(kept, "\n")
+	}
+	return res
+}
+
+// 开头和结尾几个非空行的下标
+func edgeLines(lines []string) []int {
+	var nonEmpty []int
+	for i, l := range lines {
+		if strings.TrimSpace(l) != "" {
+			nonEmpty = append(nonEmpty, i)
+		}
+	}
+	var res []int
+	for i, idx := range nonEmpty {
+		if i < headerFooterLines || i >= len(nonEmpty)-headerFooterLines {
+			res = append(res, idx)
+		}
+	}
+	return res
+}
+
+// 用于比较的行内容，数字统一替换为#，并去掉空白
+func lineKey(l string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return '#'
+		}
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, l)
+}
@@ -0,0 +1,25 @@
+package docparser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStripHeaderFooter(t *testing.T) {
+	pages := []string{
+		"公司年报\n第一章\n\n正文一\n第 1 页",
+		"公司年报\n正文二\n第 2 页",
+		"公司年报\n正文三\n公司年报\n第 3 页",
+	}
+	got := stripHeaderFooter(pages)
+	want := []string{"第一章\n\n正文一", "正文二", "正文三"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	// 页数太少时不处理
+	few := []string{"a\nb", "a\nc"}
+	if got := stripHeaderFooter(few); strings.Join(got, "|") != strings.Join(few, "|") {
+		t.Fatalf("few pages = %q", got)
+	}
+}
@@ -0,0 +1,181 @@
+package docparser
+
+import (
+	"archive/zip"
+	"context"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// 幻灯片的序号，从1开始
+	MetaSlideNumber = "slide_number"
+	// 幻灯片的标题
+	MetaTitle = "title"
+
+	// 幻灯片指向演讲者备注的关系类型
+	relTypeNotesSlide = "/notesSlide"
+)
+
+// PptxParser 解析PowerPoint文件(.pptx)，每张幻灯片输出一个文档
+// 内容包括标题、正文、表格和演讲者备注，幻灯片序号和标题记录在元数据中
+type PptxParser struct{}
+
+func NewPptxParser() *PptxParser {
+	return &PptxParser{}
+}
+
+func (p *PptxParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	options := parser.GetCommonOptions(&parser.Options{}, opts...)
+	zr, err := openZip(reader)
+	if err != nil {
+		return nil, err
+	}
+	const presentationPart = "ppt/presentation.xml"
+	pres, err := readXML(zr, presentationPart)
+	if err != nil {
+		return nil, err
+	}
+	if pres == nil {
+		return nil, gerror.New("invalid pptx: ppt/presentation.xml not found")
+	}
+	rels, err := readRels(zr, presentationPart)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []*schema.Document
+	// 按演示文稿中的顺序读取幻灯片，幻灯片文件名中的序号不一定连续
+	for i, id := range pres.path("sldIdLst").Nodes {
+		rel, ok := rels[relID(id)]
+		if !ok {
+			continue
+		}
+		title, content, err := readSlide(zr, rel.Target)
+		if err != nil {
+			return nil, err
+		}
+		if content == "" {
+			continue
+		}
+		extra := map[string]any{MetaSlideNumber: i + 1}
+		if title != "" {
+			extra[MetaTitle] = title
+		}
+		docs = append(docs, &schema.Document{Content: content, MetaData: newMeta(options, extra)})
+	}
+	return docs, nil
+}
+
+// 读取一张幻灯片，返回标题和markdown格式的内容
+func readSlide(zr *zip.Reader, part string) (string, string, error) {
+	slide, err := readXML(zr, part)
+	if err != nil || slide == nil {
+		return "", "", err
+	}
+	var title string
+	var blocks []string
+	walkShapes(slide.path("cSld", "spTree"), func(ph, text string) {
+		if (ph == "title" || ph == "ctrTitle") && title == "" {
+			title = strings.Join(strings.Fields(text), " ")
+			return
+		}
+		blocks = append(blocks, text)
+	})
+	if title != "" {
+		blocks = append([]string{"# " + title}, blocks...)
+	}
+
+	// 演讲者备注只保留正文占位符中的文本，忽略幻灯片缩略图和页码
+	rels, err := readRels(zr, part)
+	if err != nil {
+		return "", "", err
+	}
+	for _, rel := range rels {
+		if !strings.HasSuffix(rel.Type, relTypeNotesSlide) {
+			continue
+		}
+		notes, err := readXML(zr, rel.Target)
+		if err != nil {
+			return "", "", err
+		}
+		var lines []string
+		walkShapes(notes.path("cSld", "spTree"), func(ph, text string) {
+			if ph == "body" {
+				lines = append(lines, text)
+			}
+		})
+		if len(lines) > 0 {
+			blocks = append(blocks, "备注: "+strings.Join(lines, "\n"))
+		}
+	}
+	return title, strings.Join(blocks, "\n\n"), nil
+}
+
+// 节点引用的关系id，即r:id属性，sldId同时有不带命名空间的数字id属性
+func relID(n *xmlNode) string {
+	for _, a := range n.Attrs {
+		if a.Name.Local == "id" && a.Name.Space != "" {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// 按顺序遍历形状树中包含文本的形状和表格，ph是占位符类型，普通文本框为空字符串
+func walkShapes(tree *xmlNode, fn func(ph, text string)) {
+	if tree == nil {
+		return
+	}
+	for _, n := range tree.Nodes {
+		switch n.XMLName.Local {
+		case "sp":
+			text := textBody(n.child("txBody"))
+			if text == "" {
+				continue
+			}
+			ph := n.path("nvSpPr", "nvPr", "ph")
+			phType := ph.attr("type")
+			if ph != nil && phType == "" {
+				// 没有指定类型的占位符是正文
+				phType = "body"
+			}
+			fn(phType, text)
+		case "grpSp":
+			walkShapes(n, fn)
+		case "graphicFrame":
+			if tbl := n.path("graphic", "graphicData", "tbl"); tbl != nil {
+				if text := markdownTable(tableRows(tbl)); text != "" {
+					fn("", text)
+				}
+			}
+		}
+	}
+}
+
+// 文本框中的段落，有缩进级别的段落渲染为列表
+func textBody(body *xmlNode) string {
+	if body == nil {
+		return ""
+	}
+	var lines []string
+	for _, p := range body.Nodes {
+		if p.XMLName.Local != "p" {
+			continue
+		}
+		text := strings.TrimSpace(paragraphText(p))
+		if text == "" {
+			continue
+		}
+		if lvl, _ := strconv.Atoi(p.path("pPr").attr("lvl")); lvl > 0 {
+			text = strings.Repeat("  ", lvl-1) + "- " + text
+		}
+		lines = append(lines, text)
+	}
+	return strings.Join(lines, "\n")
+}
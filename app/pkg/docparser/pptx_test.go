@@ -0,0 +1,60 @@
+package docparser
+
+import (
+	"context"
+	"testing"
+)
+
+const (
+	presentationNS = `xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"`
+	relsNS         = `xmlns="http://schemas.openxmlformats.org/package/2006/relationships"`
+)
+
+func shape(ph, text string) string {
+	nvPr := `<p:nvPr/>`
+	if ph != "" {
+		nvPr = `<p:nvPr><p:ph type="` + ph + `"/></p:nvPr>`
+	}
+	return `<p:sp><p:nvSpPr>` + nvPr + `</p:nvSpPr><p:txBody>` + text + `</p:txBody></p:sp>`
+}
+
+func slide(shapes string) string {
+	return `<p:sld ` + presentationNS + `><p:cSld><p:spTree>` + shapes + `</p:spTree></p:cSld></p:sld>`
+}
+
+func TestPptxParser(t *testing.T) {
+	files := map[string]string{
+		"ppt/presentation.xml": `<p:presentation ` + presentationNS + `><p:sldIdLst>
+<p:sldId id="256" r:id="rId3"/><p:sldId id="257" r:id="rId2"/>
+</p:sldIdLst></p:presentation>`,
+		"ppt/_rels/presentation.xml.rels": `<Relationships ` + relsNS + `>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide" Target="slides/slide1.xml"/>
+<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide" Target="slides/slide2.xml"/>
+</Relationships>`,
+		"ppt/slides/slide2.xml": slide(shape("ctrTitle", `<a:p><a:r><a:t>入职培训</a:t></a:r></a:p>`)),
+		"ppt/slides/slide1.xml": slide(shape("title", `<a:p><a:r><a:t>报销流程</a:t></a:r></a:p>`) +
+			shape("", `<a:p><a:r><a:t>提交申请</a:t></a:r></a:p><a:p><a:pPr lvl="1"/><a:r><a:t>附上发票</a:t></a:r></a:p>`) +
+			`<p:graphicFrame><a:graphic><a:graphicData><a:tbl><a:tr><a:tc><a:txBody><a:p><a:r><a:t>金额</a:t></a:r></a:p></a:txBody></a:tc></a:tr>` +
+			`<a:tr><a:tc><a:txBody><a:p><a:r><a:t>100</a:t></a:r></a:p></a:txBody></a:tc></a:tr></a:tbl></a:graphicData></a:graphic></p:graphicFrame>`),
+		"ppt/slides/_rels/slide1.xml.rels": `<Relationships ` + relsNS + `>
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesSlide" Target="../notesSlides/notesSlide1.xml"/>
+</Relationships>`,
+		"ppt/notesSlides/notesSlide1.xml": `<p:notes ` + presentationNS + `><p:cSld><p:spTree>` +
+			shape("sldImg", `<a:p><a:r><a:t>缩略图</a:t></a:r></a:p>`) + shape("body", `<a:p><a:r><a:t>强调时限</a:t></a:r></a:p>`) +
+			shape("sldNum", `<a:p><a:r><a:t>2</a:t></a:r></a:p>`) + `</p:spTree></p:cSld></p:notes>`,
+	}
+	docs, err := NewPptxParser().Parse(context.Background(), newZip(t, files))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("docs = %d, want 2", len(docs))
+	}
+	if docs[0].Content != "# 入职培训" || docs[0].MetaData[MetaSlideNumber] != 1 || docs[0].MetaData[MetaTitle] != "入职培训" {
+		t.Fatalf("slide 1 = %q %v", docs[0].Content, docs[0].MetaData)
+	}
+	want := "# 报销流程\n\n提交申请\n- 附上发票\n\n| 金额 |\n| --- |\n| 100 |\n\n备注: 强调时限"
+	if docs[1].Content != want || docs[1].MetaData[MetaSlideNumber] != 2 || docs[1].MetaData[MetaTitle] != "报销流程" {
+		t.Fatalf("slide 2 = %q %v", docs[1].Content, docs[1].MetaData)
+	}
+}
@@ -0,0 +1,78 @@
+package docparser
+
+import (
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+// 按标题切分的一段内容
+type section struct {
+	titles  [3]string
+	content string
+}
+
+// 将markdown块按一到三级标题切分为多段，标题记录在h1、h2、h3元数据中
+type sectionWriter struct {
+	titles   [3]string
+	buf      strings.Builder
+	lastList bool
+	sections []*section
+}
+
+// 写入标题，一到三级标题开始新的一段
+func (w *sectionWriter) heading(level int, text string) {
+	if level <= len(w.titles) {
+		w.flush()
+		w.titles[level-1] = text
+		for i := level; i < len(w.titles); i++ {
+			w.titles[i] = ""
+		}
+	}
+	w.write(strings.Repeat("#", min(level, 6))+" "+text, false)
+}
+
+// 写入一个块，连续的列表项之间只换一行
+func (w *sectionWriter) write(s string, list bool) {
+	if s == "" {
+		return
+	}
+	if w.buf.Len() > 0 {
+		if list && w.lastList {
+			w.buf.WriteString("\n")
+		} else {
+			w.buf.WriteString("\n\n")
+		}
+	}
+	w.buf.WriteString(s)
+	w.lastList = list
+}
+
+// 保存当前段落
+func (w *sectionWriter) flush() {
+	if content := strings.TrimSpace(w.buf.String()); content != "" {
+		w.sections = append(w.sections, &section{titles: w.titles, content: content})
+	}
+	w.buf.Reset()
+	w.lastList = false
+}
+
+// 将所有段落转换为文档，extra是每个文档共有的元数据
+func (w *sectionWriter) documents(options *parser.Options, extra map[string]any) []*schema.Document {
+	w.flush()
+	docs := make([]*schema.Document, 0, len(w.sections))
+	for _, s := range w.sections {
+		m := make(map[string]any, len(extra)+len(s.titles))
+		for k, v := range extra {
+			m[k] = v
+		}
+		for i, key := range []string{MetaTitle1, MetaTitle2, MetaTitle3} {
+			if s.titles[i] != "" {
+				m[key] = s.titles[i]
+			}
+		}
+		docs = append(docs, &schema.Document{Content: s.content, MetaData: newMeta(options, m)})
+	}
+	return docs
+}
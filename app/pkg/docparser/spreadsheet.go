@@ -0,0 +1,232 @@
+package docparser
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	// 工作表名称
+	MetaSheet = "sheet"
+	// 文档第一行的行号，从1开始
+	MetaRow = "row"
+	// 文档最后一行的行号，每个文档包含多行时才有
+	MetaRowEnd = "row_end"
+
+	// 自动识别表头
+	HeaderAuto = 0
+	// 没有表头，使用列名A、B、C作为字段名
+	HeaderNone = -1
+)
+
+// SpreadsheetConfig 表格解析配置
+type SpreadsheetConfig struct {
+	// 表头所在的行号，从1开始，HeaderAuto表示自动识别，HeaderNone表示没有表头
+	HeaderRow int
+	// 每个文档包含的数据行数，<=0时每行一个文档
+	RowsPerDoc int
+}
+
+// XlsxParser 解析Excel文件(.xlsx)，每个工作表的每一行或每几行输出一个文档
+// 每行渲染为"表头: 值"的形式，工作表名称和行号记录在元数据中，检索时能返回完整的记录
+type XlsxParser struct {
+	conf SpreadsheetConfig
+}
+
+func NewXlsxParser(conf *SpreadsheetConfig) *XlsxParser {
+	p := &XlsxParser{}
+	if conf != nil {
+		p.conf = *conf
+	}
+	return p
+}
+
+func (p *XlsxParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	options := parser.GetCommonOptions(&parser.Options{}, opts...)
+	data, err := readAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	f, err := excelize.OpenReader(bytes.NewReader(data), excelize.Options{
+		UnzipSizeLimit:    maxPartSize,
+		UnzipXMLSizeLimit: maxPartSize,
+	})
+	if err != nil {
+		return nil, gerror.Wrap(err, "invalid xlsx")
+	}
+	defer f.Close()
+	var docs []*schema.Document
+	for _, sheet := range f.GetSheetList() {
+		rows, err := f.GetRows(sheet)
+		if err != nil {
+			return nil, gerror.Wrapf(err, "read sheet %s failed", sheet)
+		}
+		docs = append(docs, rowDocs(rows, p.conf, newMeta(options, map[string]any{MetaSheet: sheet}))...)
+	}
+	return docs, nil
+}
+
+// CSVParser 解析CSV文件，自动识别逗号、分号、制表符分隔符，输出格式与XlsxParser相同
+type CSVParser struct {
+	conf SpreadsheetConfig
+}
+
+func NewCSVParser(conf *SpreadsheetConfig) *CSVParser {
+	p := &CSVParser{}
+	if conf != nil {
+		p.conf = *conf
+	}
+	return p
+}
+
+func (p *CSVParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	options := parser.GetCommonOptions(&parser.Options{}, opts...)
+	data, err := readAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	// 去掉Excel导出时带的BOM
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = sniffDelimiter(data)
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, gerror.Wrap(err, "invalid csv")
+	}
+	return rowDocs(rows, p.conf, newMeta(options, nil)), nil
+}
+
+// 根据第一行中出现次数最多的字符识别分隔符
+func sniffDelimiter(data []byte) rune {
+	line := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		line = data[:i]
+	}
+	res, most := ',', 0
+	for _, d := range []rune{',', ';', '\t'} {
+		if n := bytes.Count(line, []byte(string(d))); n > most {
+			res, most = d, n
+		}
+	}
+	return res
+}
+
+// 将表格的行转换为文档，meta是每个文档共有的元数据
+func rowDocs(rows [][]string, conf SpreadsheetConfig, meta map[string]any) []*schema.Document {
+	headerIdx := headerIndex(rows, conf.HeaderRow)
+	var header []string
+	if headerIdx >= 0 {
+		header = rows[headerIdx]
+	}
+	rowsPerDoc := max(conf.RowsPerDoc, 1)
+
+	var docs []*schema.Document
+	var lines []string
+	start, end := 0, 0
+	emit := func() {
+		if len(lines) == 0 {
+			return
+		}
+		m := make(map[string]any, len(meta)+2)
+		for k, v := range meta {
+			m[k] = v
+		}
+		m[MetaRow] = start
+		if end != start {
+			m[MetaRowEnd] = end
+		}
+		docs = append(docs, &schema.Document{Content: strings.Join(lines, "\n\n"), MetaData: m})
+		lines = lines[:0]
+	}
+	for i := headerIdx + 1; i < len(rows); i++ {
+		line := renderRow(header, rows[i])
+		if line == "" {
+			continue
+		}
+		if len(lines) == 0 {
+			start = i + 1
+		}
+		end = i + 1
+		lines = append(lines, line)
+		if len(lines) >= rowsPerDoc {
+			emit()
+		}
+	}
+	emit()
+	return docs
+}
+
+// 表头所在行的下标，没有表头时返回-1
+func headerIndex(rows [][]string, headerRow int) int {
+	switch {
+	case headerRow == HeaderNone:
+		return -1
+	case headerRow > 0:
+		if headerRow > len(rows) {
+			return -1
+		}
+		return headerRow - 1
+	}
+	// 自动识别：第一个非空行的单元格都不是数字且没有重复时作为表头
+	for i, row := range rows {
+		if isEmptyRow(row) {
+			continue
+		}
+		if i == len(rows)-1 {
+			return -1
+		}
+		seen := make(map[string]bool, len(row))
+		for _, cell := range row {
+			cell = strings.TrimSpace(cell)
+			if cell == "" {
+				continue
+			}
+			if _, err := strconv.ParseFloat(cell, 64); err == nil || seen[cell] {
+				return -1
+			}
+			seen[cell] = true
+		}
+		return i
+	}
+	return -1
+}
+
+func isEmptyRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// 将一行渲染为"表头: 值"，跳过空单元格，没有表头的列使用列名
+func renderRow(header, row []string) string {
+	pairs := make([]string, 0, len(row))
+	for i, cell := range row {
+		cell = strings.Join(strings.Fields(cell), " ")
+		if cell == "" {
+			continue
+		}
+		name := ""
+		if i < len(header) {
+			name = strings.TrimSpace(header[i])
+		}
+		if name == "" {
+			name, _ = excelize.ColumnNumberToName(i + 1)
+		}
+		pairs = append(pairs, name+": "+cell)
+	}
+	return strings.Join(pairs, "\n")
+}
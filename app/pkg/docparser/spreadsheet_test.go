@@ -0,0 +1,79 @@
+package docparser
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestXlsxParser(t *testing.T) {
+	f := excelize.NewFile()
+	if err := f.SetSheetName("Sheet1", "产品"); err != nil {
+		t.Fatal(err)
+	}
+	rows := [][]any{{"名称", "价格", ""}, {"苹果", 5, "备注"}, {}, {"香蕉", 3}}
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := f.SetSheetRow("产品", cell, &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var buf bytes.Buffer
+	if err := f.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	docs, err := NewXlsxParser(nil).Parse(context.Background(), bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("docs = %d, want 2", len(docs))
+	}
+	if docs[0].Content != "名称: 苹果\n价格: 5\nC: 备注" || docs[0].MetaData[MetaSheet] != "产品" || docs[0].MetaData[MetaRow] != 2 {
+		t.Fatalf("doc 0 = %q %v", docs[0].Content, docs[0].MetaData)
+	}
+	if docs[1].Content != "名称: 香蕉\n价格: 3" || docs[1].MetaData[MetaRow] != 4 {
+		t.Fatalf("doc 1 = %q %v", docs[1].Content, docs[1].MetaData)
+	}
+
+	docs, err = NewXlsxParser(&SpreadsheetConfig{RowsPerDoc: 5}).Parse(context.Background(), bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 1 || docs[0].MetaData[MetaRow] != 2 || docs[0].MetaData[MetaRowEnd] != 4 {
+		t.Fatalf("grouped docs = %v", docs)
+	}
+}
+
+func TestCSVParser(t *testing.T) {
+	data := "\xef\xbb\xbfq;a\n\"怎么退款\";\"联系客服; 7天内\"\n"
+	docs, err := NewCSVParser(nil).Parse(context.Background(), strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 1 || docs[0].Content != "q: 怎么退款\na: 联系客服; 7天内" {
+		t.Fatalf("docs = %v", docs)
+	}
+
+	// 第一行是数字时自动识别为没有表头
+	docs, err = NewCSVParser(nil).Parse(context.Background(), strings.NewReader("1,2\n3,4\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 2 || docs[0].Content != "A: 1\nB: 2" || docs[0].MetaData[MetaRow] != 1 {
+		t.Fatalf("docs without header = %v", docs)
+	}
+
+	// 指定表头所在的行
+	docs, err = NewCSVParser(&SpreadsheetConfig{HeaderRow: 2}).Parse(context.Background(), strings.NewReader("title\nk,v\n1,2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 1 || docs[0].Content != "k: 1\nv: 2" || docs[0].MetaData[MetaRow] != 3 {
+		t.Fatalf("docs with header row = %v", docs)
+	}
+}
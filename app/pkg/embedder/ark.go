@@ -0,0 +1,35 @@
+package embedder
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+// 方舟嵌入器
+type arkEmbedder struct {
+	embedding.Embedder
+	dims int
+}
+
+func newArk(ctx context.Context, conf *Config) (Embedder, error) {
+	timeout := conf.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	e, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		APIKey:  conf.APIKey,
+		Model:   conf.Model,
+		BaseURL: conf.BaseURL,
+		Timeout: &timeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &arkEmbedder{Embedder: e, dims: conf.Dimensions}, nil
+}
+
+func (e *arkEmbedder) Dimensions() int {
+	return e.dims
+}
@@ -0,0 +1,325 @@
+package embedder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/gogf/gf/v2/errors/gerror"
+	arkmodel "github.com/volcengine/volcengine-go-sdk/service/arkruntime/model"
+)
+
+const (
+	// 默认每次请求的文本数
+	DefaultBatchSize = 16
+	// 默认同时请求的批次数
+	DefaultConcurrency = 4
+	// 默认限流或服务端错误时的重试次数
+	DefaultMaxRetries = 3
+	// 默认第一次重试前的等待时间，之后每次翻倍
+	DefaultRetryBackoff = 500 * time.Millisecond
+)
+
+// StatusError 嵌入服务返回的HTTP错误
+type StatusError struct {
+	Code    int
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status %d: %s", e.Code, e.Message)
+}
+
+// BatchConfig 批量嵌入配置
+type BatchConfig struct {
+	// 向量缓存，为空时不缓存
+	Cache Cache
+	// 缓存键的命名空间，区分不同的模型和维度
+	Namespace string
+	// 每次请求的文本数，<=0时使用DefaultBatchSize
+	BatchSize int
+	// 同时请求的批次数，<=0时使用DefaultConcurrency
+	Concurrency int
+	// 限流(429)或服务端错误(5xx)时的重试次数，<0时不重试，0时使用DefaultMaxRetries
+	MaxRetries int
+	// 第一次重试前的等待时间，之后每次翻倍，<=0时使用DefaultRetryBackoff
+	RetryBackoff time.Duration
+	// 计算文本的token数，用于统计，为空时不统计
+	CountTokens func(text string) int
+}
+
+// Stats 嵌入统计，所有计数都是累计值
+type Stats struct {
+	// 请求嵌入的文本数
+	Texts atomic.Int64
+	// 缓存命中的文本数
+	CacheHits atomic.Int64
+	// 读写缓存失败的次数，失败时当作未命中
+	CacheErrors atomic.Int64
+	// 调用嵌入服务的次数，包括重试
+	Requests atomic.Int64
+	// 重试次数
+	Retries atomic.Int64
+	// 实际向量化的token数，不包括缓存命中的文本
+	Tokens atomic.Int64
+}
+
+// Snapshot 当前的统计值
+func (s *Stats) Snapshot() StatsSnapshot {
+	return StatsSnapshot{
+		Texts:       s.Texts.Load(),
+		CacheHits:   s.CacheHits.Load(),
+		CacheErrors: s.CacheErrors.Load(),
+		Requests:    s.Requests.Load(),
+		Retries:     s.Retries.Load(),
+		Tokens:      s.Tokens.Load(),
+	}
+}
+
+func (s *Stats) String() string {
+	return s.Snapshot().String()
+}
+
+// StatsSnapshot 某一时刻的嵌入统计，可以序列化为JSON
+type StatsSnapshot struct {
+	Texts       int64 `json:"texts"`
+	CacheHits   int64 `json:"cache_hits"`
+	CacheErrors int64 `json:"cache_errors"`
+	Requests    int64 `json:"requests"`
+	Retries     int64 `json:"retries"`
+	Tokens      int64 `json:"tokens"`
+}
+
+// HitRate 缓存命中率
+func (s StatsSnapshot) HitRate() float64 {
+	if s.Texts == 0 {
+		return 0
+	}
+	return float64(s.CacheHits) / float64(s.Texts)
+}
+
+func (s StatsSnapshot) String() string {
+	return fmt.Sprintf("texts=%d cache_hits=%d hit_rate=%.2f cache_errors=%d requests=%d retries=%d tokens=%d",
+		s.Texts, s.CacheHits, s.HitRate(), s.CacheErrors, s.Requests, s.Retries, s.Tokens)
+}
+
+// MarshalJSON 在计数之外输出缓存命中率
+func (s StatsSnapshot) MarshalJSON() ([]byte, error) {
+	type plain StatsSnapshot
+	return json.Marshal(struct {
+		plain
+		HitRate float64 `json:"hit_rate"`
+	}{plain(s), s.HitRate()})
+}
+
+type statsKey struct{}
+
+// WithStats 返回记录嵌入统计的上下文，使用这个上下文的嵌入请求在累计统计之外也计入s
+// 用于统计一次处理使用的嵌入，不受同时进行的其它请求影响
+func WithStats(ctx context.Context, s *Stats) context.Context {
+	return context.WithValue(ctx, statsKey{}, s)
+}
+
+// Batch 包装嵌入器，先查缓存，未命中的文本去重后分批并发请求，遇到限流和服务端错误时退避重试
+type Batch struct {
+	Embedder
+	conf  BatchConfig
+	stats Stats
+}
+
+// NewBatch 包装嵌入器
+func NewBatch(e Embedder, conf *BatchConfig) *Batch {
+	b := &Batch{Embedder: e}
+	if conf != nil {
+		b.conf = *conf
+	}
+	if b.conf.BatchSize <= 0 {
+		b.conf.BatchSize = DefaultBatchSize
+	}
+	if b.conf.Concurrency <= 0 {
+		b.conf.Concurrency = DefaultConcurrency
+	}
+	if b.conf.MaxRetries == 0 {
+		b.conf.MaxRetries = DefaultMaxRetries
+	}
+	if b.conf.RetryBackoff <= 0 {
+		b.conf.RetryBackoff = DefaultRetryBackoff
+	}
+	return b
+}
+
+// Capacity 一次调用能同时请求的文本数，调用方每次传入这么多文本时并发最充分
+func (b *Batch) Capacity() int {
+	return b.conf.BatchSize * b.conf.Concurrency
+}
+
+// Stats 嵌入统计
+func (b *Batch) Stats() *Stats {
+	return &b.stats
+}
+
+// 更新累计统计和上下文中的统计
+func (b *Batch) record(ctx context.Context, add func(s *Stats)) {
+	add(&b.stats)
+	if s, ok := ctx.Value(statsKey{}).(*Stats); ok {
+		add(s)
+	}
+}
+
+func (b *Batch) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	b.record(ctx, func(s *Stats) { s.Texts.Add(int64(len(texts))) })
+	keys := make([]string, len(texts))
+	for i, text := range texts {
+		keys[i] = CacheKey(b.conf.Namespace, text)
+	}
+	vectors := make(map[string][]float64, len(texts))
+	if b.conf.Cache != nil {
+		cached, err := b.conf.Cache.Get(ctx, keys)
+		if err != nil {
+			b.record(ctx, func(s *Stats) { s.CacheErrors.Add(1) })
+		}
+		for k, v := range cached {
+			vectors[k] = v
+		}
+	}
+
+	// 未命中的文本去重
+	var missing []string
+	var missingKeys []string
+	pending := make(map[string]bool)
+	for i, k := range keys {
+		if _, ok := vectors[k]; ok {
+			b.record(ctx, func(s *Stats) { s.CacheHits.Add(1) })
+			continue
+		}
+		if pending[k] {
+			continue
+		}
+		pending[k] = true
+		missing = append(missing, texts[i])
+		missingKeys = append(missingKeys, k)
+	}
+
+	if len(missing) > 0 {
+		embedded, err := b.embed(ctx, missing, opts...)
+		if err != nil {
+			return nil, err
+		}
+		fresh := make(map[string][]float64, len(missing))
+		for i, k := range missingKeys {
+			vectors[k] = embedded[i]
+			fresh[k] = embedded[i]
+		}
+		if b.conf.Cache != nil {
+			if err = b.conf.Cache.Set(ctx, fresh); err != nil {
+				b.record(ctx, func(s *Stats) { s.CacheErrors.Add(1) })
+			}
+		}
+	}
+
+	res := make([][]float64, len(texts))
+	for i, k := range keys {
+		res[i] = vectors[k]
+	}
+	return res, nil
+}
+
+// 分批并发请求，任意一批失败时返回错误
+func (b *Batch) embed(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	res := make([][]float64, len(texts))
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	sem := make(chan struct{}, b.conf.Concurrency)
+	for start := 0; start < len(texts); start += b.conf.BatchSize {
+		end := min(start+b.conf.BatchSize, len(texts))
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(start, end int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			vectors, err := b.embedBatch(ctx, texts[start:end], opts...)
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			copy(res[start:end], vectors)
+		}(start, end)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return res, nil
+}
+
+// 请求一批文本，遇到限流和服务端错误时退避重试
+func (b *Batch) embedBatch(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	backoff := b.conf.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		b.record(ctx, func(s *Stats) { s.Requests.Add(1) })
+		vectors, err := b.Embedder.EmbedStrings(ctx, texts, opts...)
+		if err == nil {
+			if len(vectors) != len(texts) {
+				return nil, gerror.Newf("got %d embeddings, want %d", len(vectors), len(texts))
+			}
+			if b.conf.CountTokens != nil {
+				var tokens int64
+				for _, text := range texts {
+					tokens += int64(b.conf.CountTokens(text))
+				}
+				b.record(ctx, func(s *Stats) { s.Tokens.Add(tokens) })
+			}
+			return vectors, nil
+		}
+		if attempt >= b.conf.MaxRetries || !Retryable(err) {
+			return nil, err
+		}
+		b.record(ctx, func(s *Stats) { s.Retries.Add(1) })
+		select {
+		case <-ctx.Done():
+			return nil, gerror.Wrap(ctx.Err(), err.Error())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// Retryable 是否是可以重试的错误：限流、服务端错误、网络超时
+func Retryable(err error) bool {
+	code := 0
+	var se *StatusError
+	var apiErr *arkmodel.APIError
+	var reqErr *arkmodel.RequestError
+	switch {
+	case errors.As(err, &se):
+		code = se.Code
+	case errors.As(err, &apiErr):
+		code = apiErr.HTTPStatusCode
+	case errors.As(err, &reqErr):
+		code = reqErr.HTTPStatusCode
+	}
+	if code == 429 || code >= 500 {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,92 @@
+package embedder
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+// 前fails次请求返回429，记录每次请求的文本数
+type flakyEmbedder struct {
+	*Hash
+	mu      sync.Mutex
+	fails   int
+	batches []int
+}
+
+func (e *flakyEmbedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.fails > 0 {
+		e.fails--
+		return nil, &StatusError{Code: 429, Message: "rate limited"}
+	}
+	e.batches = append(e.batches, len(texts))
+	return e.Hash.EmbedStrings(ctx, texts, opts...)
+}
+
+func TestBatch(t *testing.T) {
+	inner := &flakyEmbedder{Hash: NewHash(8), fails: 1}
+	b := NewBatch(inner, &BatchConfig{
+		Cache:        NewMemoryCache(),
+		Namespace:    "hash-8",
+		BatchSize:    2,
+		RetryBackoff: time.Millisecond,
+		CountTokens:  func(text string) int { return len(text) },
+	})
+	texts := []string{"a", "b", "a", "c", "d", "e"}
+	v, err := b.EmbedStrings(context.Background(), texts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v) != len(texts) || cosine(v[0], v[2]) < 0.9999 {
+		t.Fatalf("unexpected vectors %v", v)
+	}
+	// 重复的文本只请求一次，5个文本分3批
+	if len(inner.batches) != 3 {
+		t.Errorf("batches = %v", inner.batches)
+	}
+	// 上下文中的统计只记录这一次请求
+	call := &Stats{}
+	if _, err = b.EmbedStrings(WithStats(context.Background(), call), []string{"a", "f"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := call.Snapshot(); got != (StatsSnapshot{Texts: 2, CacheHits: 1, Requests: 1, Tokens: 1}) {
+		t.Errorf("call stats = %s", got)
+	}
+	s := b.Stats()
+	if s.Texts.Load() != 8 || s.CacheHits.Load() != 1 || s.Retries.Load() != 1 || s.Tokens.Load() != 6 {
+		t.Errorf("stats = %s", s)
+	}
+	j, err := json.Marshal(s.Snapshot())
+	if err != nil || !strings.Contains(string(j), `"texts":8,`) || !strings.Contains(string(j), `"hit_rate":0.125`) {
+		t.Errorf("json = %s, %v", j, err)
+	}
+
+	// 不可重试的错误直接返回
+	b = NewBatch(&flakyEmbedder{Hash: NewHash(8), fails: 5}, &BatchConfig{MaxRetries: -1})
+	if _, err = b.EmbedStrings(context.Background(), []string{"x"}); err == nil {
+		t.Error("expected error without retries")
+	}
+}
+
+func TestVectorCodec(t *testing.T) {
+	v := []float64{0.5, -1, 0.25}
+	got, err := DecodeVector(EncodeVector(v))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range v {
+		if got[i] != v[i] {
+			t.Fatalf("DecodeVector() = %v, want %v", got, v)
+		}
+	}
+	if _, err = DecodeVector([]byte{1, 2, 3}); err == nil {
+		t.Error("expected error for invalid length")
+	}
+}
@@ -0,0 +1,78 @@
+package embedder
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"sync"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Cache 向量缓存，键是文本内容的哈希
+type Cache interface {
+	// 批量读取，只返回命中的键
+	Get(ctx context.Context, keys []string) (map[string][]float64, error)
+	// 批量写入
+	Set(ctx context.Context, vectors map[string][]float64) error
+}
+
+// CacheKey 缓存的键，namespace区分不同的模型和维度，同一段文本在不同模型下的向量不同
+func CacheKey(namespace, text string) string {
+	sum := sha256.Sum256([]byte(namespace + "\x00" + text))
+	return hex.EncodeToString(sum[:])
+}
+
+// EncodeVector 把向量编码为float32小端字节，模型输出的精度不超过float32
+func EncodeVector(v []float64) []byte {
+	b := make([]byte, 4*len(v))
+	for i, x := range v {
+		binary.LittleEndian.PutUint32(b[4*i:], math.Float32bits(float32(x)))
+	}
+	return b
+}
+
+// DecodeVector 解码EncodeVector编码的向量
+func DecodeVector(b []byte) ([]float64, error) {
+	if len(b)%4 != 0 {
+		return nil, gerror.Newf("invalid vector length %d", len(b))
+	}
+	v := make([]float64, len(b)/4)
+	for i := range v {
+		v[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:])))
+	}
+	return v, nil
+}
+
+// MemoryCache 进程内的向量缓存，没有容量限制，用于开发和测试
+type MemoryCache struct {
+	mu sync.RWMutex
+	m  map[string][]float64
+}
+
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{m: make(map[string][]float64)}
+}
+
+func (c *MemoryCache) Get(ctx context.Context, keys []string) (map[string][]float64, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	res := make(map[string][]float64, len(keys))
+	for _, k := range keys {
+		if v, ok := c.m[k]; ok {
+			res[k] = v
+		}
+	}
+	return res, nil
+}
+
+func (c *MemoryCache) Set(ctx context.Context, vectors map[string][]float64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, v := range vectors {
+		c.m[k] = v
+	}
+	return nil
+}
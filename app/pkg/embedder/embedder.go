@@ -0,0 +1,135 @@
+package embedder
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// 火山引擎方舟
+	ProviderArk = "ark"
+	// 兼容OpenAI /embeddings接口的服务
+	ProviderOpenAI = "openai"
+	// 兼容Ollama /api/embed接口的服务
+	ProviderOllama = "ollama"
+	// 本地的哈希嵌入器，不需要网络，结果确定，用于开发和测试
+	ProviderHash = "hash"
+
+	// 默认的请求超时
+	DefaultTimeout = 60 * time.Second
+)
+
+// Config 嵌入器配置
+type Config struct {
+	// 嵌入器类型：ark、openai、ollama、hash
+	Provider string
+	// 模型名称
+	Model string
+	// 服务地址，为空时使用各类型的默认地址
+	BaseURL string
+	// API密钥
+	APIKey string
+	// 向量维度，启动时请求一次校验，为0时使用实际的维度；hash为0时使用默认值，openai类型大于0时会传给服务
+	Dimensions int
+	// 请求超时，<=0时使用DefaultTimeout
+	Timeout time.Duration
+}
+
+// Embedder 嵌入器，可以知道向量的维度
+type Embedder interface {
+	embedding.Embedder
+	// 向量维度，未知时返回0
+	Dimensions() int
+}
+
+// Factory 根据配置创建嵌入器
+type Factory func(ctx context.Context, conf *Config) (Embedder, error)
+
+var (
+	mu        sync.RWMutex
+	factories = map[string]Factory{
+		ProviderArk:    newArk,
+		ProviderOpenAI: newOpenAI,
+		ProviderOllama: newOllama,
+		ProviderHash: func(ctx context.Context, conf *Config) (Embedder, error) {
+			return NewHash(conf.Dimensions), nil
+		},
+	}
+)
+
+// Register 注册一种嵌入器，同名的会被覆盖
+func Register(provider string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	factories[provider] = factory
+}
+
+// Providers 已注册的嵌入器类型
+func Providers() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	res := make([]string, 0, len(factories))
+	for name := range factories {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// New 根据配置创建嵌入器，并请求一次校验向量维度
+// 配置了维度时与实际的维度不一致会返回错误，避免写入索引时才发现；没有配置时使用实际的维度
+func New(ctx context.Context, conf *Config) (Embedder, error) {
+	if conf == nil {
+		return nil, gerror.New("embedder config is required")
+	}
+	mu.RLock()
+	factory, ok := factories[conf.Provider]
+	mu.RUnlock()
+	if !ok {
+		return nil, gerror.Newf("unknown embedding provider %q, available: %v", conf.Provider, Providers())
+	}
+	e, err := factory(ctx, conf)
+	if err != nil {
+		return nil, gerror.Wrapf(err, "new %s embedder", conf.Provider)
+	}
+	vectors, err := e.EmbedStrings(ctx, []string{"dimensions"})
+	if err != nil {
+		return nil, gerror.Wrapf(err, "probe %s embedding dimensions", conf.Provider)
+	}
+	if len(vectors) != 1 || len(vectors[0]) == 0 {
+		return nil, gerror.Newf("probe %s embedding dimensions: empty vector", conf.Provider)
+	}
+	dims := len(vectors[0])
+	if e.Dimensions() > 0 {
+		if e.Dimensions() != dims {
+			return nil, gerror.Newf("%s embedding dimensions mismatch: configured %d, model returns %d", conf.Provider, e.Dimensions(), dims)
+		}
+		return e, nil
+	}
+	return &sized{Embedder: e, dims: dims}, nil
+}
+
+// 记录探测到的维度
+type sized struct {
+	Embedder
+	dims int
+}
+
+func (s *sized) Dimensions() int {
+	return s.dims
+}
+
+// 请求使用的http客户端
+func httpClient(conf *Config) *http.Client {
+	timeout := conf.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &http.Client{Timeout: timeout}
+}
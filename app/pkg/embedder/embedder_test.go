@@ -0,0 +1,110 @@
+package embedder
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func cosine(a, b []float64) float64 {
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	return dot / math.Sqrt(na*nb)
+}
+
+func TestHash(t *testing.T) {
+	e, err := New(context.Background(), &Config{Provider: ProviderHash, Dimensions: 64})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Dimensions() != 64 {
+		t.Fatalf("Dimensions() = %d", e.Dimensions())
+	}
+	v, err := e.EmbedStrings(context.Background(), []string{"知识库检索增强生成", "知识库检索增强生成", "知识库的检索", "今天天气很好", "，。"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v[0]) != 64 || cosine(v[0], v[1]) < 0.9999 {
+		t.Error("same text should get the same vector")
+	}
+	if cosine(v[0], v[2]) <= cosine(v[0], v[3]) {
+		t.Error("overlapping text should be more similar")
+	}
+	if cosine(v[4], v[4]) < 0.9999 {
+		t.Error("text without features should get a unit vector")
+	}
+}
+
+func TestOpenAI(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" || r.Header.Get("Authorization") != "Bearer key" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		var req struct {
+			Model string   `json:"model"`
+			Input []string `json:"input"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		// 倒序返回，按index排列
+		var data []map[string]any
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			data = append(data, map[string]any{"index": i, "embedding": []float64{float64(i), 1, 2}})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer srv.Close()
+
+	e, err := New(context.Background(), &Config{Provider: ProviderOpenAI, Model: "m", BaseURL: srv.URL + "/v1/", APIKey: "key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 没有配置维度时请求一次获取
+	if e.Dimensions() != 3 {
+		t.Errorf("Dimensions() = %d, want 3", e.Dimensions())
+	}
+	v, err := e.EmbedStrings(context.Background(), []string{"a", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v[0][0] != 0 || v[1][0] != 1 {
+		t.Errorf("vectors out of order: %v", v)
+	}
+	if _, err = New(context.Background(), &Config{Provider: ProviderOpenAI, Model: "m", BaseURL: srv.URL}); err == nil {
+		t.Error("expected error from bad response")
+	}
+}
+
+func TestOllama(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/embed" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"embeddings": [][]float64{{1, 2}}})
+	}))
+	defer srv.Close()
+
+	e, err := New(context.Background(), &Config{Provider: ProviderOllama, Model: "m", BaseURL: srv.URL, Dimensions: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := e.EmbedStrings(context.Background(), []string{"a"})
+	if err != nil || len(v) != 1 || len(v[0]) != 2 {
+		t.Errorf("EmbedStrings() = %v, %v", v, err)
+	}
+	// 配置的维度与实际不一致时启动失败
+	if _, err = New(context.Background(), &Config{Provider: ProviderOllama, Model: "m", BaseURL: srv.URL, Dimensions: 3}); err == nil {
+		t.Error("expected error for dimensions mismatch")
+	}
+	if _, err = New(context.Background(), &Config{Provider: "unknown"}); err == nil {
+		t.Error("expected error for unknown provider")
+	}
+}
@@ -0,0 +1,95 @@
+package embedder
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+// 哈希嵌入器的默认维度
+const DefaultHashDimensions = 256
+
+// Hash 本地的哈希嵌入器，不需要网络和模型，相同的文本总是得到相同的向量
+// 把单词、中日韩文字和相邻两个字哈希到固定维度并归一化，词语重合越多的文本越相似，没有语义理解能力，只用于开发和测试
+type Hash struct {
+	dims int
+}
+
+// NewHash 创建哈希嵌入器，dims<=0时使用DefaultHashDimensions
+func NewHash(dims int) *Hash {
+	if dims <= 0 {
+		dims = DefaultHashDimensions
+	}
+	return &Hash{dims: dims}
+}
+
+func (h *Hash) Dimensions() int {
+	return h.dims
+}
+
+func (h *Hash) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	res := make([][]float64, len(texts))
+	for i, text := range texts {
+		res[i] = h.embed(text)
+	}
+	return res, nil
+}
+
+func (h *Hash) embed(text string) []float64 {
+	v := make([]float64, h.dims)
+	add := func(feature string) {
+		f := fnv.New64a()
+		_, _ = f.Write([]byte(feature))
+		sum := f.Sum64()
+		// 用哈希的最高位决定正负，减少不同特征落到同一维度时的偏差
+		if sum>>63 == 0 {
+			v[sum%uint64(h.dims)]++
+		} else {
+			v[sum%uint64(h.dims)]--
+		}
+	}
+	var word strings.Builder
+	var prev rune
+	flush := func() {
+		if word.Len() > 0 {
+			add(word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			flush()
+			add(string(r))
+			if prev != 0 {
+				add(string([]rune{prev, r}))
+			}
+			prev = r
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(r)
+		default:
+			flush()
+		}
+		prev = 0
+	}
+	flush()
+	var norm float64
+	for _, x := range v {
+		norm += x * x
+	}
+	// 没有任何特征时返回固定的单位向量，余弦相似度不支持零向量
+	if norm == 0 {
+		v[0] = 1
+		return v
+	}
+	norm = math.Sqrt(norm)
+	for i := range v {
+		v[i] /= norm
+	}
+	return v
+}
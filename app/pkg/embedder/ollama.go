@@ -0,0 +1,60 @@
+package embedder
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// 默认的Ollama服务地址
+const defaultOllamaBaseURL = "http://localhost:11434"
+
+// 兼容Ollama /api/embed接口的嵌入器
+type ollamaEmbedder struct {
+	client  *http.Client
+	baseURL string
+	apiKey  string
+	model   string
+	dims    int
+}
+
+func newOllama(ctx context.Context, conf *Config) (Embedder, error) {
+	if conf.Model == "" {
+		return nil, gerror.New("model is required")
+	}
+	baseURL := conf.BaseURL
+	if baseURL == "" {
+		baseURL = defaultOllamaBaseURL
+	}
+	return &ollamaEmbedder{
+		client:  httpClient(conf),
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  conf.APIKey,
+		model:   conf.Model,
+		dims:    conf.Dimensions,
+	}, nil
+}
+
+func (e *ollamaEmbedder) Dimensions() int {
+	return e.dims
+}
+
+func (e *ollamaEmbedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	var res struct {
+		Embeddings [][]float64 `json:"embeddings"`
+	}
+	body := map[string]any{"model": e.model, "input": texts}
+	if err := postJSON(ctx, e.client, e.baseURL+"/api/embed", e.apiKey, body, &res); err != nil {
+		return nil, err
+	}
+	if len(res.Embeddings) != len(texts) {
+		return nil, gerror.Newf("got %d embeddings, want %d", len(res.Embeddings), len(texts))
+	}
+	return res.Embeddings, nil
+}
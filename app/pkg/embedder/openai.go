@@ -0,0 +1,108 @@
+package embedder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// 默认的OpenAI接口地址
+const defaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// 兼容OpenAI /embeddings接口的嵌入器，也可以用于vLLM、LocalAI等服务
+type openAIEmbedder struct {
+	client  *http.Client
+	baseURL string
+	apiKey  string
+	model   string
+	dims    int
+}
+
+func newOpenAI(ctx context.Context, conf *Config) (Embedder, error) {
+	if conf.Model == "" {
+		return nil, gerror.New("model is required")
+	}
+	baseURL := conf.BaseURL
+	if baseURL == "" {
+		baseURL = defaultOpenAIBaseURL
+	}
+	return &openAIEmbedder{
+		client:  httpClient(conf),
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  conf.APIKey,
+		model:   conf.Model,
+		dims:    conf.Dimensions,
+	}, nil
+}
+
+func (e *openAIEmbedder) Dimensions() int {
+	return e.dims
+}
+
+func (e *openAIEmbedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	body := map[string]any{"model": e.model, "input": texts}
+	if e.dims > 0 {
+		body["dimensions"] = e.dims
+	}
+	var res struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float64 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := postJSON(ctx, e.client, e.baseURL+"/embeddings", e.apiKey, body, &res); err != nil {
+		return nil, err
+	}
+	if len(res.Data) != len(texts) {
+		return nil, gerror.Newf("got %d embeddings, want %d", len(res.Data), len(texts))
+	}
+	vectors := make([][]float64, len(texts))
+	for _, d := range res.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, gerror.Newf("embedding index %d out of range", d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	return vectors, nil
+}
+
+// 发送JSON请求并解析响应，apiKey不为空时作为Bearer令牌
+func postJSON(ctx context.Context, client *http.Client, url, apiKey string, body, res any) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return gerror.Wrap(err, "")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return gerror.Wrap(err, "")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return gerror.Wrap(err, "")
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return gerror.Wrap(err, "")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return gerror.Wrap(&StatusError{Code: resp.StatusCode, Message: string(bytes.TrimSpace(data))}, url)
+	}
+	if err = json.Unmarshal(data, res); err != nil {
+		return gerror.Wrapf(err, "decode response from %s", url)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package redact
+
+import (
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// 中国大陆手机号
+	KindMobile = "mobile"
+	// 18位居民身份证号
+	KindIDCard = "id_card"
+	// 电子邮箱
+	KindEmail = "email"
+	// 国际银行账号
+	KindIBAN = "iban"
+	// 银行卡号
+	KindBankCard = "bank_card"
+)
+
+// Detector 在文本中查找一类敏感信息
+type Detector interface {
+	// 敏感信息的类别，记录在检测报告中
+	Kind() string
+	// 返回所有匹配的字节区间[start, end)，区间按起始位置排列且不重叠
+	Find(text string) [][2]int
+}
+
+// 正则匹配后再校验的检测器，digits为true时要求匹配的前后不是数字或字母，避免截取长数字串中的一段
+type regexDetector struct {
+	kind   string
+	re     *regexp.Regexp
+	digits bool
+	valid  func(s string) bool
+}
+
+func (d *regexDetector) Kind() string {
+	return d.kind
+}
+
+func (d *regexDetector) Find(text string) [][2]int {
+	var res [][2]int
+	for _, loc := range d.re.FindAllStringIndex(text, -1) {
+		if d.digits && (loc[0] > 0 && isAlnum(text[loc[0]-1]) || loc[1] < len(text) && isAlnum(text[loc[1]])) {
+			continue
+		}
+		if d.valid != nil && !d.valid(text[loc[0]:loc[1]]) {
+			continue
+		}
+		res = append(res, [2]int{loc[0], loc[1]})
+	}
+	return res
+}
+
+func isAlnum(b byte) bool {
+	return b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
+
+// NewMobileDetector 中国大陆手机号，可以带+86或86前缀
+func NewMobileDetector() Detector {
+	return &regexDetector{kind: KindMobile, re: regexp.MustCompile(`(?:\+?86[- ]?)?1[3-9]\d{9}`), digits: true}
+}
+
+// NewIDCardDetector 18位居民身份证号，校验出生日期和最后一位校验码
+func NewIDCardDetector() Detector {
+	return &regexDetector{
+		kind:   KindIDCard,
+		re:     regexp.MustCompile(`[1-9]\d{5}(?:18|19|20)\d{2}(?:0[1-9]|1[0-2])(?:0[1-9]|[12]\d|3[01])\d{3}[\dXx]`),
+		digits: true,
+		valid:  validIDCard,
+	}
+}
+
+// NewEmailDetector 电子邮箱
+func NewEmailDetector() Detector {
+	return &regexDetector{kind: KindEmail, re: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`)}
+}
+
+// NewIBANDetector 国际银行账号，可以每4位用空格分组，使用mod 97校验
+func NewIBANDetector() Detector {
+	return &regexDetector{
+		kind:   KindIBAN,
+		re:     regexp.MustCompile(`[A-Z]{2}\d{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,4})?`),
+		digits: true,
+		valid:  validIBAN,
+	}
+}
+
+// NewBankCardDetector 13到19位的银行卡号，可以每4位用空格或横线分组，使用Luhn算法校验
+func NewBankCardDetector() Detector {
+	return &regexDetector{
+		kind:   KindBankCard,
+		re:     regexp.MustCompile(`\d{13,19}|\d{4}(?:[ -]\d{4}){2,3}(?:[ -]\d{1,3})?`),
+		digits: true,
+		valid:  validLuhn,
+	}
+}
+
+// NewRegexDetector 自定义的正则检测器
+func NewRegexDetector(kind string, pattern string) (Detector, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &regexDetector{kind: kind, re: re}, nil
+}
+
+// 身份证号最后一位的校验码
+func validIDCard(s string) bool {
+	weights := []int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+	sum := 0
+	for i, w := range weights {
+		sum += int(s[i]-'0') * w
+	}
+	return "10X98765432"[sum%11] == strings.ToUpper(s[17:])[0]
+}
+
+// IBAN把前4位移到末尾，字母转换为数字后对97取模等于1
+func validIBAN(s string) bool {
+	s = strings.ReplaceAll(s, " ", "")
+	if len(s) < 15 || len(s) > 34 {
+		return false
+	}
+	var sb strings.Builder
+	for _, c := range s[4:] + s[:4] {
+		if c >= 'A' && c <= 'Z' {
+			sb.WriteString(strconv.Itoa(int(c - 'A' + 10)))
+		} else {
+			sb.WriteRune(c)
+		}
+	}
+	n, ok := new(big.Int).SetString(sb.String(), 10)
+	return ok && new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
+
+// Luhn校验，忽略分组的空格和横线
+func validLuhn(s string) bool {
+	sum, n := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c == ' ' || c == '-' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n >= 13 && sum%10 == 0
+}
@@ -0,0 +1,313 @@
+package redact
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// 用*遮盖敏感信息，保留开头和结尾的几个字符
+	ModeMask = "mask"
+	// 替换为敏感信息的哈希，相同的值得到相同的标记，例如[MOBILE_1a2b3c4d]
+	ModeHash = "hash"
+	// 发现敏感信息时拒绝整个文档
+	ModeReject = "reject"
+)
+
+// ErrRejected 拒绝模式下文档包含敏感信息
+var ErrRejected = errors.New("document contains sensitive information")
+
+// Rule 自定义的正则检测规则
+type Rule struct {
+	// 敏感信息的类别
+	Kind string
+	// 正则表达式
+	Pattern string
+}
+
+// Config 脱敏配置
+type Config struct {
+	// 处理方式：mask、hash、reject，默认mask
+	Mode string
+	// 启用的内置检测器：mobile、id_card、email、iban、bank_card，为空时全部启用
+	Detectors []string
+	// 自定义的正则检测规则
+	Rules []Rule
+	// 计算哈希标记的盐，避免通过枚举手机号等反查原值
+	Salt string
+}
+
+// Report 检测报告，每类敏感信息出现的次数
+type Report map[string]int
+
+// Total 敏感信息的总数
+func (r Report) Total() int {
+	n := 0
+	for _, c := range r {
+		n += c
+	}
+	return n
+}
+
+// 合并另一个报告
+func (r Report) add(o Report) {
+	for k, c := range o {
+		r[k] += c
+	}
+}
+
+// Redactor 查找并处理文本中的敏感信息
+type Redactor struct {
+	mode      string
+	salt      string
+	detectors []Detector
+}
+
+// 内置检测器，按优先级排列，多个检测器匹配到重叠的区间时使用靠前的
+var builtins = []struct {
+	kind string
+	new  func() Detector
+}{
+	{KindIDCard, NewIDCardDetector},
+	{KindIBAN, NewIBANDetector},
+	{KindBankCard, NewBankCardDetector},
+	{KindMobile, NewMobileDetector},
+	{KindEmail, NewEmailDetector},
+}
+
+func New(conf *Config) (*Redactor, error) {
+	if conf == nil {
+		conf = &Config{}
+	}
+	r := &Redactor{mode: conf.Mode, salt: conf.Salt}
+	switch r.mode {
+	case "":
+		r.mode = ModeMask
+	case ModeMask, ModeHash, ModeReject:
+	default:
+		return nil, gerror.Newf("unknown redaction mode %q", conf.Mode)
+	}
+	all := len(conf.Detectors) == 0
+	enabled := make(map[string]bool, len(conf.Detectors))
+	for _, kind := range conf.Detectors {
+		enabled[kind] = true
+	}
+	for _, b := range builtins {
+		if all || enabled[b.kind] {
+			r.detectors = append(r.detectors, b.new())
+			delete(enabled, b.kind)
+		}
+	}
+	for kind := range enabled {
+		return nil, gerror.Newf("unknown detector %q", kind)
+	}
+	for _, rule := range conf.Rules {
+		if rule.Kind == "" {
+			return nil, gerror.Newf("detector kind is required for pattern %q", rule.Pattern)
+		}
+		d, err := NewRegexDetector(rule.Kind, rule.Pattern)
+		if err != nil {
+			return nil, gerror.Wrapf(err, "invalid pattern for %s", rule.Kind)
+		}
+		r.detectors = append(r.detectors, d)
+	}
+	return r, nil
+}
+
+// Mode 处理方式
+func (r *Redactor) Mode() string {
+	return r.mode
+}
+
+// 匹配到的敏感信息
+type match struct {
+	start, end int
+	kind       string
+}
+
+// 查找所有敏感信息，重叠的区间只保留优先级高的检测器的结果
+func (r *Redactor) find(text string) []match {
+	var res []match
+	for _, d := range r.detectors {
+		for _, loc := range d.Find(text) {
+			overlap := false
+			for _, m := range res {
+				if loc[0] < m.end && m.start < loc[1] {
+					overlap = true
+					break
+				}
+			}
+			if !overlap && loc[1] > loc[0] {
+				res = append(res, match{start: loc[0], end: loc[1], kind: d.Kind()})
+			}
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].start < res[j].start })
+	return res
+}
+
+// Redact 处理文本中的敏感信息，返回处理后的文本和检测报告，拒绝模式下文本不变
+func (r *Redactor) Redact(text string) (string, Report) {
+	matches := r.find(text)
+	report := make(Report)
+	if len(matches) == 0 {
+		return text, report
+	}
+	for _, m := range matches {
+		report[m.kind]++
+	}
+	if r.mode == ModeReject {
+		return text, report
+	}
+	var sb strings.Builder
+	last := 0
+	for _, m := range matches {
+		sb.WriteString(text[last:m.start])
+		sb.WriteString(r.replace(m.kind, text[m.start:m.end]))
+		last = m.end
+	}
+	sb.WriteString(text[last:])
+	return sb.String(), report
+}
+
+// 替换一处敏感信息
+func (r *Redactor) replace(kind, s string) string {
+	if r.mode == ModeHash {
+		sum := sha256.Sum256([]byte(r.salt + s))
+		return "[" + strings.ToUpper(kind) + "_" + hex.EncodeToString(sum[:4]) + "]"
+	}
+	if kind == KindEmail {
+		at := strings.LastIndex(s, "@")
+		_, size := utf8.DecodeRuneInString(s)
+		return s[:size] + "***" + s[at:]
+	}
+	// 较长的号码保留前3位和后4位，便于人工核对
+	n := utf8.RuneCountInString(s)
+	if n < 11 {
+		return strings.Repeat("*", n)
+	}
+	runes := []rune(s)
+	return string(runes[:3]) + strings.Repeat("*", n-7) + string(runes[n-4:])
+}
+
+type transformOptions struct {
+	report   Report
+	metaKeys []string
+}
+
+// WithReport 把检测报告累计到report中
+func WithReport(report Report) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *transformOptions) {
+		o.report = report
+	})
+}
+
+// WithMetaKeys 同时处理这些元数据中的敏感信息，例如邮件的发件人和收件人
+// 处理字符串类型的值，以及map、切片中嵌套的字符串，例如JSON记录映射出的字段
+func WithMetaKeys(keys ...string) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *transformOptions) {
+		o.metaKeys = keys
+	})
+}
+
+// Transform 处理文档内容中的敏感信息，拒绝模式下发现敏感信息时返回ErrRejected
+func (r *Redactor) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	options := document.GetTransformerImplSpecificOptions(&transformOptions{}, opts...)
+	total := make(Report)
+	res := make([]*schema.Document, 0, len(docs))
+	for _, doc := range docs {
+		content, report := r.Redact(doc.Content)
+		total.add(report)
+		// 元数据可能被多个文档共用，有改动时才复制
+		meta, copied := doc.MetaData, false
+		for _, key := range options.metaKeys {
+			v, ok := doc.MetaData[key]
+			if !ok {
+				continue
+			}
+			redacted, report := r.redactValue(v)
+			if report.Total() == 0 {
+				continue
+			}
+			total.add(report)
+			if !copied {
+				meta = make(map[string]any, len(doc.MetaData))
+				for k, v := range doc.MetaData {
+					meta[k] = v
+				}
+				copied = true
+			}
+			meta[key] = redacted
+		}
+		res = append(res, &schema.Document{ID: doc.ID, Content: content, MetaData: meta})
+	}
+	if options.report != nil {
+		options.report.add(total)
+	}
+	if r.mode == ModeReject && total.Total() > 0 {
+		return nil, gerror.Wrapf(ErrRejected, "%v", map[string]int(total))
+	}
+	return res, nil
+}
+
+// 处理元数据的值，map和切片递归处理其中的字符串，有改动时返回新的map和切片，不修改原来的值
+func (r *Redactor) redactValue(v any) (any, Report) {
+	switch val := v.(type) {
+	case string:
+		return r.Redact(val)
+	case float64:
+		// JSON中的手机号、卡号可能是数字，发现敏感信息时替换为处理后的字符串
+		redacted, report := r.Redact(strconv.FormatFloat(val, 'f', -1, 64))
+		if report.Total() == 0 {
+			return v, report
+		}
+		return redacted, report
+	case []string:
+		total := make(Report)
+		res := make([]string, len(val))
+		for i, s := range val {
+			var report Report
+			res[i], report = r.Redact(s)
+			total.add(report)
+		}
+		if total.Total() == 0 {
+			return v, total
+		}
+		return res, total
+	case []any:
+		total := make(Report)
+		res := make([]any, len(val))
+		for i, item := range val {
+			var report Report
+			res[i], report = r.redactValue(item)
+			total.add(report)
+		}
+		if total.Total() == 0 {
+			return v, total
+		}
+		return res, total
+	case map[string]any:
+		total := make(Report)
+		res := make(map[string]any, len(val))
+		for k, item := range val {
+			var report Report
+			res[k], report = r.redactValue(item)
+			total.add(report)
+		}
+		if total.Total() == 0 {
+			return v, total
+		}
+		return res, total
+	}
+	return v, nil
+}
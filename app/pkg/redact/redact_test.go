@@ -0,0 +1,131 @@
+package redact
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+
+	"ragx/app/pkg/docparser"
+)
+
+func TestRedactMask(t *testing.T) {
+	r, err := New(&Config{Rules: []Rule{{Kind: "employee_id", Pattern: `EMP-\d{6}`}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := "张三 电话13812345678，身份证11010519491231002X，邮箱zhangsan@example.com，" +
+		"卡号4111 1111 1111 1111，IBAN GB82 WEST 1234 5698 7654 32，工号EMP-000123"
+	got, report := r.Redact(text)
+	want := "张三 电话138****5678，身份证110***********002X，邮箱z***@example.com，" +
+		"卡号411************1111，IBAN GB8********************4 32，工号**********"
+	if got != want {
+		t.Errorf("Redact() =\n%s\nwant\n%s", got, want)
+	}
+	for _, kind := range []string{KindMobile, KindIDCard, KindEmail, KindBankCard, KindIBAN, "employee_id"} {
+		if report[kind] != 1 {
+			t.Errorf("report[%s] = %d, want 1", kind, report[kind])
+		}
+	}
+}
+
+func TestRedactValidation(t *testing.T) {
+	r, err := New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 校验码错误的身份证号、Luhn校验失败的卡号、长数字串中的一段都不是敏感信息
+	for _, text := range []string{"110105194912310021", "4111111111111112", "订单号2023138123456789012"} {
+		if got, report := r.Redact(text); got != text || report.Total() != 0 {
+			t.Errorf("Redact(%q) = %q, %v", text, got, report)
+		}
+	}
+}
+
+func TestRedactHash(t *testing.T) {
+	r, err := New(&Config{Mode: ModeHash, Detectors: []string{KindMobile}, Salt: "s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := r.Redact("13812345678 和 13812345678，a@b.com")
+	parts := strings.Split(got, " 和 ")
+	if !strings.HasPrefix(parts[0], "[MOBILE_") || !strings.HasPrefix(parts[1], parts[0]) {
+		t.Errorf("Redact() = %q", got)
+	}
+	// 没有启用邮箱检测器
+	if !strings.HasSuffix(got, "a@b.com") {
+		t.Errorf("Redact() = %q", got)
+	}
+	if _, err = New(&Config{Detectors: []string{"unknown"}}); err == nil {
+		t.Error("expected error for unknown detector")
+	}
+}
+
+func TestTransformReject(t *testing.T) {
+	r, err := New(&Config{Mode: ModeReject})
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := make(Report)
+	docs := []*schema.Document{{Content: "没有敏感信息"}, {Content: "联系 a@b.com"}}
+	if _, err = r.Transform(context.Background(), docs, WithReport(report)); !errors.Is(err, ErrRejected) {
+		t.Fatalf("err = %v, want ErrRejected", err)
+	}
+	if report[KindEmail] != 1 || docs[1].Content != "联系 a@b.com" {
+		t.Errorf("report = %v, content = %q", report, docs[1].Content)
+	}
+
+	r, _ = New(nil)
+	report = make(Report)
+	meta := map[string]any{"from": "a@b.com", "page": 1}
+	res, err := r.Transform(context.Background(), []*schema.Document{{Content: "正文", MetaData: meta}}, WithReport(report), WithMetaKeys("from", "page"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 原来的元数据不变
+	if res[0].MetaData["from"] != "a***@b.com" || meta["from"] != "a@b.com" || report[KindEmail] != 1 {
+		t.Errorf("report = %v, content = %q", report, docs[1].Content)
+	}
+}
+
+func TestTransformMappedFields(t *testing.T) {
+	r, err := New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := `{"id":"1","text":"客户来电","customer_phone":"13812345678","contact":{"mobile":13912345678},"tags":["a@b.com"]}`
+	docs, err := docparser.NewJSONLParser().Parse(context.Background(), strings.NewReader(data),
+		docparser.WithJSONMapping(&docparser.JSONMapping{
+			ContentTemplate: "{text}",
+			MetadataFields:  []string{"customer_phone", "contact", "tags"},
+			IDField:         "id",
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := make(Report)
+	res, err := r.Transform(context.Background(), docs, WithReport(report), WithMetaKeys(docparser.MetaFields, docparser.MetaRecordID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(res[0].MetaData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"13812345678", "13912345678", "a@b.com"} {
+		if strings.Contains(string(b), s) {
+			t.Errorf("metadata %s contains %s", b, s)
+		}
+	}
+	fields := res[0].MetaData[docparser.MetaFields].(map[string]any)
+	if fields["customer_phone"] != "138****5678" || report[KindMobile] != 2 || report[KindEmail] != 1 {
+		t.Errorf("fields = %v, report = %v", fields, report)
+	}
+	// 解析出的原始元数据不变
+	if docs[0].MetaData[docparser.MetaFields].(map[string]any)["customer_phone"] != "13812345678" {
+		t.Errorf("original metadata changed: %v", docs[0].MetaData)
+	}
+}
@@ -0,0 +1,388 @@
+package splitter
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"ragx/app/pkg/tokenizer"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// 代码的语言，即文件扩展名，不含.
+	MetaLanguage = "language"
+	// Go代码的包名
+	MetaPackage = "package"
+	// 声明的名称，方法为"(*T).Name"的形式
+	MetaSymbol = "symbol"
+	// 方法的接收者类型
+	MetaReceiver = "receiver"
+	// 声明的类型：func、method、type、const、var、import、package
+	MetaKind = "kind"
+	// 代码块的起止行号，从1开始
+	MetaLineStart = "line_start"
+	MetaLineEnd   = "line_end"
+
+	// 默认代码块的大小上限，token数
+	DefaultCodeChunkSize = 512
+)
+
+// CodeExtensions 使用代码分割器的文件扩展名
+var CodeExtensions = map[string]bool{
+	".go": true, ".py": true, ".js": true, ".jsx": true, ".ts": true, ".tsx": true, ".java": true, ".kt": true,
+	".c": true, ".h": true, ".cc": true, ".cpp": true, ".hpp": true, ".cs": true, ".rs": true, ".rb": true,
+	".php": true, ".swift": true, ".scala": true, ".sh": true, ".sql": true, ".proto": true, ".lua": true,
+}
+
+// CodeConfig 代码分割器配置
+type CodeConfig struct {
+	// 代码块的大小上限，token数，<=0时使用DefaultCodeChunkSize
+	ChunkSize int
+	// 计算token数的分词器，为空时使用估算
+	Tokenizer tokenizer.Tokenizer
+}
+
+// CodeSplitter 按代码结构分割源代码，避免把函数从中间切开
+// Go代码使用go/parser按顶层声明分割，保留文档注释，包名、接收者等信息记录在元数据中
+// 其他语言按行分割，只在括号配平且没有缩进的位置切分
+type CodeSplitter struct {
+	chunkSize int
+	tok       tokenizer.Tokenizer
+}
+
+func NewCodeSplitter(conf *CodeConfig) *CodeSplitter {
+	s := &CodeSplitter{chunkSize: DefaultCodeChunkSize, tok: tokenizer.NewEstimator()}
+	if conf != nil && conf.ChunkSize > 0 {
+		s.chunkSize = conf.ChunkSize
+	}
+	if conf != nil && conf.Tokenizer != nil {
+		s.tok = conf.Tokenizer
+	}
+	return s
+}
+
+func (s *CodeSplitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	var res []*schema.Document
+	for _, doc := range docs {
+		ext, _ := doc.MetaData["_extension"].(string)
+		if ext == "" {
+			if src, ok := doc.MetaData["_source"].(string); ok {
+				ext = path.Ext(src)
+			}
+		}
+		var chunks []codeChunk
+		if ext == ".go" {
+			chunks = s.splitGo(doc.Content)
+		}
+		if chunks == nil {
+			chunks = s.splitLines(doc.Content, 1, nil)
+		}
+		for _, c := range chunks {
+			meta := make(map[string]any, len(doc.MetaData)+len(c.meta)+3)
+			for k, v := range doc.MetaData {
+				meta[k] = v
+			}
+			for k, v := range c.meta {
+				meta[k] = v
+			}
+			if ext != "" {
+				meta[MetaLanguage] = strings.TrimPrefix(ext, ".")
+			}
+			meta[MetaLineStart] = c.start
+			meta[MetaLineEnd] = c.end
+			res = append(res, &schema.Document{Content: c.content, MetaData: meta})
+		}
+	}
+	return res, nil
+}
+
+// 分割出的代码块
+type codeChunk struct {
+	content    string
+	start, end int
+	meta       map[string]any
+}
+
+// 按顶层声明分割Go代码，解析失败时返回nil
+// 函数、方法单独作为一块，相邻的类型、常量、变量、导入声明在不超过大小上限时合并
+func (s *CodeSplitter) splitGo(src string) []codeChunk {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil
+	}
+	tf := fset.File(f.Pos())
+	offset := func(p token.Pos) int { return tf.Offset(p) }
+	line := func(off int) int { return tf.Line(tf.Pos(off)) }
+	pkgMeta := map[string]any{MetaPackage: f.Name.Name}
+
+	var chunks []codeChunk
+	// 等待合并的声明
+	var pending *codeChunk
+	flush := func() {
+		if pending != nil {
+			chunks = append(chunks, *pending)
+			pending = nil
+		}
+	}
+	emit := func(start, end int, meta map[string]any, merge bool) {
+		content := strings.TrimSpace(src[start:end])
+		if content == "" {
+			return
+		}
+		c := codeChunk{content: content, start: line(start), end: line(end), meta: meta}
+		if s.tok.Count(content) > s.chunkSize {
+			// 超过大小上限的声明按行继续分割，每一块都保留声明的元数据
+			flush()
+			chunks = append(chunks, s.splitLines(content, c.start, meta)...)
+			return
+		}
+		if !merge {
+			flush()
+			chunks = append(chunks, c)
+			return
+		}
+		if pending != nil && s.tok.Count(pending.content+"\n\n"+content) <= s.chunkSize {
+			pending.content += "\n\n" + content
+			pending.end = c.end
+			pending.meta = mergeSymbols(pending.meta, meta)
+			return
+		}
+		flush()
+		pending = &c
+	}
+
+	// 包注释和package语句
+	start := 0
+	if f.Doc != nil {
+		start = offset(f.Doc.Pos())
+	}
+	emit(start, offset(f.Name.End()), withMeta(pkgMeta, MetaKind, "package"), true)
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			start := offset(d.Pos())
+			if d.Doc != nil {
+				start = offset(d.Doc.Pos())
+			}
+			meta := withMeta(pkgMeta, MetaKind, "func")
+			meta[MetaSymbol] = d.Name.Name
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				recv := typeString(d.Recv.List[0].Type)
+				meta[MetaKind] = "method"
+				meta[MetaReceiver] = strings.TrimPrefix(recv, "*")
+				meta[MetaSymbol] = "(" + recv + ")." + d.Name.Name
+			}
+			emit(start, offset(d.End()), meta, false)
+		case *ast.GenDecl:
+			start := offset(d.Pos())
+			if d.Doc != nil {
+				start = offset(d.Doc.Pos())
+			}
+			meta := withMeta(pkgMeta, MetaKind, d.Tok.String())
+			if names := specNames(d); len(names) > 0 {
+				meta[MetaSymbol] = strings.Join(names, ",")
+			}
+			emit(start, offset(d.End()), meta, true)
+		}
+	}
+	flush()
+	return chunks
+}
+
+// 复制元数据并设置一个值
+func withMeta(meta map[string]any, key string, value any) map[string]any {
+	res := make(map[string]any, len(meta)+3)
+	for k, v := range meta {
+		res[k] = v
+	}
+	res[key] = value
+	return res
+}
+
+// 合并两个声明的元数据，名称和类型用逗号拼接
+func mergeSymbols(a, b map[string]any) map[string]any {
+	res := withMeta(a, MetaKind, joinMeta(a[MetaKind], b[MetaKind]))
+	if sym := joinMeta(a[MetaSymbol], b[MetaSymbol]); sym != "" {
+		res[MetaSymbol] = sym
+	}
+	return res
+}
+
+func joinMeta(a, b any) string {
+	x, _ := a.(string)
+	y, _ := b.(string)
+	switch {
+	case x == "":
+		return y
+	case y == "" || x == y || strings.HasSuffix(x, ","+y):
+		return x
+	}
+	return x + "," + y
+}
+
+// 类型、常量、变量声明的名称
+func specNames(d *ast.GenDecl) []string {
+	var names []string
+	for _, spec := range d.Specs {
+		switch sp := spec.(type) {
+		case *ast.TypeSpec:
+			names = append(names, sp.Name.Name)
+		case *ast.ValueSpec:
+			for _, n := range sp.Names {
+				if n.Name != "_" {
+					names = append(names, n.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// 接收者类型的文本，例如*Client、List[T]
+func typeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X)
+	case *ast.IndexExpr:
+		return typeString(t.X) + "[" + typeString(t.Index) + "]"
+	case *ast.IndexListExpr:
+		params := make([]string, 0, len(t.Indices))
+		for _, i := range t.Indices {
+			params = append(params, typeString(i))
+		}
+		return typeString(t.X) + "[" + strings.Join(params, ", ") + "]"
+	case *ast.SelectorExpr:
+		return typeString(t.X) + "." + t.Sel.Name
+	}
+	return ""
+}
+
+// 按行分割代码，firstLine是第一行的行号
+// 只在括号最浅的位置切分，优先选择下一行没有缩进或为空行的位置，即顶层声明之间
+func (s *CodeSplitter) splitLines(src string, firstLine int, meta map[string]any) []codeChunk {
+	lines := strings.Split(src, "\n")
+	// 每行结束时的括号深度
+	depths := braceDepths(lines)
+	var chunks []codeChunk
+	add := func(from, to int) {
+		if c := strings.TrimSpace(strings.Join(lines[from:to], "\n")); c != "" {
+			chunks = append(chunks, codeChunk{content: c, start: firstLine + from, end: firstLine + to - 1, meta: meta})
+		}
+	}
+	// 分割[from, to)行
+	var split func(from, to int)
+	split = func(from, to int) {
+		if s.tok.Count(strings.Join(lines[from:to], "\n")) <= s.chunkSize {
+			add(from, to)
+			return
+		}
+		if to-from == 1 {
+			// 压缩过的代码等超长的行按字符切分
+			for _, part := range tokenizer.Split(s.tok, lines[from], s.chunkSize) {
+				chunks = append(chunks, codeChunk{content: part, start: firstLine + from, end: firstLine + from, meta: meta})
+			}
+			return
+		}
+		minDepth := depths[from]
+		for i := from; i < to-1; i++ {
+			minDepth = min(minDepth, depths[i])
+		}
+		// 在第i行之后切分，第一块不超过上限的前提下尽量大
+		first, fit, fitTop := -1, -1, -1
+		n := 0
+		for i := from; i < to-1; i++ {
+			n += s.tok.Count(lines[i]) + 1
+			if depths[i] != minDepth {
+				continue
+			}
+			if first < 0 {
+				first = i
+			}
+			if n > s.chunkSize {
+				break
+			}
+			fit = i
+			if next := lines[i+1]; strings.TrimSpace(next) == "" || !startsWithSpace(next) {
+				fitTop = i
+			}
+		}
+		best := fitTop
+		if best < 0 {
+			best = fit
+		}
+		if best < 0 {
+			best = first
+		}
+		split(from, best+1)
+		split(best+1, to)
+	}
+	split(0, len(lines))
+	return s.mergeSmall(chunks)
+}
+
+// 合并相邻的较小代码块
+func (s *CodeSplitter) mergeSmall(chunks []codeChunk) []codeChunk {
+	var res []codeChunk
+	for _, c := range chunks {
+		if n := len(res); n > 0 && s.tok.Count(res[n-1].content+"\n"+c.content) <= s.chunkSize {
+			res[n-1].content += "\n" + c.content
+			res[n-1].end = c.end
+			continue
+		}
+		res = append(res, c)
+	}
+	return res
+}
+
+// 每行结束时未闭合的括号数量，忽略字符串和行注释中的括号
+func braceDepths(lines []string) []int {
+	depths := make([]int, len(lines))
+	depth := 0
+	for i, l := range lines {
+		var quote byte
+		for j := 0; j < len(l); j++ {
+			c := l[j]
+			if quote != 0 {
+				if c == '\\' {
+					j++
+				} else if c == quote {
+					quote = 0
+				}
+				continue
+			}
+			switch c {
+			case '"', '\'', '`':
+				quote = c
+			case '/':
+				if j+1 < len(l) && l[j+1] == '/' {
+					j = len(l)
+				}
+			case '#':
+				// shell、python的注释
+				if j == 0 || l[j-1] == ' ' {
+					j = len(l)
+				}
+			case '{', '(', '[':
+				depth++
+			case '}', ')', ']':
+				if depth > 0 {
+					depth--
+				}
+			}
+		}
+		depths[i] = depth
+	}
+	return depths
+}
+
+func startsWithSpace(l string) bool {
+	return strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t")
+}
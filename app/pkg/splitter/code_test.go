@@ -0,0 +1,96 @@
+package splitter
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// 按字节计数，便于测试中精确控制块的大小
+type byteCounter struct{}
+
+func (byteCounter) Count(text string) int { return len(text) }
+
+const testGoSource = `// Package demo 示例
+package demo
+
+import "fmt"
+
+// Client 客户端
+type Client struct {
+	name string
+}
+
+const a, b = 1, 2
+
+// Hello 打招呼
+// 第二行注释
+func (c *Client) Hello() {
+	if c != nil {
+		fmt.Println("hello {", c.name)
+	}
+}
+
+func Add(x, y int) int {
+	return x + y
+}
+`
+
+func TestCodeSplitterGo(t *testing.T) {
+	docs, err := NewCodeSplitter(nil).Transform(context.Background(), []*schema.Document{{
+		Content:  testGoSource,
+		MetaData: map[string]any{"_extension": ".go", "_file_name": "demo.go"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 3 {
+		for _, d := range docs {
+			t.Logf("%v\n%s", d.MetaData, d.Content)
+		}
+		t.Fatalf("got %d docs, want 3", len(docs))
+	}
+	if !strings.HasPrefix(docs[0].Content, "// Package demo") || docs[0].MetaData[MetaSymbol] != "Client,a,b" {
+		t.Errorf("first chunk = %q, meta = %v", docs[0].Content, docs[0].MetaData)
+	}
+	m := docs[1].MetaData
+	if !strings.HasPrefix(docs[1].Content, "// Hello 打招呼\n// 第二行注释\nfunc (c *Client) Hello()") ||
+		m[MetaSymbol] != "(*Client).Hello" || m[MetaReceiver] != "Client" || m[MetaKind] != "method" ||
+		m[MetaPackage] != "demo" || m[MetaLineStart] != 13 || m[MetaLineEnd] != 19 || m["_file_name"] != "demo.go" {
+		t.Errorf("method chunk = %q, meta = %v", docs[1].Content, m)
+	}
+	if docs[2].MetaData[MetaSymbol] != "Add" || docs[2].MetaData[MetaKind] != "func" {
+		t.Errorf("func meta = %v", docs[2].MetaData)
+	}
+}
+
+func TestCodeSplitterLines(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < 4; i++ {
+		b.WriteString("function f() {\n")
+		for j := 0; j < 3; j++ {
+			b.WriteString("  call(\"}\");\n")
+		}
+		b.WriteString("}\n\n")
+	}
+	docs, err := NewCodeSplitter(&CodeConfig{ChunkSize: 100, Tokenizer: byteCounter{}}).Transform(context.Background(), []*schema.Document{{
+		Content:  b.String(),
+		MetaData: map[string]any{"_extension": ".js"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 4 {
+		t.Fatalf("got %d docs, want 4", len(docs))
+	}
+	for _, d := range docs {
+		if !strings.HasPrefix(d.Content, "function f() {") || !strings.HasSuffix(d.Content, "}") || d.MetaData[MetaLanguage] != "js" {
+			t.Errorf("unexpected chunk %q", d.Content)
+		}
+	}
+	if docs[1].MetaData[MetaLineStart] != 7 {
+		t.Errorf("line_start = %v", docs[1].MetaData[MetaLineStart])
+	}
+}
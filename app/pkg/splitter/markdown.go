@@ -0,0 +1,288 @@
+package splitter
+
+import (
+	"context"
+	"ragx/app/pkg/tokenizer"
+	"regexp"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+// 默认markdown文档块的大小上限，token数
+const DefaultMarkdownChunkSize = 512
+
+var (
+	// 标题，例如"## 安装"
+	headingRe = regexp.MustCompile(`^ {0,3}(#{1,6})[ \t]+(.*?)[ \t#]*$`)
+	// 代码块的开始，例如"```go"、"~~~"
+	fenceRe = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	// 表格的分隔行，例如"| --- | :---: |"
+	tableSepRe = regexp.MustCompile(`^ {0,3}\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	// 列表项，例如"- a"、"1. a"
+	listItemRe = regexp.MustCompile(`^ {0,3}([-*+]|\d{1,9}[.)])[ \t]+`)
+
+	// 标题级别对应的元数据
+	headingKeys = []string{"h1", "h2", "h3", "h4", "h5", "h6"}
+)
+
+// MarkdownConfig markdown分割器配置
+type MarkdownConfig struct {
+	// 文档块的大小上限，token数，<=0时使用DefaultMarkdownChunkSize
+	ChunkSize int
+	// 计算token数的分词器，为空时使用估算
+	Tokenizer tokenizer.Tokenizer
+}
+
+// MarkdownSplitter 按一到六级标题分割markdown文档，标题记录在h1到h6元数据中
+// 代码块、表格、列表作为整体不会被切开，超过大小上限时代码块重复围栏、表格重复表头后再切分
+type MarkdownSplitter struct {
+	chunkSize int
+	tok       tokenizer.Tokenizer
+}
+
+func NewMarkdownSplitter(conf *MarkdownConfig) *MarkdownSplitter {
+	s := &MarkdownSplitter{chunkSize: DefaultMarkdownChunkSize, tok: tokenizer.NewEstimator()}
+	if conf != nil && conf.ChunkSize > 0 {
+		s.chunkSize = conf.ChunkSize
+	}
+	if conf != nil && conf.Tokenizer != nil {
+		s.tok = conf.Tokenizer
+	}
+	return s
+}
+
+func (s *MarkdownSplitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	var res []*schema.Document
+	// 块之间的空行
+	sep := s.tok.Count("\n\n")
+	for _, doc := range docs {
+		var titles [6]string
+		var buf []string
+		size := 0
+		// 只有标题没有正文的段落不输出
+		hasBody := false
+		emit := func() {
+			content := strings.TrimSpace(strings.Join(buf, "\n\n"))
+			ok := content != "" && hasBody
+			buf, size, hasBody = buf[:0], 0, false
+			if !ok {
+				return
+			}
+			meta := make(map[string]any, len(doc.MetaData)+len(titles))
+			for k, v := range doc.MetaData {
+				meta[k] = v
+			}
+			for i, t := range titles {
+				if t != "" {
+					meta[headingKeys[i]] = t
+				} else {
+					delete(meta, headingKeys[i])
+				}
+			}
+			res = append(res, &schema.Document{Content: content, MetaData: meta})
+		}
+		for _, b := range parseBlocks(doc.Content) {
+			if b.kind == blockHeading {
+				// 新的标题开始新的一段，下级标题清空
+				emit()
+				titles[b.level-1] = b.title
+				for i := b.level; i < len(titles); i++ {
+					titles[i] = ""
+				}
+			}
+			for _, part := range s.splitBlock(b) {
+				n := s.tok.Count(part)
+				if size > 0 && size+n > s.chunkSize {
+					emit()
+				}
+				buf = append(buf, part)
+				size += n + sep
+				hasBody = hasBody || b.kind != blockHeading
+			}
+		}
+		emit()
+	}
+	return res, nil
+}
+
+type blockKind int
+
+const (
+	blockParagraph blockKind = iota
+	blockHeading
+	blockFence
+	blockTable
+	blockList
+)
+
+// markdown中的一个块
+type mdBlock struct {
+	kind  blockKind
+	lines []string
+	// 标题的级别和文本
+	level int
+	title string
+}
+
+// 将markdown拆分为块，块之间的空行被去掉
+func parseBlocks(content string) []mdBlock {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	var blocks []mdBlock
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+		case fenceRe.MatchString(line):
+			// 代码块直到相同的围栏结束，没有结束时到文档末尾
+			fence := fenceRe.FindStringSubmatch(line)[1]
+			j := i + 1
+			for j < len(lines) && !isFenceClose(lines[j], fence) {
+				j++
+			}
+			end := min(j+1, len(lines))
+			blocks = append(blocks, mdBlock{kind: blockFence, lines: lines[i:end]})
+			i = end
+		case headingRe.MatchString(line):
+			m := headingRe.FindStringSubmatch(line)
+			blocks = append(blocks, mdBlock{kind: blockHeading, lines: []string{strings.TrimSpace(line)}, level: len(m[1]), title: m[2]})
+			i++
+		case strings.Contains(line, "|") && i+1 < len(lines) && tableSepRe.MatchString(lines[i+1]) && strings.Contains(lines[i+1], "-"):
+			j := i + 2
+			for j < len(lines) && strings.TrimSpace(lines[j]) != "" && strings.Contains(lines[j], "|") {
+				j++
+			}
+			blocks = append(blocks, mdBlock{kind: blockTable, lines: lines[i:j]})
+			i = j
+		case listItemRe.MatchString(line):
+			// 列表项之间的空行、缩进的续行都属于列表
+			j := i + 1
+			for j < len(lines) {
+				l := lines[j]
+				if strings.TrimSpace(l) == "" {
+					k := j + 1
+					for k < len(lines) && strings.TrimSpace(lines[k]) == "" {
+						k++
+					}
+					if k < len(lines) && (listItemRe.MatchString(lines[k]) || startsWithSpace(lines[k])) {
+						j = k
+						continue
+					}
+					break
+				}
+				if !listItemRe.MatchString(l) && !startsWithSpace(l) {
+					break
+				}
+				j++
+			}
+			blocks = append(blocks, mdBlock{kind: blockList, lines: trimBlank(lines[i:j])})
+			i = j
+		default:
+			j := i + 1
+			for j < len(lines) && strings.TrimSpace(lines[j]) != "" && !startsBlock(lines, j) {
+				j++
+			}
+			blocks = append(blocks, mdBlock{kind: blockParagraph, lines: lines[i:j]})
+			i = j
+		}
+	}
+	return blocks
+}
+
+// 第i行是否开始一个新的块，用于结束段落
+func startsBlock(lines []string, i int) bool {
+	l := lines[i]
+	return fenceRe.MatchString(l) || headingRe.MatchString(l) || listItemRe.MatchString(l) ||
+		(strings.Contains(l, "|") && i+1 < len(lines) && tableSepRe.MatchString(lines[i+1]) && strings.Contains(lines[i+1], "-"))
+}
+
+func isFenceClose(line, fence string) bool {
+	t := strings.TrimSpace(line)
+	return strings.HasPrefix(t, fence) && strings.Trim(t, fence[:1]) == ""
+}
+
+// 去掉末尾的空行
+func trimBlank(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// 块的文本，超过大小上限时切分为多段
+func (s *MarkdownSplitter) splitBlock(b mdBlock) []string {
+	text := strings.Join(b.lines, "\n")
+	if s.tok.Count(text) <= s.chunkSize {
+		return []string{text}
+	}
+	switch b.kind {
+	case blockFence:
+		// 每一段都加上开始和结束的围栏，保持代码块完整
+		open := b.lines[0]
+		body := b.lines[1:]
+		closing := strings.Repeat(fenceRe.FindStringSubmatch(open)[1][:1], 3)
+		if n := len(body); n > 0 && isFenceClose(body[n-1], closing) {
+			closing = strings.TrimSpace(body[n-1])
+			body = body[:n-1]
+		}
+		return s.packLines(body, s.chunkSize-s.tok.Count(open+"\n"+closing)-1, func(part string) string {
+			return open + "\n" + part + "\n" + closing
+		})
+	case blockTable:
+		// 每一段都重复表头和分隔行
+		head := strings.Join(b.lines[:2], "\n")
+		return s.packLines(b.lines[2:], s.chunkSize-s.tok.Count(head)-1, func(part string) string {
+			return head + "\n" + part
+		})
+	case blockList:
+		// 在顶层列表项之间切分
+		var items []string
+		for i, l := range b.lines {
+			if i == 0 || (listItemRe.MatchString(l) && !startsWithSpace(l)) {
+				items = append(items, l)
+			} else {
+				items[len(items)-1] += "\n" + l
+			}
+		}
+		return s.packLines(items, s.chunkSize, nil)
+	}
+	return s.packLines(b.lines, s.chunkSize, nil)
+}
+
+// 将多行合并为不超过limit个token的多段，单行超过limit时按字符切分，wrap用于给每一段加上前后缀
+func (s *MarkdownSplitter) packLines(lines []string, limit int, wrap func(string) string) []string {
+	limit = max(limit, 1)
+	var res, cur []string
+	n := 0
+	flush := func() {
+		if len(cur) == 0 {
+			return
+		}
+		part := strings.Join(cur, "\n")
+		if wrap != nil {
+			part = wrap(part)
+		}
+		res = append(res, part)
+		cur, n = cur[:0], 0
+	}
+	for _, l := range lines {
+		c := s.tok.Count(l)
+		if c > limit {
+			flush()
+			for _, p := range tokenizer.Split(s.tok, l, limit) {
+				cur = append(cur, p)
+				flush()
+			}
+			continue
+		}
+		if n > 0 && n+c+1 > limit {
+			flush()
+		}
+		cur = append(cur, l)
+		n += c + 1
+	}
+	flush()
+	return res
+}
@@ -0,0 +1,69 @@
+package splitter
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+func TestMarkdownSplitter(t *testing.T) {
+	content := "# 指南\n\n## 安装\n\n运行下面的命令。\n\n```sh\n# 不是标题\nmake install\n```\n\n" +
+		"#### 参数\n\n| 名称 | 说明 |\n| --- | --- |\n| a | 第一个 |\n\n- 第一项\n\n- 第二项\n  续行\n"
+	docs, err := NewMarkdownSplitter(nil).Transform(context.Background(), []*schema.Document{{
+		Content:  content,
+		MetaData: map[string]any{"_extension": ".md"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 2 {
+		for _, d := range docs {
+			t.Logf("%v %q", d.MetaData, d.Content)
+		}
+		t.Fatalf("got %d docs, want 2", len(docs))
+	}
+	if docs[0].MetaData["h1"] != "指南" || docs[0].MetaData["h2"] != "安装" ||
+		docs[0].Content != "## 安装\n\n运行下面的命令。\n\n```sh\n# 不是标题\nmake install\n```" {
+		t.Errorf("first doc = %v %q", docs[0].MetaData, docs[0].Content)
+	}
+	if docs[1].MetaData["h4"] != "参数" || docs[1].MetaData["h2"] != "安装" ||
+		!strings.HasSuffix(docs[1].Content, "- 第一项\n\n- 第二项\n  续行") {
+		t.Errorf("second doc = %v %q", docs[1].MetaData, docs[1].Content)
+	}
+}
+
+func TestMarkdownSplitterOversized(t *testing.T) {
+	var code, table strings.Builder
+	code.WriteString("```go\n")
+	table.WriteString("| id | name |\n| --- | --- |\n")
+	for i := 0; i < 20; i++ {
+		code.WriteString("fmt.Println(\"line\")\n")
+		table.WriteString("| 1 | abcdefghij |\n")
+	}
+	code.WriteString("```")
+	docs, err := NewMarkdownSplitter(&MarkdownConfig{ChunkSize: 120, Tokenizer: byteCounter{}}).Transform(context.Background(), []*schema.Document{{
+		Content: code.String() + "\n\n" + table.String(),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fences, tables int
+	for _, d := range docs {
+		if len(d.Content) > 120 {
+			t.Errorf("chunk too large: %d", len(d.Content))
+		}
+		switch {
+		case strings.HasPrefix(d.Content, "```go\n") && strings.HasSuffix(d.Content, "\n```"):
+			fences++
+		case strings.HasPrefix(d.Content, "| id | name |\n| --- | --- |\n| 1"):
+			tables++
+		default:
+			t.Errorf("broken chunk %q", d.Content)
+		}
+	}
+	if fences < 2 || tables < 2 {
+		t.Errorf("fences = %d, tables = %d", fences, tables)
+	}
+}
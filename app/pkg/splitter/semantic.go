@@ -0,0 +1,230 @@
+package splitter
+
+import (
+	"context"
+	"math"
+	"ragx/app/pkg/tokenizer"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// 默认在相邻句子的相似度低于第5百分位时切分
+	DefaultBreakpointPercentile = 5
+	// 默认文档块的大小下限，token数，小于下限时不在语义断点切分
+	DefaultSemanticMinSize = 64
+	// 默认文档块的大小上限，token数
+	DefaultSemanticMaxSize = 512
+	// 默认每次向量化的句子数
+	DefaultEmbedBatchSize = 16
+)
+
+// SemanticConfig 语义分割器配置
+type SemanticConfig struct {
+	// 向量化句子的嵌入器，必填
+	Embedder embedding.Embedder
+	// 计算token数的分词器，为空时使用估算
+	Tokenizer tokenizer.Tokenizer
+	// 句子的分隔符，分隔符保留在句子末尾
+	Separators []string
+	// 相邻句子的相似度低于所有相似度的这个百分位时切分，取值0到100，<=0时使用DefaultBreakpointPercentile
+	BreakpointPercentile float64
+	// 文档块的大小下限和上限，token数，<=0时使用默认值
+	MinSize int
+	MaxSize int
+	// 每次向量化的句子数，<=0时使用DefaultEmbedBatchSize
+	BatchSize int
+}
+
+// SemanticSplitter 按语义分割文档，避免把同一个话题切开
+// 先按分隔符拆分为句子并分批向量化，在相邻句子的相似度明显下降的位置切分，文档块的大小保持在上下限之间
+type SemanticSplitter struct {
+	embedder   embedding.Embedder
+	tok        tokenizer.Tokenizer
+	separators []string
+	percentile float64
+	minSize    int
+	maxSize    int
+	batchSize  int
+}
+
+func NewSemanticSplitter(conf *SemanticConfig) (*SemanticSplitter, error) {
+	if conf == nil || conf.Embedder == nil {
+		return nil, gerror.New("semantic splitter requires an embedder")
+	}
+	s := &SemanticSplitter{
+		embedder:   conf.Embedder,
+		tok:        conf.Tokenizer,
+		separators: conf.Separators,
+		percentile: conf.BreakpointPercentile,
+		minSize:    conf.MinSize,
+		maxSize:    conf.MaxSize,
+		batchSize:  conf.BatchSize,
+	}
+	if s.tok == nil {
+		s.tok = tokenizer.NewEstimator()
+	}
+	if len(s.separators) == 0 {
+		s.separators = []string{"\n", "。", "?", "？", "!", "！"}
+	}
+	if s.percentile <= 0 {
+		s.percentile = DefaultBreakpointPercentile
+	}
+	s.percentile = min(s.percentile, 100)
+	if s.maxSize <= 0 {
+		s.maxSize = DefaultSemanticMaxSize
+	}
+	if s.minSize <= 0 {
+		s.minSize = DefaultSemanticMinSize
+	}
+	s.minSize = min(s.minSize, s.maxSize)
+	if s.batchSize <= 0 {
+		s.batchSize = DefaultEmbedBatchSize
+	}
+	return s, nil
+}
+
+func (s *SemanticSplitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	var res []*schema.Document
+	for _, doc := range docs {
+		chunks, err := s.split(ctx, doc.Content)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range chunks {
+			meta := make(map[string]any, len(doc.MetaData))
+			for k, v := range doc.MetaData {
+				meta[k] = v
+			}
+			res = append(res, &schema.Document{Content: c, MetaData: meta})
+		}
+	}
+	return res, nil
+}
+
+// 分割一个文档的内容
+func (s *SemanticSplitter) split(ctx context.Context, content string) ([]string, error) {
+	sentences := s.sentences(content)
+	if len(sentences) == 0 {
+		return nil, nil
+	}
+	if len(sentences) == 1 {
+		return []string{strings.TrimSpace(sentences[0])}, nil
+	}
+	texts := make([]string, len(sentences))
+	for i, st := range sentences {
+		texts[i] = strings.TrimSpace(st)
+	}
+	vectors, err := s.embed(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	// sims[i]为第i句与第i+1句的相似度
+	sims := make([]float64, len(vectors)-1)
+	for i := range sims {
+		sims[i] = cosine(vectors[i], vectors[i+1])
+	}
+	threshold := percentile(sims, s.percentile)
+
+	var chunks []string
+	var cur strings.Builder
+	size := 0
+	flush := func() {
+		if c := strings.TrimSpace(cur.String()); c != "" {
+			chunks = append(chunks, c)
+		}
+		cur.Reset()
+		size = 0
+	}
+	for i, st := range sentences {
+		n := s.tok.Count(st)
+		if size > 0 && (size+n > s.maxSize || (sims[i-1] < threshold && size >= s.minSize)) {
+			flush()
+		}
+		cur.WriteString(st)
+		size += n
+	}
+	flush()
+	return chunks, nil
+}
+
+// 按分隔符拆分句子，分隔符保留在句子末尾，只有空白的句子并入前一句，超过大小上限的句子按字符切分
+func (s *SemanticSplitter) sentences(content string) []string {
+	var res []string
+	add := func(st string) {
+		if strings.TrimSpace(st) == "" {
+			if len(res) > 0 {
+				res[len(res)-1] += st
+			}
+			return
+		}
+		if s.tok.Count(st) > s.maxSize {
+			res = append(res, tokenizer.Split(s.tok, st, s.maxSize)...)
+			return
+		}
+		res = append(res, st)
+	}
+	start := 0
+	for i := 0; i < len(content); i++ {
+		for _, sep := range s.separators {
+			if sep != "" && strings.HasPrefix(content[i:], sep) {
+				i += len(sep) - 1
+				add(content[start : i+1])
+				start = i + 1
+				break
+			}
+		}
+	}
+	if start < len(content) {
+		add(content[start:])
+	}
+	return res
+}
+
+// 分批向量化
+func (s *SemanticSplitter) embed(ctx context.Context, texts []string) ([][]float64, error) {
+	res := make([][]float64, 0, len(texts))
+	for i := 0; i < len(texts); i += s.batchSize {
+		batch := texts[i:min(i+s.batchSize, len(texts))]
+		vectors, err := s.embedder.EmbedStrings(ctx, batch)
+		if err != nil {
+			return nil, gerror.Wrap(err, "embed sentences")
+		}
+		if len(vectors) != len(batch) {
+			return nil, gerror.Newf("embed sentences: got %d vectors, want %d", len(vectors), len(batch))
+		}
+		res = append(res, vectors...)
+	}
+	return res, nil
+}
+
+// 余弦相似度，向量为零时返回0
+func cosine(a, b []float64) float64 {
+	var dot, na, nb float64
+	for i := 0; i < len(a) && i < len(b); i++ {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(na*nb)
+}
+
+// 第p百分位的值，使用线性插值
+func percentile(values []float64, p float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	pos := p / 100 * float64(len(sorted)-1)
+	i := int(pos)
+	if i+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[i] + (sorted[i+1]-sorted[i])*(pos-float64(i))
+}
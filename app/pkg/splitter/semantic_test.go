@@ -0,0 +1,77 @@
+package splitter
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+)
+
+// 按话题关键词生成向量的嵌入器，记录每次调用的句子数
+type topicEmbedder struct {
+	batches []int
+}
+
+func (e *topicEmbedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	e.batches = append(e.batches, len(texts))
+	res := make([][]float64, len(texts))
+	for i, t := range texts {
+		switch {
+		case strings.Contains(t, "猫"):
+			res[i] = []float64{1, 0.1, 0}
+		case strings.Contains(t, "股票"):
+			res[i] = []float64{0, 1, 0.1}
+		default:
+			res[i] = []float64{0.1, 0, 1}
+		}
+	}
+	return res, nil
+}
+
+func TestSemanticSplitter(t *testing.T) {
+	emb := &topicEmbedder{}
+	s, err := NewSemanticSplitter(&SemanticConfig{Embedder: emb, MinSize: 1, BreakpointPercentile: 50, BatchSize: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := "猫喜欢睡觉。猫喜欢吃鱼。猫很可爱！股票上涨了。股票下跌了。股票很难预测？"
+	docs, err := s.Transform(context.Background(), []*schema.Document{{
+		Content:  content,
+		MetaData: map[string]any{"_source": "a.txt"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("got %d docs, want 2: %v", len(docs), docs)
+	}
+	if docs[0].Content != "猫喜欢睡觉。猫喜欢吃鱼。猫很可爱！" || docs[1].Content != "股票上涨了。股票下跌了。股票很难预测？" {
+		t.Errorf("unexpected chunks %q, %q", docs[0].Content, docs[1].Content)
+	}
+	if docs[1].MetaData["_source"] != "a.txt" {
+		t.Errorf("meta = %v", docs[1].MetaData)
+	}
+	if len(emb.batches) != 2 || emb.batches[0] != 4 || emb.batches[1] != 2 {
+		t.Errorf("batches = %v", emb.batches)
+	}
+}
+
+func TestSemanticSplitterMaxSize(t *testing.T) {
+	s, err := NewSemanticSplitter(&SemanticConfig{Embedder: &topicEmbedder{}, MinSize: 1, MaxSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs, err := s.Transform(context.Background(), []*schema.Document{{Content: strings.Repeat("猫喜欢睡觉。", 6)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 所有句子的话题相同，只按大小上限切分
+	if len(docs) != 6 {
+		t.Fatalf("got %d docs, want 6", len(docs))
+	}
+	if _, err = NewSemanticSplitter(&SemanticConfig{}); err == nil {
+		t.Error("expected error without embedder")
+	}
+}
@@ -0,0 +1,31 @@
+package tokenizer
+
+import (
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/pkoukk/tiktoken-go"
+)
+
+// DefaultEncoding BPE默认使用的编码
+const DefaultEncoding = "cl100k_base"
+
+// BPE 使用tiktoken的BPE词表计算token数
+// 词表首次使用时下载并缓存到TIKTOKEN_CACHE_DIR目录，离线部署时需要预先放入该目录
+type BPE struct {
+	enc *tiktoken.Tiktoken
+}
+
+// NewBPE encoding为词表的编码名称，例如cl100k_base、o200k_base，为空时使用DefaultEncoding
+func NewBPE(encoding string) (*BPE, error) {
+	if encoding == "" {
+		encoding = DefaultEncoding
+	}
+	enc, err := tiktoken.GetEncoding(encoding)
+	if err != nil {
+		return nil, gerror.Wrapf(err, "load bpe encoding %s", encoding)
+	}
+	return &BPE{enc: enc}, nil
+}
+
+func (b *BPE) Count(text string) int {
+	return len(b.enc.EncodeOrdinary(text))
+}
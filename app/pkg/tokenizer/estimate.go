@@ -0,0 +1,51 @@
+package tokenizer
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Estimator 按字符类别估算token数，不需要词表，结果与BPE词表的计数接近
+// 中日韩文字、标点和符号每个字符约1个token，其它文字按单词计，每个单词约1个token，较长的单词每6个字节多1个token，
+// 连续的换行约1个token
+type Estimator struct{}
+
+func NewEstimator() *Estimator {
+	return &Estimator{}
+}
+
+func (e *Estimator) Count(text string) int {
+	n, word := 0, 0
+	newline := false
+	flush := func() {
+		if word > 0 {
+			n += 1 + (word-1)/6
+		}
+		word = 0
+	}
+	for _, r := range text {
+		if r != '\n' {
+			newline = false
+		}
+		switch {
+		case r == '\n':
+			flush()
+			if !newline {
+				n++
+			}
+			newline = true
+		case unicode.IsSpace(r):
+			flush()
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			flush()
+			n++
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r):
+			word += utf8.RuneLen(r)
+		default:
+			flush()
+			n++
+		}
+	}
+	flush()
+	return n
+}
@@ -0,0 +1,90 @@
+package tokenizer
+
+import (
+	"unicode/utf8"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// 按字符类别估算token数，不需要词表
+	KindEstimate = "estimate"
+	// 使用BPE词表精确计算token数
+	KindBPE = "bpe"
+)
+
+// Tokenizer 计算文本的token数，文档块的大小和重叠都以token计，避免中英文文本的块大小相差太多
+type Tokenizer interface {
+	// Count 文本的token数
+	Count(text string) int
+}
+
+// New 根据类型创建分词器，kind为空时使用估算，encoding为BPE词表的编码名称
+func New(kind, encoding string) (Tokenizer, error) {
+	switch kind {
+	case "", KindEstimate:
+		return NewEstimator(), nil
+	case KindBPE:
+		return NewBPE(encoding)
+	}
+	return nil, gerror.Newf("unsupported tokenizer: %s", kind)
+}
+
+// Split 将文本切分为每段不超过limit个token的多段，只在字符边界切分
+func Split(t Tokenizer, text string, limit int) []string {
+	limit = max(limit, 1)
+	if t.Count(text) <= limit {
+		return []string{text}
+	}
+	var res []string
+	for text != "" {
+		n := prefixLen(t, text, limit)
+		res = append(res, text[:n])
+		text = text[n:]
+	}
+	return res
+}
+
+// Truncate 截取文本开头不超过limit个token的部分，只在字符边界截断，至少保留一个字符
+func Truncate(t Tokenizer, text string, limit int) string {
+	if text == "" {
+		return ""
+	}
+	return text[:prefixLen(t, text, max(limit, 1))]
+}
+
+// 不超过limit个token的最长前缀的字节数，至少包含一个字符，text不能为空
+// 从limit个字符开始按指数扩大窗口找到上界，再在窗口内二分查找，计算量只与前缀的长度相关，而不是整个文本的长度
+func prefixLen(t Tokenizer, text string, limit int) int {
+	// offsets[i]为前i个字符的字节数，按需要计算
+	offsets := []int{0}
+	extend := func(n int) int {
+		for len(offsets) <= n && offsets[len(offsets)-1] < len(text) {
+			_, size := utf8.DecodeRuneInString(text[offsets[len(offsets)-1]:])
+			offsets = append(offsets, offsets[len(offsets)-1]+size)
+		}
+		return len(offsets) - 1
+	}
+	lo, hi := extend(1), 0
+	for step := limit; ; step *= 2 {
+		n := extend(step)
+		if t.Count(text[:offsets[n]]) > limit {
+			hi = max(n-1, lo)
+			break
+		}
+		lo = n
+		if n < step {
+			// 剩余的文本都不超过limit
+			return len(text)
+		}
+	}
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if t.Count(text[:offsets[mid]]) <= limit {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return offsets[lo]
+}
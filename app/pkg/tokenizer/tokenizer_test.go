@@ -0,0 +1,118 @@
+package tokenizer
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestEstimatorCount(t *testing.T) {
+	e := NewEstimator()
+	cases := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"hello world", 2},
+		{"internationalization", 4},
+		{"你好，世界", 5},
+		{"a\n\n\nb", 3},
+		{"Go语言", 3},
+	}
+	for _, c := range cases {
+		if got := e.Count(c.text); got != c.want {
+			t.Errorf("Count(%q) = %d, want %d", c.text, got, c.want)
+		}
+	}
+	// 同样长度的中文比英文的token多
+	if e.Count(strings.Repeat("中", 100)) <= e.Count(strings.Repeat("a", 100)) {
+		t.Error("cjk should count more tokens than latin of the same length")
+	}
+}
+
+func TestSplit(t *testing.T) {
+	e := NewEstimator()
+	text := strings.Repeat("知识库检索", 20)
+	parts := Split(e, text, 30)
+	if len(parts) != 4 {
+		t.Fatalf("got %d parts, want 4", len(parts))
+	}
+	if strings.Join(parts, "") != text {
+		t.Error("parts do not join to the original text")
+	}
+	for _, p := range parts {
+		if e.Count(p) > 30 {
+			t.Errorf("part too large: %d", e.Count(p))
+		}
+	}
+	if got := Split(e, "short", 30); len(got) != 1 || got[0] != "short" {
+		t.Errorf("Split(short) = %q", got)
+	}
+}
+
+// 按字符计数，并记录累计计算过的字符数
+type runeCounter struct {
+	scanned int
+}
+
+func (c *runeCounter) Count(text string) int {
+	n := utf8.RuneCountInString(text)
+	c.scanned += n
+	return n
+}
+
+func TestSplitLinear(t *testing.T) {
+	text := strings.Repeat("知识库检索增强生成", 10000)
+	for _, limit := range []int{1, 7, 100, 1000} {
+		c := &runeCounter{}
+		parts := Split(c, text, limit)
+		n := utf8.RuneCountInString(text)
+		if len(parts) != (n+limit-1)/limit || strings.Join(parts, "") != text {
+			t.Fatalf("limit %d: got %d parts", limit, len(parts))
+		}
+		for _, p := range parts[:len(parts)-1] {
+			if utf8.RuneCountInString(p) != limit {
+				t.Fatalf("limit %d: part of %d runes", limit, utf8.RuneCountInString(p))
+			}
+		}
+		// 每段只在不超过两倍段长的窗口内查找，总计算量是文本长度的对数倍，而不是平方
+		if c.scanned > 40*n {
+			t.Errorf("limit %d: scanned %d runes for text of %d", limit, c.scanned, n)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	e := NewEstimator()
+	if got := Truncate(e, "short", 30); got != "short" {
+		t.Errorf("Truncate(short) = %q", got)
+	}
+	if got := Truncate(e, "", 30); got != "" {
+		t.Errorf("Truncate(empty) = %q", got)
+	}
+	text := strings.Repeat("知识库检索", 20)
+	if got := Truncate(e, text, 30); got != Split(e, text, 30)[0] {
+		t.Errorf("Truncate() = %q", got)
+	}
+	// 至少保留一个字符
+	if got := Truncate(e, "知识库", 0); got != "知" {
+		t.Errorf("Truncate(limit 0) = %q", got)
+	}
+	// 只计算开头的部分，与文本的总长度无关
+	c := &runeCounter{}
+	if got := Truncate(c, strings.Repeat("知", 1000000), 100); utf8.RuneCountInString(got) != 100 {
+		t.Errorf("Truncate() = %d runes", utf8.RuneCountInString(got))
+	}
+	if c.scanned > 2000 {
+		t.Errorf("scanned %d runes", c.scanned)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if tok, err := New("", ""); err != nil || tok == nil {
+		t.Fatalf("New(\"\") = %v, %v", tok, err)
+	}
+	if _, err := New("unknown", ""); err == nil {
+		t.Error("expected error for unknown tokenizer")
+	}
+}
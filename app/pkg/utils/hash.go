@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// SHA256Hex 计算数据的sha256，返回16进制字符串
+func SHA256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// FileSHA256 计算文件内容的sha256，返回16进制字符串
+func FileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", gerror.Wrap(err, "")
+	}
+	defer f.Close()
+	return ReaderSHA256(f)
+}
+
+// ReaderSHA256 计算读取到的全部内容的sha256，返回16进制字符串
+func ReaderSHA256(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", gerror.Wrap(err, "")
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}